@@ -61,6 +61,9 @@ func main() {
 	gitDir := ""
 	flaggy.String(&gitDir, "g", "git-dir", "equivalent of the --git-dir git argument")
 
+	scriptPath := ""
+	flaggy.String(&scriptPath, "s", "script", "Run the actions listed in the given YAML/JSON file against the repo without opening the gui, then exit. See docs/Scripting.md")
+
 	flaggy.Parse()
 
 	if repoPath != "" {
@@ -123,6 +126,15 @@ func main() {
 
 	app, err := app.NewApp(appConfig, filterPath)
 
+	if err == nil && scriptPath != "" {
+		// errors from a script are about the script's steps rather than bugs in
+		// lazygit, so we don't want to print a stack trace for them
+		if err := app.RunScript(scriptPath); err != nil {
+			log.Fatal(err.Error())
+		}
+		os.Exit(0)
+	}
+
 	if err == nil {
 		err = app.Run()
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/env"
 	"github.com/jesseduffield/lazygit/pkg/gui"
 	"github.com/jesseduffield/lazygit/pkg/i18n"
+	"github.com/jesseduffield/lazygit/pkg/script"
 	"github.com/jesseduffield/lazygit/pkg/updates"
 	"github.com/sirupsen/logrus"
 	"io"
@@ -233,6 +234,10 @@ func (app *App) Run() error {
 		return app.Rebase()
 	}
 
+	if app.ClientContext == oscommands.ASKPASS_CLIENT_COMMAND {
+		return app.Askpass()
+	}
+
 	if app.ClientContext == "EXIT_IMMEDIATELY" {
 		os.Exit(0)
 	}
@@ -241,6 +246,17 @@ func (app *App) Run() error {
 	return err
 }
 
+// RunScript runs the actions in the given script file against the repo without
+// showing the gui
+func (app *App) RunScript(path string) error {
+	steps, err := script.LoadScript(path)
+	if err != nil {
+		return err
+	}
+
+	return app.Gui.RunScript(steps, os.Stdout)
+}
+
 func gitDir() string {
 	dir := env.GetGitDirEnv()
 	if dir == "" {
@@ -270,6 +286,27 @@ func (app *App) Rebase() error {
 	return nil
 }
 
+// Askpass contains logic for when git or ssh have run us as their askpass
+// program to ask the user for a credential. The prompt is passed on to the
+// lazygit instance that ran the command and the answer printed for git or ssh
+// to read.
+func (app *App) Askpass() error {
+	app.Log.Info("Lazygit invoked as askpass demon")
+
+	prompt := ""
+	if len(os.Args) > 1 {
+		prompt = os.Args[1]
+	}
+
+	if err := oscommands.RunAskpassClient(prompt, os.Stdout); err != nil {
+		// git and ssh just need a non-zero exit code to know that there's no answer
+		app.Log.Error(err)
+		os.Exit(1)
+	}
+
+	return nil
+}
+
 // Close closes any resources
 func (app *App) Close() error {
 	for _, closer := range app.closers {
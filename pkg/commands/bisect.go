@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// GetBisectInfo reads the state of the current bisect from the .git directory.
+// If we're not bisecting, the returned info will not be started.
+func (c *GitCommand) GetBisectInfo() *models.BisectInfo {
+	info := models.NewBisectInfo()
+
+	start := c.readBisectFile("BISECT_START")
+	if start == "" {
+		return info
+	}
+	info.Start = start
+
+	// BISECT_TERMS holds the new term on the first line and the old term on the second
+	terms := utils.SplitLines(c.readBisectFile("BISECT_TERMS"))
+	if len(terms) == 2 {
+		info.NewTerm = terms[0]
+		info.OldTerm = terms[1]
+	}
+
+	info.Current = c.readBisectFile("BISECT_EXPECTED_REV")
+
+	log, err := c.RunCommandWithOutput("git bisect log")
+	if err != nil {
+		c.Log.Error(err)
+		return info
+	}
+	parseBisectLog(info, log)
+
+	if info.Bisecting() {
+		candidates, err := c.getBisectCandidateCount(info)
+		if err != nil {
+			c.Log.Error(err)
+		} else {
+			info.Candidates = candidates
+		}
+	}
+
+	return info
+}
+
+func (c *GitCommand) readBisectFile(name string) string {
+	content, err := ioutil.ReadFile(filepath.Join(c.DotGitDir, name))
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(content))
+}
+
+// parseBisectLog fills in the marked commits from the output of `git bisect log`, which looks like:
+// git bisect start
+// # bad: [f17ed65d44f8921d5dd504929b3724d943b9ceea] my commit
+// git bisect bad f17ed65d44f8921d5dd504929b3724d943b9ceea
+// # good: [3caaf5f3b86e9faf2b1b7e86542919d09b704a9c] my other commit
+// git bisect good 3caaf5f3b86e9faf2b1b7e86542919d09b704a9c
+// # first bad commit: [f17ed65d44f8921d5dd504929b3724d943b9ceea] my commit
+func parseBisectLog(info *models.BisectInfo, log string) {
+	firstNewPrefix := fmt.Sprintf("# first %s commit: [", info.NewTerm)
+
+	for _, line := range utils.SplitLines(log) {
+		if strings.HasPrefix(line, firstNewPrefix) {
+			info.FirstNewSha = strings.SplitN(strings.TrimPrefix(line, firstNewPrefix), "]", 2)[0]
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 4 || fields[0] != "git" || fields[1] != "bisect" {
+			continue
+		}
+
+		var status models.BisectStatus
+		switch fields[2] {
+		case info.NewTerm:
+			status = models.BisectStatusNew
+		case info.OldTerm:
+			status = models.BisectStatusOld
+		case "skip":
+			status = models.BisectStatusSkipped
+		default:
+			continue
+		}
+
+		for _, sha := range fields[3:] {
+			info.StatusMap[sha] = status
+
+			switch status {
+			case models.BisectStatusNew:
+				info.NewSha = sha
+			case models.BisectStatusOld:
+				info.OldShas = append(info.OldShas, sha)
+			}
+		}
+	}
+}
+
+// getBisectCandidateCount returns the number of commits which could still turn
+// out to be the first new commit: those reachable from the new commit but not
+// from any old commit, minus the ones we've skipped
+func (c *GitCommand) getBisectCandidateCount(info *models.BisectInfo) (int, error) {
+	output, err := c.RunCommandWithOutput("git rev-list %s --not %s", info.NewSha, strings.Join(info.OldShas, " "))
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, sha := range utils.SplitLines(output) {
+		if info.Status(sha) != models.BisectStatusSkipped {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (c *GitCommand) StartBisect() error {
+	return c.RunCommand("git bisect start")
+}
+
+// MarkBisectCommit marks a commit with the given term e.g. 'bad', 'good', or 'skip'.
+// Once git knows of both a new and an old commit, it checks out the next commit to test.
+func (c *GitCommand) MarkBisectCommit(ref string, term string) error {
+	return c.RunCommand("git bisect %s %s", term, ref)
+}
+
+func (c *GitCommand) ResetBisect() error {
+	return c.RunCommand("git bisect reset")
+}
@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/secureexec"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseBisectLog is a function.
+func TestParseBisectLog(t *testing.T) {
+	type scenario struct {
+		testName string
+		newTerm  string
+		oldTerm  string
+		log      string
+		test     func(*models.BisectInfo)
+	}
+
+	scenarios := []scenario{
+		{
+			"Nothing marked yet",
+			"bad",
+			"good",
+			"git bisect start\n# status: waiting for both good and bad commits\n",
+			func(info *models.BisectInfo) {
+				assert.Empty(t, info.StatusMap)
+				assert.Equal(t, "", info.NewSha)
+				assert.Empty(t, info.OldShas)
+				assert.False(t, info.Finished())
+			},
+		},
+		{
+			"Marked and skipped commits",
+			"bad",
+			"good",
+			"git bisect start\n" +
+				"# bad: [f17ed65d] c8\n" +
+				"git bisect bad f17ed65d\n" +
+				"# good: [3caaf5f3] c1\n" +
+				"git bisect good 3caaf5f3\n" +
+				"# skip: [ae0fff76] c4\n" +
+				"git bisect skip ae0fff76\n" +
+				"# good: [0495a2f4] c5\n" +
+				"git bisect good 0495a2f4\n",
+			func(info *models.BisectInfo) {
+				assert.EqualValues(t, map[string]models.BisectStatus{
+					"f17ed65d": models.BisectStatusNew,
+					"3caaf5f3": models.BisectStatusOld,
+					"ae0fff76": models.BisectStatusSkipped,
+					"0495a2f4": models.BisectStatusOld,
+				}, info.StatusMap)
+				assert.Equal(t, "f17ed65d", info.NewSha)
+				assert.EqualValues(t, []string{"3caaf5f3", "0495a2f4"}, info.OldShas)
+				assert.False(t, info.Finished())
+			},
+		},
+		{
+			"Finished with custom terms",
+			"fixed",
+			"broken",
+			"git bisect start '--term-new=fixed' '--term-old=broken'\n" +
+				"# fixed: [f17ed65d] c8\n" +
+				"git bisect fixed f17ed65d\n" +
+				"# broken: [b8464fd1] c7\n" +
+				"git bisect broken b8464fd1\n" +
+				"# first fixed commit: [f17ed65d] c8\n",
+			func(info *models.BisectInfo) {
+				assert.Equal(t, models.BisectStatusNew, info.Status("f17ed65d"))
+				assert.Equal(t, models.BisectStatusOld, info.Status("b8464fd1"))
+				assert.Equal(t, models.BisectStatusNone, info.Status("0495a2f4"))
+				assert.Equal(t, "f17ed65d", info.FirstNewSha)
+				assert.True(t, info.Finished())
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			info := models.NewBisectInfo()
+			info.NewTerm = s.newTerm
+			info.OldTerm = s.oldTerm
+
+			parseBisectLog(info, s.log)
+			s.test(info)
+		})
+	}
+}
+
+// TestGitCommandGetBisectCandidateCount is a function.
+func TestGitCommandGetBisectCandidateCount(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.Command = func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"rev-list", "f17ed65d", "--not", "3caaf5f3", "0495a2f4"}, args)
+
+		return secureexec.Command("echo", "f17ed65d\nb8464fd1\nae0fff76\n08adf19f")
+	}
+
+	info := models.NewBisectInfo()
+	info.NewSha = "f17ed65d"
+	info.OldShas = []string{"3caaf5f3", "0495a2f4"}
+	info.StatusMap["ae0fff76"] = models.BisectStatusSkipped
+
+	count, err := gitCmd.getBisectCandidateCount(info)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+}
+
+// TestGitCommandMarkBisectCommit is a function.
+func TestGitCommandMarkBisectCommit(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.Command = func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"bisect", "good", "3caaf5f3"}, args)
+
+		return secureexec.Command("echo")
+	}
+
+	assert.NoError(t, gitCmd.MarkBisectCommit("3caaf5f3", "good"))
+}
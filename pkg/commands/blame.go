@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+)
+
+// GetBlame returns the lines of the file at the given ref along with the
+// commits that last changed them. An empty ref means the working tree.
+func (c *GitCommand) GetBlame(path string, ref string) ([]*models.BlameLine, error) {
+	refArg := ""
+	if ref != "" {
+		refArg = " " + ref
+	}
+
+	output, err := c.RunCommandWithOutput("git blame --porcelain%s -- %s", refArg, c.OSCommand.Quote(path))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseBlamePorcelain(output), nil
+}
+
+// parseBlamePorcelain parses the output of `git blame --porcelain`. Each line
+// of the file comes after a header giving the sha of its commit and its line
+// numbers. The details of a commit are only given the first time it appears.
+func parseBlamePorcelain(output string) []*models.BlameLine {
+	outputLines := strings.Split(output, "\n")
+	commits := map[string]*models.BlameLine{}
+	blameLines := []*models.BlameLine{}
+
+	for i := 0; i < len(outputLines); i++ {
+		header := strings.Fields(outputLines[i])
+		if len(header) < 3 {
+			continue
+		}
+		sha := header[0]
+		originalLineNumber, err := strconv.Atoi(header[1])
+		if err != nil {
+			continue
+		}
+
+		commit, ok := commits[sha]
+		if !ok {
+			commit = &models.BlameLine{Sha: sha}
+			commits[sha] = commit
+		}
+
+		for i++; i < len(outputLines) && !strings.HasPrefix(outputLines[i], "\t"); i++ {
+			split := strings.SplitN(outputLines[i], " ", 2)
+			value := ""
+			if len(split) == 2 {
+				value = split[1]
+			}
+
+			switch split[0] {
+			case "author":
+				commit.Author = value
+			case "author-time":
+				commit.AuthorTime, _ = strconv.ParseInt(value, 10, 64)
+			case "summary":
+				commit.Summary = value
+			case "previous":
+				previous := strings.SplitN(value, " ", 2)
+				if len(previous) == 2 {
+					commit.PreviousSha = previous[0]
+					commit.PreviousPath = previous[1]
+				}
+			}
+		}
+
+		if i == len(outputLines) {
+			break
+		}
+
+		blameLine := *commit
+		blameLine.OriginalLineNumber = originalLineNumber
+		blameLine.Content = outputLines[i][1:]
+		blameLines = append(blameLines, &blameLine)
+	}
+
+	return blameLines
+}
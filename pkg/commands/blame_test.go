@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/secureexec"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandGetBlame is a function.
+func TestGitCommandGetBlame(t *testing.T) {
+	type scenario struct {
+		testName     string
+		ref          string
+		expectedArgs []string
+		output       string
+		expected     []*models.BlameLine
+	}
+
+	porcelain := `a3a0eb9f2d43a85f3e6c6f0a9c06fa3bd1d4e2a1 1 1 2
+author Jesse Duffield
+author-time 1609459200
+summary add greeting
+previous 9c1f3b7e0e0a7c3e1f9a1b8c4d2e6f5a3b7c9d0e old.go
+filename main.go
+	package main
+a3a0eb9f2d43a85f3e6c6f0a9c06fa3bd1d4e2a1 3 2
+	
+0000000000000000000000000000000000000000 3 3 1
+author Not Committed Yet
+author-time 1612137600
+summary Version of main.go from main.go
+filename main.go
+	func main() {}
+`
+
+	scenarios := []scenario{
+		{
+			"Working tree",
+			"",
+			[]string{"blame", "--porcelain", "--", "main.go"},
+			porcelain,
+			[]*models.BlameLine{
+				{
+					Sha:                "a3a0eb9f2d43a85f3e6c6f0a9c06fa3bd1d4e2a1",
+					Author:             "Jesse Duffield",
+					AuthorTime:         1609459200,
+					Summary:            "add greeting",
+					PreviousSha:        "9c1f3b7e0e0a7c3e1f9a1b8c4d2e6f5a3b7c9d0e",
+					PreviousPath:       "old.go",
+					OriginalLineNumber: 1,
+					Content:            "package main",
+				},
+				{
+					Sha:                "a3a0eb9f2d43a85f3e6c6f0a9c06fa3bd1d4e2a1",
+					Author:             "Jesse Duffield",
+					AuthorTime:         1609459200,
+					Summary:            "add greeting",
+					PreviousSha:        "9c1f3b7e0e0a7c3e1f9a1b8c4d2e6f5a3b7c9d0e",
+					PreviousPath:       "old.go",
+					OriginalLineNumber: 3,
+					Content:            "",
+				},
+				{
+					Sha:                "0000000000000000000000000000000000000000",
+					Author:             "Not Committed Yet",
+					AuthorTime:         1612137600,
+					Summary:            "Version of main.go from main.go",
+					OriginalLineNumber: 3,
+					Content:            "func main() {}",
+				},
+			},
+		},
+		{
+			"At a commit",
+			"a3a0eb9f",
+			[]string{"blame", "--porcelain", "a3a0eb9f", "--", "main.go"},
+			"",
+			[]*models.BlameLine{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.Command = func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				assert.EqualValues(t, s.expectedArgs, args)
+
+				return secureexec.Command("printf", "%s", s.output)
+			}
+
+			blameLines, err := gitCmd.GetBlame("main.go", s.ref)
+			assert.NoError(t, err)
+			assert.EqualValues(t, s.expected, blameLines)
+		})
+	}
+}
@@ -5,13 +5,21 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
 // NewBranch create new branch
 func (c *GitCommand) NewBranch(name string, base string) error {
-	return c.RunCommand("git checkout -b %s %s", name, base)
+	getOperation := func() (*models.Operation, error) {
+		sha, err := c.refSha(base)
+		return &models.Operation{Kind: models.OPERATION_CREATE_BRANCH, Name: name, Sha: sha}, err
+	}
+
+	return c.withJournal(getOperation, func() error {
+		return c.RunCommand("git checkout -b %s %s", name, base)
+	})
 }
 
 // CurrentBranchName get the current branch name and displayname.
@@ -47,7 +55,14 @@ func (c *GitCommand) DeleteBranch(branch string, force bool) error {
 		command = "git branch -D"
 	}
 
-	return c.OSCommand.RunCommand("%s %s", command, branch)
+	getOperation := func() (*models.Operation, error) {
+		sha, err := c.refSha("refs/heads/" + branch)
+		return &models.Operation{Kind: models.OPERATION_DELETE_BRANCH, Name: branch, Sha: sha}, err
+	}
+
+	return c.withJournal(getOperation, func() error {
+		return c.OSCommand.RunCommand("%s %s", command, branch)
+	})
 }
 
 // Checkout checks out a branch (or commit), with --force if you set the force arg to true
@@ -93,6 +108,21 @@ func (c *GitCommand) SetBranchUpstream(remoteName string, remoteBranchName strin
 	return c.RunCommand("git branch --set-upstream-to=%s/%s %s", remoteName, remoteBranchName, branchName)
 }
 
+// SetBranchPushRemote sets the remote we push the branch to. An empty remote
+// name unsets it so that we push to remote.pushDefault or the upstream's remote.
+func (c *GitCommand) SetBranchPushRemote(branchName string, remoteName string) error {
+	key := fmt.Sprintf("branch.%s.pushRemote", branchName)
+	if remoteName == "" {
+		if c.GetConfigValue(key) == "" {
+			// git errors if we unset a key that isn't set
+			return nil
+		}
+		return c.RunCommand("git config --unset %s", c.OSCommand.Quote(key))
+	}
+
+	return c.RunCommand("git config %s %s", c.OSCommand.Quote(key), c.OSCommand.Quote(remoteName))
+}
+
 func (c *GitCommand) GetCurrentBranchUpstreamDifferenceCount() (string, string) {
 	return c.GetCommitDifferences("HEAD", "HEAD@{u}")
 }
@@ -157,5 +187,11 @@ func (c *GitCommand) ResetMixed(ref string) error {
 }
 
 func (c *GitCommand) RenameBranch(oldName string, newName string) error {
-	return c.RunCommand("git branch --move %s %s", oldName, newName)
+	getOperation := func() (*models.Operation, error) {
+		return &models.Operation{Kind: models.OPERATION_RENAME_BRANCH, From: oldName, To: newName}, nil
+	}
+
+	return c.withJournal(getOperation, func() error {
+		return c.RunCommand("git branch --move %s %s", oldName, newName)
+	})
 }
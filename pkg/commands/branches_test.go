@@ -80,6 +80,57 @@ func TestGitCommandNewBranch(t *testing.T) {
 	assert.NoError(t, gitCmd.NewBranch("test", "master"))
 }
 
+// TestGitCommandSetBranchPushRemote is a function.
+func TestGitCommandSetBranchPushRemote(t *testing.T) {
+	type scenario struct {
+		testName         string
+		remoteName       string
+		configuredRemote string
+		expectedArgs     []string
+	}
+
+	scenarios := []scenario{
+		{
+			testName:     "Set push remote",
+			remoteName:   "origin",
+			expectedArgs: []string{"config", "branch.feature/thing.pushRemote", "origin"},
+		},
+		{
+			testName:         "Unset push remote",
+			remoteName:       "",
+			configuredRemote: "origin",
+			expectedArgs:     []string{"config", "--unset", "branch.feature/thing.pushRemote"},
+		},
+		{
+			testName:         "Unset push remote that isn't set",
+			remoteName:       "",
+			configuredRemote: "",
+			expectedArgs:     nil,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.getGitConfigValue = func(key string) (string, error) {
+				assert.EqualValues(t, "branch.feature/thing.pushRemote", key)
+				return s.configuredRemote, nil
+			}
+			var args []string
+			gitCmd.OSCommand.Command = func(cmd string, cmdArgs ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				args = cmdArgs
+
+				return secureexec.Command("echo")
+			}
+
+			assert.NoError(t, gitCmd.SetBranchPushRemote("feature/thing", s.remoteName))
+			assert.EqualValues(t, s.expectedArgs, args)
+		})
+	}
+}
+
 // TestGitCommandDeleteBranch is a function.
 func TestGitCommandDeleteBranch(t *testing.T) {
 	type scenario struct {
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"github.com/go-errors/errors"
+)
+
+// These give the URLs for viewing things on the git service the origin remote
+// is hosted on
+
+// CommitURL returns the URL for viewing the given commit
+func (c *GitCommand) CommitURL(sha string) (string, error) {
+	return c.browseURL(func(service *Service, remoteURL string) string {
+		return service.CommitURL(remoteURL, sha)
+	})
+}
+
+// BranchURL returns the URL for viewing the given branch
+func (c *GitCommand) BranchURL(branchName string) (string, error) {
+	return c.browseURL(func(service *Service, remoteURL string) string {
+		return service.BranchURL(remoteURL, branchName)
+	})
+}
+
+// TagURL returns the URL for viewing the given tag
+func (c *GitCommand) TagURL(tagName string) (string, error) {
+	return c.browseURL(func(service *Service, remoteURL string) string {
+		return service.TagURL(remoteURL, tagName)
+	})
+}
+
+// FileAtLineURL returns the URL for viewing the given file at the given branch,
+// scrolled to the given line
+func (c *GitCommand) FileAtLineURL(branchName string, path string, line int) (string, error) {
+	return c.browseURL(func(service *Service, remoteURL string) string {
+		return service.FileAtLineURL(remoteURL, branchName, path, line)
+	})
+}
+
+// CommitFileAtLineURL returns the URL for viewing the given file at the given
+// commit, scrolled to the given line
+func (c *GitCommand) CommitFileAtLineURL(sha string, path string, line int) (string, error) {
+	return c.browseURL(func(service *Service, remoteURL string) string {
+		return service.CommitFileAtLineURL(remoteURL, sha, path, line)
+	})
+}
+
+func (c *GitCommand) browseURL(getURL func(service *Service, remoteURL string) string) (string, error) {
+	remoteURL := c.GetRemoteURL()
+
+	service := getServiceForURL(getServices(c.Config), remoteURL)
+	if service == nil {
+		return "", errors.New(c.Tr.UnsupportedGitService)
+	}
+
+	url := getURL(service, remoteURL)
+	if url == "" {
+		return "", errors.New(c.Tr.ServiceURLNotConfigured)
+	}
+
+	return url, nil
+}
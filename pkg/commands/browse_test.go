@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandBrowseURLs is a function.
+func TestGitCommandBrowseURLs(t *testing.T) {
+	type scenario struct {
+		testName  string
+		remoteURL string
+		getURL    func(*GitCommand) (string, error)
+		test      func(string, error)
+	}
+
+	scenarios := []scenario{
+		{
+			"Commit on github",
+			"git@github.com:peter/calculator.git",
+			func(gitCmd *GitCommand) (string, error) {
+				return gitCmd.CommitURL("abc123")
+			},
+			func(url string, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "https://github.com/peter/calculator/commit/abc123", url)
+			},
+		},
+		{
+			"File at line on a service from the config",
+			"git@git.work.com:peter/calculator.git",
+			func(gitCmd *GitCommand) (string, error) {
+				return gitCmd.FileAtLineURL("master", "pkg/sum.go", 42)
+			},
+			func(url string, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "https://gitea.work.com/peter/calculator/src/master/pkg/sum.go#L42", url)
+			},
+		},
+		{
+			"Tag on a service without a tag URL",
+			"git@git.custom.com:peter/calculator.git",
+			func(gitCmd *GitCommand) (string, error) {
+				return gitCmd.TagURL("v1.0")
+			},
+			func(url string, err error) {
+				assert.Error(t, err)
+			},
+		},
+		{
+			"Branch on an unsupported service",
+			"git@something.com:peter/calculator.git",
+			func(gitCmd *GitCommand) (string, error) {
+				return gitCmd.BranchURL("master")
+			},
+			func(url string, err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.Config.GetUserConfig().Services = map[string]string{
+				"git.work.com":   "gitea:gitea.work.com",
+				"git.custom.com": "custom:web.custom.com",
+			}
+			gitCmd.Config.GetUserConfig().ServiceProviders = map[string]config.ServiceProvider{
+				"custom": {
+					CommitURL: "http://{{.webDomain}}/{{.owner}}/{{.repo}}/c/{{.sha}}",
+				},
+			}
+			gitCmd.getGitConfigValue = func(path string) (string, error) {
+				assert.Equal(t, "remote.origin.url", path)
+				return s.remoteURL, nil
+			}
+
+			s.test(s.getURL(gitCmd))
+		})
+	}
+}
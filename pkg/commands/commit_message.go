@@ -0,0 +1,169 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// GetCommitTemplate returns the content of the file configured via
+// commit.template, or an empty string if there isn't one. Comment lines are
+// left out because we pass the message straight to `git commit -m`, which
+// won't strip them for us.
+func (c *GitCommand) GetCommitTemplate() (string, error) {
+	path := c.GetConfigValue("commit.template")
+	if path == "" {
+		return "", nil
+	}
+
+	if strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, path[2:])
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	lines := []string{}
+	for _, line := range strings.Split(string(content), "\n") {
+		if !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n"), nil
+}
+
+// GetUserIdentity returns the configured user in the 'Name <email>' form used
+// by trailers
+func (c *GitCommand) GetUserIdentity() string {
+	return c.GetConfigValue("user.name") + " <" + c.GetConfigValue("user.email") + ">"
+}
+
+// we only look at the recent commits for authors because going through the
+// whole history takes a while in a big repo
+const commitAuthorsCommitLimit = 1000
+
+// GetCommitAuthors returns the authors of the recent commits reachable from
+// HEAD in the 'Name <email>' form, with the most prolific first
+func (c *GitCommand) GetCommitAuthors() ([]string, error) {
+	output, err := c.OSCommand.RunCommandWithOutput(
+		fmt.Sprintf("git shortlog --summary --numbered --email --max-count=%d HEAD", commitAuthorsCommitLimit),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	authors := []string{}
+	for _, line := range utils.SplitLines(output) {
+		// each line looks like '    12\tName <email>'
+		split := strings.SplitN(strings.TrimSpace(line), "\t", 2)
+		if len(split) != 2 {
+			continue
+		}
+		authors = append(authors, split[1])
+	}
+
+	return authors, nil
+}
+
+var trailerRegex = regexp.MustCompile(`^[A-Za-z0-9-]+: `)
+
+// AddTrailer appends a trailer like 'Signed-off-by: Name <email>' to the
+// message. Trailers need to be in the last paragraph of the message, so we
+// start a new paragraph unless the last one is already made up of trailers.
+// If the message already has the trailer we leave it alone.
+func AddTrailer(message string, trailer string) string {
+	message = strings.TrimRight(message, "\n")
+	lines := strings.Split(message, "\n")
+
+	lastParagraph := []string{}
+	for i := len(lines) - 1; i >= 0 && lines[i] != ""; i-- {
+		lastParagraph = append(lastParagraph, lines[i])
+	}
+
+	isTrailerParagraph := len(lastParagraph) > 0 && len(lastParagraph) < len(lines)
+	for _, line := range lastParagraph {
+		if line == trailer {
+			return message
+		}
+		if !trailerRegex.MatchString(line) {
+			isTrailerParagraph = false
+		}
+	}
+
+	if isTrailerParagraph {
+		return message + "\n" + trailer
+	}
+
+	return message + "\n\n" + trailer
+}
+
+// matches e.g. 'feat(parser)!: description', capturing the type and scope
+var conventionalSubjectRegex = regexp.MustCompile(`^([A-Za-z]+)(\(([^()]*)\))?!?: \S`)
+
+// ValidateCommitMessage checks the subject of the message against the
+// configured rules, returning a description of each rule it breaks
+func (c *GitCommand) ValidateCommitMessage(message string) []string {
+	rules := c.Config.GetUserConfig().Git.CommitValidation
+	subject := strings.Split(strings.TrimSpace(message), "\n")[0]
+
+	// messages from `git commit --fixup` and friends are meant to be squashed
+	// away, so their subject doesn't matter
+	for _, prefix := range []string{"fixup! ", "squash! ", "amend! "} {
+		if strings.HasPrefix(subject, prefix) {
+			return nil
+		}
+	}
+
+	problems := []string{}
+
+	if rules.MaxSubjectLength > 0 && len([]rune(subject)) > rules.MaxSubjectLength {
+		problems = append(problems, utils.ResolvePlaceholderString(c.Tr.CommitSubjectTooLong, map[string]string{
+			"length":    strconv.Itoa(len([]rune(subject))),
+			"maxLength": strconv.Itoa(rules.MaxSubjectLength),
+		}))
+	}
+
+	if !rules.Conventional {
+		return problems
+	}
+
+	match := conventionalSubjectRegex.FindStringSubmatch(subject)
+	if match == nil {
+		return append(problems, c.Tr.CommitNotConventional)
+	}
+
+	commitType := match[1]
+	if len(rules.Types) > 0 && !utils.IncludesString(rules.Types, commitType) {
+		problems = append(problems, utils.ResolvePlaceholderString(c.Tr.CommitTypeNotAllowed, map[string]string{
+			"type":  commitType,
+			"types": strings.Join(rules.Types, ", "),
+		}))
+	}
+
+	scope := match[3]
+	if scope == "" {
+		if rules.RequireScope {
+			problems = append(problems, c.Tr.CommitScopeRequired)
+		}
+	} else if len(rules.Scopes) > 0 && !utils.IncludesString(rules.Scopes, scope) {
+		problems = append(problems, utils.ResolvePlaceholderString(c.Tr.CommitScopeNotAllowed, map[string]string{
+			"scope":  scope,
+			"scopes": strings.Join(rules.Scopes, ", "),
+		}))
+	}
+
+	return problems
+}
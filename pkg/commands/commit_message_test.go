@@ -0,0 +1,211 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/secureexec"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandGetCommitTemplate is a function.
+func TestGitCommandGetCommitTemplate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazygit-commit-template")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	templatePath := filepath.Join(dir, "template")
+	assert.NoError(t, ioutil.WriteFile(templatePath, []byte("feat: \n\n# Describe the change\nRefs: \n\n"), 0644))
+
+	type scenario struct {
+		testName       string
+		configuredPath string
+		test           func(string, error)
+	}
+
+	scenarios := []scenario{
+		{
+			"No template configured",
+			"",
+			func(template string, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "", template)
+			},
+		},
+		{
+			"Template with comments",
+			templatePath,
+			func(template string, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "feat: \n\nRefs: ", template)
+			},
+		},
+		{
+			"Template that doesn't exist",
+			filepath.Join(dir, "missing"),
+			func(template string, err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.getGitConfigValue = func(key string) (string, error) {
+				assert.Equal(t, "commit.template", key)
+				return s.configuredPath, nil
+			}
+
+			s.test(gitCmd.GetCommitTemplate())
+		})
+	}
+}
+
+// TestGitCommandGetCommitAuthors is a function.
+func TestGitCommandGetCommitAuthors(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.Command = func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"shortlog", "--summary", "--numbered", "--email", "--max-count=1000", "HEAD"}, args)
+
+		return secureexec.Command("printf", "    12\tJesse Duffield <jesse@example.com>\n     3\tJane Doe <jane@example.com>\n")
+	}
+
+	authors, err := gitCmd.GetCommitAuthors()
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"Jesse Duffield <jesse@example.com>", "Jane Doe <jane@example.com>"}, authors)
+}
+
+// TestAddTrailer is a function.
+func TestAddTrailer(t *testing.T) {
+	type scenario struct {
+		testName string
+		message  string
+		trailer  string
+		expected string
+	}
+
+	scenarios := []scenario{
+		{
+			"Subject only",
+			"fix: handle empty input",
+			"Signed-off-by: Jane Doe <jane@example.com>",
+			"fix: handle empty input\n\nSigned-off-by: Jane Doe <jane@example.com>",
+		},
+		{
+			"Subject that looks like a trailer",
+			"docs: fix typo",
+			"Signed-off-by: Jane Doe <jane@example.com>",
+			"docs: fix typo\n\nSigned-off-by: Jane Doe <jane@example.com>",
+		},
+		{
+			"Body without trailers",
+			"fix: handle empty input\n\nWe were panicking before.\n",
+			"Signed-off-by: Jane Doe <jane@example.com>",
+			"fix: handle empty input\n\nWe were panicking before.\n\nSigned-off-by: Jane Doe <jane@example.com>",
+		},
+		{
+			"Existing trailers",
+			"fix: handle empty input\n\nSigned-off-by: Jane Doe <jane@example.com>",
+			"Co-authored-by: Joe Bloggs <joe@example.com>",
+			"fix: handle empty input\n\nSigned-off-by: Jane Doe <jane@example.com>\nCo-authored-by: Joe Bloggs <joe@example.com>",
+		},
+		{
+			"Trailer already present",
+			"fix: handle empty input\n\nSigned-off-by: Jane Doe <jane@example.com>",
+			"Signed-off-by: Jane Doe <jane@example.com>",
+			"fix: handle empty input\n\nSigned-off-by: Jane Doe <jane@example.com>",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.Equal(t, s.expected, AddTrailer(s.message, s.trailer))
+		})
+	}
+}
+
+// TestGitCommandValidateCommitMessage is a function.
+func TestGitCommandValidateCommitMessage(t *testing.T) {
+	conventional := config.CommitValidationConfig{
+		MaxSubjectLength: 30,
+		Conventional:     true,
+		Types:            []string{"feat", "fix"},
+		Scopes:           []string{"gui", "commands"},
+	}
+
+	type scenario struct {
+		testName string
+		rules    config.CommitValidationConfig
+		message  string
+		expected []string
+	}
+
+	scenarios := []scenario{
+		{
+			"No rules",
+			config.CommitValidationConfig{},
+			"whatever I like, however long I like it to be",
+			[]string{},
+		},
+		{
+			"Subject too long",
+			config.CommitValidationConfig{MaxSubjectLength: 10},
+			"a subject that is too long\n\nthe body can be as long as it likes",
+			[]string{"subject is 26 characters long, the limit is 10"},
+		},
+		{
+			"Valid conventional commit",
+			conventional,
+			"feat(gui): add trailers\n\nbody",
+			[]string{},
+		},
+		{
+			"Breaking change without scope",
+			conventional,
+			"fix!: drop support",
+			[]string{},
+		},
+		{
+			"Not conventional",
+			conventional,
+			"add trailers",
+			[]string{"subject should look like 'type(scope): description'"},
+		},
+		{
+			"Unknown type and scope",
+			conventional,
+			"chore(deps): bump",
+			[]string{
+				"type 'chore' should be one of: feat, fix",
+				"scope 'deps' should be one of: gui, commands",
+			},
+		},
+		{
+			"Missing required scope",
+			config.CommitValidationConfig{Conventional: true, RequireScope: true},
+			"feat: add trailers",
+			[]string{"a scope is required e.g. 'type(scope): description'"},
+		},
+		{
+			"Fixup commits aren't validated",
+			conventional,
+			"fixup! add trailers",
+			nil,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.Config.GetUserConfig().Git.CommitValidation = s.rules
+
+			assert.EqualValues(t, s.expected, gitCmd.ValidateCommitMessage(s.message))
+		})
+	}
+}
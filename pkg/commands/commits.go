@@ -60,12 +60,17 @@ func (c *GitCommand) AmendHeadCmdStr() string {
 	return "git commit --amend --no-edit --allow-empty"
 }
 
-func (c *GitCommand) ShowCmdStr(sha string, filterPath string) string {
+func (c *GitCommand) ShowCmdStr(sha string, filterPath string, plain bool) string {
+	colorArg := c.colorArg()
+	if plain {
+		colorArg = "never"
+	}
+
 	filterPathArg := ""
 	if filterPath != "" {
 		filterPathArg = fmt.Sprintf(" -- %s", c.OSCommand.Quote(filterPath))
 	}
-	return fmt.Sprintf("git show --submodule --color=%s --no-renames --stat -p %s %s", c.colorArg(), sha, filterPathArg)
+	return fmt.Sprintf("git show --submodule --color=%s --no-renames --stat -p %s %s", colorArg, sha, filterPathArg)
 }
 
 // Revert reverts the selected commit by sha
@@ -55,8 +55,22 @@ func (c *GitCommand) UsingGpg() bool {
 		return false
 	}
 
-	gpgsign := c.GetConfigValue("commit.gpgsign")
-	value := strings.ToLower(gpgsign)
+	return c.getConfigBool("commit.gpgsign")
+}
+
+// UsingGpgForTag is like UsingGpg but for creating a tag, which gets signed if
+// we ask for it or if the user has tag.gpgSign set
+func (c *GitCommand) UsingGpgForTag(sign bool) bool {
+	overrideGpg := c.Config.GetUserConfig().Git.OverrideGpg
+	if overrideGpg {
+		return false
+	}
+
+	return sign || c.getConfigBool("tag.gpgSign")
+}
+
+func (c *GitCommand) getConfigBool(key string) bool {
+	value := strings.ToLower(c.GetConfigValue(key))
 
 	return value == "true" || value == "1" || value == "yes" || value == "on"
 }
@@ -68,3 +68,45 @@ func TestGitCommandUsingGpg(t *testing.T) {
 		})
 	}
 }
+
+// TestGitCommandUsingGpgForTag is a function.
+func TestGitCommandUsingGpgForTag(t *testing.T) {
+	type scenario struct {
+		testName          string
+		sign              bool
+		getGitConfigValue func(string) (string, error)
+		expected          bool
+	}
+
+	scenarios := []scenario{
+		{
+			"Not signing and tag.gpgSign is not set",
+			false,
+			func(string) (string, error) { return "", nil },
+			false,
+		},
+		{
+			"Signing explicitly",
+			true,
+			func(string) (string, error) { return "", nil },
+			true,
+		},
+		{
+			"Option tag.gpgSign is true",
+			false,
+			func(key string) (string, error) {
+				assert.Equal(t, "tag.gpgSign", key)
+				return "true", nil
+			},
+			true,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.getGitConfigValue = s.getGitConfigValue
+			assert.Equal(t, s.expected, gitCmd.UsingGpgForTag(s.sign))
+		})
+	}
+}
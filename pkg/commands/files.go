@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/go-errors/errors"
@@ -224,6 +225,11 @@ func (c *GitCommand) ApplyPatch(patch string, flags ...string) error {
 		return err
 	}
 
+	return c.ApplyPatchFile(filepath, flags...)
+}
+
+// ApplyPatchFile applies the patch in the given file
+func (c *GitCommand) ApplyPatchFile(filepath string, flags ...string) error {
 	flagStr := ""
 	for _, flag := range flags {
 		flagStr += " --" + flag
@@ -291,6 +297,17 @@ func (c *GitCommand) DiscardAnyUnstagedFileChanges() error {
 	return c.RunCommand("git checkout -- .")
 }
 
+// HasTrackedChanges tells us whether there are any staged or unstaged changes
+// to tracked files
+func (c *GitCommand) HasTrackedChanges() (bool, error) {
+	output, err := c.OSCommand.RunCommandWithOutput("git status --porcelain --untracked-files=no")
+	if err != nil {
+		return false, err
+	}
+
+	return strings.TrimSpace(output) != "", nil
+}
+
 // RemoveTrackedFiles will delete the given file(s) even if they are currently tracked
 func (c *GitCommand) RemoveTrackedFiles(name string) error {
 	return c.RunCommand("git rm -r --cached %s", name)
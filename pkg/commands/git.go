@@ -39,6 +39,10 @@ type GitCommand struct {
 
 	// Push to current determines whether the user has configured to push to the remote branch of the same name as the current or not
 	PushToCurrent bool
+
+	// journalOperations determines whether we record operations like deleting a
+	// branch in the operation journal so that they can be undone
+	journalOperations bool
 }
 
 // NewGitCommand it runs git commands
@@ -76,6 +80,7 @@ func NewGitCommand(log *logrus.Entry, osCommand *oscommands.OSCommand, tr *i18n.
 		getGitConfigValue: getGitConfigValue,
 		DotGitDir:         dotGitDir,
 		PushToCurrent:     pushToCurrent,
+		journalOperations: true,
 	}
 
 	gitCommand.PatchManager = patch.NewPatchManager(log, gitCommand.ApplyPatch, gitCommand.ShowFileDiff)
@@ -104,6 +109,16 @@ func (c *GitCommand) WithSpan(span string) *GitCommand {
 	return newGitCommand
 }
 
+// WithProgress returns a copy of the GitCommand whose pushes, pulls and fetches
+// report git's progress to onProgress and are killed when cancel is closed
+func (c *GitCommand) WithProgress(onProgress func(oscommands.Progress), cancel <-chan struct{}) *GitCommand {
+	newGitCommand := &GitCommand{}
+	*newGitCommand = *c
+	newGitCommand.OSCommand = c.OSCommand.WithProgress(onProgress, cancel)
+
+	return newGitCommand
+}
+
 func navigateToRepoRootDirectory(stat func(string) (os.FileInfo, error), chdir func(string) error) error {
 	gitDir := env.GetGitDirEnv()
 	if gitDir != "" {
@@ -0,0 +1,257 @@
+package commands
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// A git service (Github, Bitbucket, ...) is defined by a set of URL templates.
+// The templates can use the following placeholders:
+//   {{.webDomain}}  the domain the service's web interface lives on
+//   {{.owner}}      the owner of the repo (may contain slashes e.g. for nested groups)
+//   {{.repo}}       the name of the repo
+//   {{.from}}       the branch a pull request is coming from
+//   {{.to}}         the branch a pull request is going into
+//   {{.sha}}        a commit sha
+//   {{.branch}}     a branch name
+//   {{.tag}}        a tag name
+//   {{.ref}}        a branch name or commit sha a file is being viewed at (the
+//                   sha placeholder is used instead in CommitFileAtLineURL)
+//   {{.path}}       the path of a file relative to the repo root
+//   {{.line}}       a line number in a file
+// Any other named groups in a provider's remote URL patterns can also be used.
+
+var defaultServiceProviders = map[string]config.ServiceProvider{
+	"github": {
+		PullRequestURLIntoDefaultBranch: "https://{{.webDomain}}/{{.owner}}/{{.repo}}/compare/{{.from}}?expand=1",
+		PullRequestURLIntoTargetBranch:  "https://{{.webDomain}}/{{.owner}}/{{.repo}}/compare/{{.to}}...{{.from}}?expand=1",
+		CommitURL:                       "https://{{.webDomain}}/{{.owner}}/{{.repo}}/commit/{{.sha}}",
+		BranchURL:                       "https://{{.webDomain}}/{{.owner}}/{{.repo}}/tree/{{.branch}}",
+		TagURL:                          "https://{{.webDomain}}/{{.owner}}/{{.repo}}/releases/tag/{{.tag}}",
+		FileAtLineURL:                   "https://{{.webDomain}}/{{.owner}}/{{.repo}}/blob/{{.ref}}/{{.path}}#L{{.line}}",
+	},
+	"bitbucket": {
+		PullRequestURLIntoDefaultBranch: "https://{{.webDomain}}/{{.owner}}/{{.repo}}/pull-requests/new?source={{.from}}&t=1",
+		PullRequestURLIntoTargetBranch:  "https://{{.webDomain}}/{{.owner}}/{{.repo}}/pull-requests/new?source={{.from}}&dest={{.to}}&t=1",
+		CommitURL:                       "https://{{.webDomain}}/{{.owner}}/{{.repo}}/commits/{{.sha}}",
+		BranchURL:                       "https://{{.webDomain}}/{{.owner}}/{{.repo}}/branch/{{.branch}}",
+		TagURL:                          "https://{{.webDomain}}/{{.owner}}/{{.repo}}/src/{{.tag}}",
+		FileAtLineURL:                   "https://{{.webDomain}}/{{.owner}}/{{.repo}}/src/{{.ref}}/{{.path}}#lines-{{.line}}",
+	},
+	"gitlab": {
+		PullRequestURLIntoDefaultBranch: "https://{{.webDomain}}/{{.owner}}/{{.repo}}/merge_requests/new?merge_request[source_branch]={{.from}}",
+		PullRequestURLIntoTargetBranch:  "https://{{.webDomain}}/{{.owner}}/{{.repo}}/merge_requests/new?merge_request[source_branch]={{.from}}&merge_request[target_branch]={{.to}}",
+		CommitURL:                       "https://{{.webDomain}}/{{.owner}}/{{.repo}}/-/commit/{{.sha}}",
+		BranchURL:                       "https://{{.webDomain}}/{{.owner}}/{{.repo}}/-/tree/{{.branch}}",
+		TagURL:                          "https://{{.webDomain}}/{{.owner}}/{{.repo}}/-/tags/{{.tag}}",
+		FileAtLineURL:                   "https://{{.webDomain}}/{{.owner}}/{{.repo}}/-/blob/{{.ref}}/{{.path}}#L{{.line}}",
+	},
+	"gitea": {
+		PullRequestURLIntoDefaultBranch: "https://{{.webDomain}}/{{.owner}}/{{.repo}}/compare/{{.from}}",
+		PullRequestURLIntoTargetBranch:  "https://{{.webDomain}}/{{.owner}}/{{.repo}}/compare/{{.to}}...{{.from}}",
+		CommitURL:                       "https://{{.webDomain}}/{{.owner}}/{{.repo}}/commit/{{.sha}}",
+		BranchURL:                       "https://{{.webDomain}}/{{.owner}}/{{.repo}}/src/branch/{{.branch}}",
+		TagURL:                          "https://{{.webDomain}}/{{.owner}}/{{.repo}}/releases/tag/{{.tag}}",
+		FileAtLineURL:                   "https://{{.webDomain}}/{{.owner}}/{{.repo}}/src/{{.ref}}/{{.path}}#L{{.line}}",
+	},
+	// the owner here is '<organisation>/<project>'
+	"azuredevops": {
+		PullRequestURLIntoDefaultBranch: "https://{{.webDomain}}/{{.owner}}/_git/{{.repo}}/pullrequestcreate?sourceRef={{.from}}",
+		PullRequestURLIntoTargetBranch:  "https://{{.webDomain}}/{{.owner}}/_git/{{.repo}}/pullrequestcreate?sourceRef={{.from}}&targetRef={{.to}}",
+		CommitURL:                       "https://{{.webDomain}}/{{.owner}}/_git/{{.repo}}/commit/{{.sha}}",
+		BranchURL:                       "https://{{.webDomain}}/{{.owner}}/_git/{{.repo}}?version=GB{{.branch}}",
+		TagURL:                          "https://{{.webDomain}}/{{.owner}}/_git/{{.repo}}?version=GT{{.tag}}",
+		FileAtLineURL:                   "https://{{.webDomain}}/{{.owner}}/_git/{{.repo}}?path=/{{.path}}&version=GB{{.ref}}&line={{.line}}",
+		CommitFileAtLineURL:             "https://{{.webDomain}}/{{.owner}}/_git/{{.repo}}?path=/{{.path}}&version=GC{{.sha}}&line={{.line}}",
+		RemoteURLPatterns: []string{
+			`^.+:v3/(?P<owner>[^/]+/[^/]+)/(?P<repo>[^/]+?)(\.git)?$`,
+			`^https?://.+?/(?P<owner>[^/]+/[^/]+)/_git/(?P<repo>[^/]+?)(\.git)?$`,
+		},
+	},
+	// the owner here is the project key
+	"bitbucketServer": {
+		PullRequestURLIntoDefaultBranch: "https://{{.webDomain}}/projects/{{.owner}}/repos/{{.repo}}/pull-requests?create&sourceBranch={{.from}}",
+		PullRequestURLIntoTargetBranch:  "https://{{.webDomain}}/projects/{{.owner}}/repos/{{.repo}}/pull-requests?create&sourceBranch={{.from}}&targetBranch={{.to}}",
+		CommitURL:                       "https://{{.webDomain}}/projects/{{.owner}}/repos/{{.repo}}/commits/{{.sha}}",
+		BranchURL:                       "https://{{.webDomain}}/projects/{{.owner}}/repos/{{.repo}}/browse?at={{.branch}}",
+		TagURL:                          "https://{{.webDomain}}/projects/{{.owner}}/repos/{{.repo}}/browse?at=refs/tags/{{.tag}}",
+		FileAtLineURL:                   "https://{{.webDomain}}/projects/{{.owner}}/repos/{{.repo}}/browse/{{.path}}?at={{.ref}}#{{.line}}",
+		RemoteURLPatterns: []string{
+			`^ssh://.+?/(?P<owner>[^/]+)/(?P<repo>[^/]+?)(\.git)?$`,
+			`^https?://.+?/scm/(?P<owner>[^/]+)/(?P<repo>[^/]+?)(\.git)?$`,
+		},
+	},
+}
+
+// defaultServiceDomains are the public instances we recognise without any
+// config. Self-hosted instances are mapped to a provider via the 'services'
+// config.
+var defaultServiceDomains = []struct {
+	provider string
+	domain   string
+}{
+	{provider: "github", domain: "github.com"},
+	{provider: "bitbucket", domain: "bitbucket.org"},
+	{provider: "gitlab", domain: "gitlab.com"},
+	{provider: "gitea", domain: "gitea.com"},
+	{provider: "azuredevops", domain: "dev.azure.com"},
+}
+
+// getServiceProviders returns the built-in providers along with those declared
+// in the user config. A user-declared provider with the same name as a
+// built-in one only overrides the templates it sets.
+func getServiceProviders(userConfig *config.UserConfig) map[string]config.ServiceProvider {
+	providers := map[string]config.ServiceProvider{}
+	for name, provider := range defaultServiceProviders {
+		providers[name] = provider
+	}
+
+	for name, userProvider := range userConfig.ServiceProviders {
+		provider := providers[name]
+		for _, field := range []struct {
+			value  string
+			target *string
+		}{
+			{userProvider.PullRequestURLIntoDefaultBranch, &provider.PullRequestURLIntoDefaultBranch},
+			{userProvider.PullRequestURLIntoTargetBranch, &provider.PullRequestURLIntoTargetBranch},
+			{userProvider.CommitURL, &provider.CommitURL},
+			{userProvider.BranchURL, &provider.BranchURL},
+			{userProvider.TagURL, &provider.TagURL},
+			{userProvider.FileAtLineURL, &provider.FileAtLineURL},
+			{userProvider.CommitFileAtLineURL, &provider.CommitFileAtLineURL},
+		} {
+			if field.value != "" {
+				*field.target = field.value
+			}
+		}
+		if len(userProvider.RemoteURLPatterns) > 0 {
+			provider.RemoteURLPatterns = userProvider.RemoteURLPatterns
+		}
+		providers[name] = provider
+	}
+
+	return providers
+}
+
+// Service is a service that repository is on (Github, Bitbucket, ...)
+type Service struct {
+	// Name is the domain found in the remote URLs of the service
+	Name      string
+	WebDomain string
+	Provider  config.ServiceProvider
+}
+
+// NewService builds a Service based on the host type, returning nil if we
+// don't know of a provider with that name
+func NewService(providers map[string]config.ServiceProvider, typeName string, repositoryDomain string, siteDomain string) *Service {
+	provider, ok := providers[typeName]
+	if !ok {
+		return nil
+	}
+
+	return &Service{
+		Name:      repositoryDomain,
+		WebDomain: siteDomain,
+		Provider:  provider,
+	}
+}
+
+// repoPlaceholders pulls the owner and repo (along with anything else the
+// provider's patterns capture) out of the remote URL
+func (s *Service) repoPlaceholders(remoteURL string) map[string]string {
+	placeholders := map[string]string{"webDomain": s.WebDomain}
+
+	for _, pattern := range s.Provider.RemoteURLPatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			continue
+		}
+
+		match := re.FindStringSubmatch(remoteURL)
+		if match == nil {
+			continue
+		}
+
+		for i, name := range re.SubexpNames() {
+			if name != "" {
+				placeholders[name] = match[i]
+			}
+		}
+		return placeholders
+	}
+
+	repoInfo := getRepoInfoFromURL(remoteURL)
+	placeholders["owner"] = repoInfo.Owner
+	placeholders["repo"] = repoInfo.Repository
+
+	return placeholders
+}
+
+// resolveURL fills in the given template, returning an empty string if the
+// provider doesn't define it
+func (s *Service) resolveURL(template string, remoteURL string, arguments map[string]string) string {
+	if template == "" {
+		return ""
+	}
+
+	placeholders := s.repoPlaceholders(remoteURL)
+	for key, value := range arguments {
+		placeholders[key] = value
+	}
+
+	return utils.ResolvePlaceholderString(template, placeholders)
+}
+
+// PullRequestURL returns the URL for creating a pull request from one branch
+// into another, or into the default branch if 'to' is empty
+func (s *Service) PullRequestURL(remoteURL string, from string, to string) string {
+	if to == "" {
+		return s.resolveURL(s.Provider.PullRequestURLIntoDefaultBranch, remoteURL, map[string]string{"from": from})
+	}
+
+	return s.resolveURL(s.Provider.PullRequestURLIntoTargetBranch, remoteURL, map[string]string{"from": from, "to": to})
+}
+
+// CommitURL returns the URL for viewing a commit
+func (s *Service) CommitURL(remoteURL string, sha string) string {
+	return s.resolveURL(s.Provider.CommitURL, remoteURL, map[string]string{"sha": sha})
+}
+
+// BranchURL returns the URL for viewing a branch
+func (s *Service) BranchURL(remoteURL string, branch string) string {
+	return s.resolveURL(s.Provider.BranchURL, remoteURL, map[string]string{"branch": branch})
+}
+
+// TagURL returns the URL for viewing a tag (or its release, where the service
+// has them)
+func (s *Service) TagURL(remoteURL string, tag string) string {
+	return s.resolveURL(s.Provider.TagURL, remoteURL, map[string]string{"tag": tag})
+}
+
+// FileAtLineURL returns the URL for viewing a file at a given branch, scrolled
+// to the given line
+func (s *Service) FileAtLineURL(remoteURL string, branch string, path string, line int) string {
+	return s.resolveURL(s.Provider.FileAtLineURL, remoteURL, map[string]string{
+		"ref":  branch,
+		"path": path,
+		"line": strconv.Itoa(line),
+	})
+}
+
+// CommitFileAtLineURL returns the URL for viewing a file at a given commit,
+// scrolled to the given line. Most services don't mind whether they're given
+// a branch or a commit, so we fall back to FileAtLineURL.
+func (s *Service) CommitFileAtLineURL(remoteURL string, sha string, path string, line int) string {
+	if s.Provider.CommitFileAtLineURL == "" {
+		return s.FileAtLineURL(remoteURL, sha, path, line)
+	}
+
+	return s.resolveURL(s.Provider.CommitFileAtLineURL, remoteURL, map[string]string{
+		"sha":  sha,
+		"path": path,
+		"line": strconv.Itoa(line),
+	})
+}
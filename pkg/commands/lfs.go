@@ -0,0 +1,171 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// GetLfsPaths tells us which of the given paths are stored with Git LFS, going
+// by their `filter` attribute. If a commit is given, we use the attributes as
+// they were in that commit rather than as they are in the worktree.
+func (c *GitCommand) GetLfsPaths(paths []string, commit string) (map[string]bool, error) {
+	if len(paths) == 0 {
+		return map[string]bool{}, nil
+	}
+
+	sourceArg := ""
+	if commit != "" {
+		sourceArg = " --source " + c.OSCommand.Quote(commit)
+	}
+
+	output, err := c.checkAttr(paths, sourceArg)
+	if err != nil && commit != "" {
+		// --source needs git 2.40, so with older versions we fall back to the
+		// worktree's attributes
+		c.Log.Error(err)
+		output, err = c.checkAttr(paths, "")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// each path comes back as '<path>\x00filter\x00<value>\x00'
+	lfsPaths := map[string]bool{}
+	fields := strings.Split(output, "\x00")
+	for i := 0; i+2 < len(fields); i += 3 {
+		if fields[i+2] == "lfs" {
+			lfsPaths[fields[i]] = true
+		}
+	}
+
+	return lfsPaths, nil
+}
+
+func (c *GitCommand) checkAttr(paths []string, sourceArg string) (string, error) {
+	cmd := c.OSCommand.ExecutableFromString("git check-attr -z --stdin" + sourceArg + " filter")
+	cmd.Stdin = strings.NewReader(strings.Join(paths, "\x00") + "\x00")
+
+	// git warns about invalid patterns on stderr, which we need to keep out of
+	// the output
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	c.OSCommand.LogExecCmd(cmd)
+	c.OSCommand.BeforeExecuteCmd(cmd)
+	if err := cmd.Run(); err != nil {
+		if stderr.Len() == 0 {
+			return "", err
+		}
+		return "", errors.New(stderr.String())
+	}
+
+	return stdout.String(), nil
+}
+
+// LfsPointer is the part of an LFS pointer file that identifies an object
+type LfsPointer struct {
+	Oid  string
+	Size string
+}
+
+// ParseLfsPointerDiff returns the old and new pointers from the diff of an LFS
+// pointer file, where a side is nil if the file didn't exist on that side. If
+// the diff isn't of a pointer file, ok is false.
+func ParseLfsPointerDiff(diff string) (oldPointer *LfsPointer, newPointer *LfsPointer, ok bool) {
+	isPointer := false
+	oldFields := map[string]string{}
+	newFields := map[string]string{}
+
+	for _, line := range strings.Split(diff, "\n") {
+		if line == "" || strings.HasPrefix(line, "---") || strings.HasPrefix(line, "+++") {
+			continue
+		}
+
+		prefix := line[:1]
+		if prefix != " " && prefix != "-" && prefix != "+" {
+			continue
+		}
+
+		split := strings.SplitN(line[1:], " ", 2)
+		if len(split) != 2 {
+			continue
+		}
+		key, value := split[0], split[1]
+
+		if key == "version" && strings.HasPrefix(value, "https://git-lfs.github.com/spec/") {
+			isPointer = true
+		}
+		if prefix != "+" {
+			oldFields[key] = value
+		}
+		if prefix != "-" {
+			newFields[key] = value
+		}
+	}
+
+	toPointer := func(fields map[string]string) *LfsPointer {
+		if fields["oid"] == "" {
+			return nil
+		}
+		return &LfsPointer{Oid: fields["oid"], Size: fields["size"]}
+	}
+
+	oldPointer = toPointer(oldFields)
+	newPointer = toPointer(newFields)
+	if !isPointer || (oldPointer == nil && newPointer == nil) {
+		return nil, nil, false
+	}
+
+	return oldPointer, newPointer, true
+}
+
+// LfsLock is a file locked on the LFS server
+type LfsLock struct {
+	ID    string
+	Path  string
+	Owner string
+}
+
+// GetLfsLocks returns the locks held on the LFS server for the current remote
+func (c *GitCommand) GetLfsLocks() ([]*LfsLock, error) {
+	output, err := c.RunCommandWithOutput("git lfs locks --json")
+	if err != nil {
+		return nil, err
+	}
+
+	var rawLocks []struct {
+		ID    string `json:"id"`
+		Path  string `json:"path"`
+		Owner struct {
+			Name string `json:"name"`
+		} `json:"owner"`
+	}
+	if err := json.Unmarshal([]byte(output), &rawLocks); err != nil {
+		return nil, err
+	}
+
+	locks := make([]*LfsLock, len(rawLocks))
+	for i, rawLock := range rawLocks {
+		locks[i] = &LfsLock{ID: rawLock.ID, Path: rawLock.Path, Owner: rawLock.Owner.Name}
+	}
+
+	return locks, nil
+}
+
+func (c *GitCommand) LfsLock(path string) error {
+	return c.RunCommand("git lfs lock %s", c.OSCommand.Quote(path))
+}
+
+// LfsUnlock releases the lock on the file. Forcing it lets you release a lock
+// held by someone else.
+func (c *GitCommand) LfsUnlock(path string, force bool) error {
+	forceArg := ""
+	if force {
+		forceArg = " --force"
+	}
+
+	return c.RunCommand("git lfs unlock%s %s", forceArg, c.OSCommand.Quote(path))
+}
@@ -0,0 +1,215 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/secureexec"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandGetLfsPaths is a function.
+func TestGitCommandGetLfsPaths(t *testing.T) {
+	type scenario struct {
+		testName string
+		paths    []string
+		commit   string
+		command  func(string, ...string) *exec.Cmd
+		test     func(map[string]bool, error)
+	}
+
+	scenarios := []scenario{
+		{
+			"No paths",
+			[]string{},
+			"",
+			func(cmd string, args ...string) *exec.Cmd {
+				t.Errorf("unexpected command: %s %v", cmd, args)
+				return secureexec.Command("echo")
+			},
+			func(lfsPaths map[string]bool, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, map[string]bool{}, lfsPaths)
+			},
+		},
+		{
+			"Worktree attributes",
+			[]string{"design.psd", "notes.txt", "assets/small.png"},
+			"",
+			func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				assert.EqualValues(t, []string{"check-attr", "-z", "--stdin", "filter"}, args)
+
+				return secureexec.Command("printf", `design.psd\0filter\0lfs\0notes.txt\0filter\0unspecified\0assets/small.png\0filter\0unset\0`)
+			},
+			func(lfsPaths map[string]bool, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, map[string]bool{"design.psd": true}, lfsPaths)
+			},
+		},
+		{
+			"Warnings about patterns",
+			[]string{"design.psd"},
+			"",
+			func(cmd string, args ...string) *exec.Cmd {
+				return secureexec.Command("sh", "-c", `echo 'warning: Negative patterns are ignored in git attributes' >&2; printf 'design.psd\0filter\0lfs\0'`)
+			},
+			func(lfsPaths map[string]bool, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, map[string]bool{"design.psd": true}, lfsPaths)
+			},
+		},
+		{
+			"Commit attributes",
+			[]string{"design.psd"},
+			"abc123",
+			func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				assert.EqualValues(t, []string{"check-attr", "-z", "--stdin", "--source", "abc123", "filter"}, args)
+
+				return secureexec.Command("printf", `design.psd\0filter\0lfs\0`)
+			},
+			func(lfsPaths map[string]bool, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, map[string]bool{"design.psd": true}, lfsPaths)
+			},
+		},
+		{
+			"Git too old for --source",
+			[]string{"design.psd"},
+			"abc123",
+			func(cmd string, args ...string) *exec.Cmd {
+				if len(args) > 3 && args[3] == "--source" {
+					return secureexec.Command("sh", "-c", "echo 'unknown option' >&2; exit 129")
+				}
+				assert.EqualValues(t, []string{"check-attr", "-z", "--stdin", "filter"}, args)
+
+				return secureexec.Command("printf", `design.psd\0filter\0lfs\0`)
+			},
+			func(lfsPaths map[string]bool, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, map[string]bool{"design.psd": true}, lfsPaths)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.Command = s.command
+			s.test(gitCmd.GetLfsPaths(s.paths, s.commit))
+		})
+	}
+}
+
+// TestParseLfsPointerDiff is a function.
+func TestParseLfsPointerDiff(t *testing.T) {
+	type scenario struct {
+		testName    string
+		diff        string
+		expectedOld *LfsPointer
+		expectedNew *LfsPointer
+		expectedOk  bool
+	}
+
+	scenarios := []scenario{
+		{
+			"Changed object",
+			`diff --git a/design.psd b/design.psd
+index 1d2e3f4..5a6b7c8 100644
+--- a/design.psd
++++ b/design.psd
+@@ -1,3 +1,3 @@
+ version https://git-lfs.github.com/spec/v1
+-oid sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393
+-size 12345
++oid sha256:9b2f10c4a1e3d5f60718293a4b5c6d7e8f9012345678abcdef0123456789abcd
++size 1572864
+`,
+			&LfsPointer{Oid: "sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393", Size: "12345"},
+			&LfsPointer{Oid: "sha256:9b2f10c4a1e3d5f60718293a4b5c6d7e8f9012345678abcdef0123456789abcd", Size: "1572864"},
+			true,
+		},
+		{
+			"New object",
+			`diff --git a/design.psd b/design.psd
+new file mode 100644
+--- /dev/null
++++ b/design.psd
+@@ -0,0 +1,3 @@
++version https://git-lfs.github.com/spec/v1
++oid sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393
++size 12345
+`,
+			nil,
+			&LfsPointer{Oid: "sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393", Size: "12345"},
+			true,
+		},
+		{
+			"Not a pointer file",
+			`diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1 +1 @@
+-oid := 1
++oid := 2
+`,
+			nil,
+			nil,
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			oldPointer, newPointer, ok := ParseLfsPointerDiff(s.diff)
+			assert.EqualValues(t, s.expectedOld, oldPointer)
+			assert.EqualValues(t, s.expectedNew, newPointer)
+			assert.Equal(t, s.expectedOk, ok)
+		})
+	}
+}
+
+// TestGitCommandGetLfsLocks is a function.
+func TestGitCommandGetLfsLocks(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.Command = func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"lfs", "locks", "--json"}, args)
+
+		return secureexec.Command("echo", `[{"id":"1","path":"design.psd","owner":{"name":"Jane Doe"},"locked_at":"2021-06-01T10:00:00Z"}]`)
+	}
+
+	locks, err := gitCmd.GetLfsLocks()
+	assert.NoError(t, err)
+	assert.EqualValues(t, []*LfsLock{{ID: "1", Path: "design.psd", Owner: "Jane Doe"}}, locks)
+}
+
+// TestGitCommandLfsUnlock is a function.
+func TestGitCommandLfsUnlock(t *testing.T) {
+	type scenario struct {
+		testName string
+		force    bool
+		expected []string
+	}
+
+	scenarios := []scenario{
+		{"Own lock", false, []string{"lfs", "unlock", "design.psd"}},
+		{"Someone else's lock", true, []string{"lfs", "unlock", "--force", "design.psd"}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.Command = func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				assert.EqualValues(t, s.expected, args)
+
+				return secureexec.Command("echo")
+			}
+
+			assert.NoError(t, gitCmd.LfsUnlock("design.psd", s.force))
+		})
+	}
+}
@@ -36,11 +36,35 @@ func NewBranchListBuilder(log *logrus.Entry, gitCommand *GitCommand, reflogCommi
 	}, nil
 }
 
+const (
+	branchFieldsFormat = "%(HEAD)|%(refname:short)|%(upstream:short)|%(upstream:track)"
+	// these need git 2.16, so older versions don't get to see how a branch
+	// compares with its copy on the push remote
+	pushFieldsFormat = "|%(upstream:remotename)|%(push)|%(push:track)|%(push:remotename)"
+)
+
 func (b *BranchListBuilder) obtainBranches() []*models.Branch {
-	cmdStr := `git for-each-ref --sort=-committerdate --format="%(HEAD)|%(refname:short)|%(upstream:short)|%(upstream:track)" refs/heads`
-	output, err := b.GitCommand.OSCommand.RunCommandWithOutput(cmdStr)
+	// When pushing to a different remote than the one we pull from, simple
+	// behaves like current, but git can't work out %(push) for simple in that
+	// case, so we spell it out
+	configArg := ""
+	if pushDefault := b.GitCommand.GetConfigValue("push.default"); pushDefault == "" || pushDefault == "simple" {
+		configArg = "-c push.default=current "
+	}
+
+	fieldCount := 8
+	output, err := b.GitCommand.OSCommand.RunCommandWithOutput(
+		"git %sfor-each-ref --sort=-committerdate --format=%s refs/heads", configArg, b.GitCommand.OSCommand.Quote(branchFieldsFormat+pushFieldsFormat),
+	)
 	if err != nil {
-		panic(err)
+		b.Log.Warnf("listing branches without their push remotes, probably because git is too old: %v", err)
+		fieldCount = 4
+		output, err = b.GitCommand.OSCommand.RunCommandWithOutput(
+			"git for-each-ref --sort=-committerdate --format=%s refs/heads", b.GitCommand.OSCommand.Quote(branchFieldsFormat),
+		)
+		if err != nil {
+			panic(err)
+		}
 	}
 
 	trimmedOutput := strings.TrimSpace(output)
@@ -52,12 +76,16 @@ func (b *BranchListBuilder) obtainBranches() []*models.Branch {
 		}
 
 		split := strings.Split(line, SEPARATION_CHAR)
-		if len(split) != 4 {
-			// Ignore line if it isn't separated into 4 parts
+		if len(split) != fieldCount {
+			// Ignore line if it isn't separated into the parts we asked for
 			// This is probably a warning message, for more info see:
 			// https://github.com/jesseduffield/lazygit/issues/1385#issuecomment-885580439
 			continue
 		}
+		// without the push fields we treat the branch as pushing to its upstream
+		for len(split) < 8 {
+			split = append(split, "")
+		}
 
 		name := strings.TrimPrefix(split[1], "heads/")
 		branch := &models.Branch{
@@ -67,6 +95,17 @@ func (b *BranchListBuilder) obtainBranches() []*models.Branch {
 			Head:      split[0] == "*",
 		}
 
+		// if we push to the remote we pull from, the upstream tells us all we need
+		pushRemote := split[7]
+		if pushRemote != "" && pushRemote != split[4] {
+			branch.PushRemote = pushRemote
+			branch.PushRemotePushables, branch.PushRemotePullables = "?", "?"
+			// %(push) is empty when the branch isn't on the push remote yet
+			if split[5] != "" {
+				branch.PushRemotePushables, branch.PushRemotePullables = parseTrack(split[6])
+			}
+		}
+
 		upstreamName := split[2]
 		if upstreamName == "" {
 			branches = append(branches, branch)
@@ -74,23 +113,7 @@ func (b *BranchListBuilder) obtainBranches() []*models.Branch {
 		}
 
 		branch.UpstreamName = upstreamName
-
-		track := split[3]
-		re := regexp.MustCompile(`ahead (\d+)`)
-		match := re.FindStringSubmatch(track)
-		if len(match) > 1 {
-			branch.Pushables = match[1]
-		} else {
-			branch.Pushables = "0"
-		}
-
-		re = regexp.MustCompile(`behind (\d+)`)
-		match = re.FindStringSubmatch(track)
-		if len(match) > 1 {
-			branch.Pullables = match[1]
-		} else {
-			branch.Pullables = "0"
-		}
+		branch.Pushables, branch.Pullables = parseTrack(split[3])
 
 		branches = append(branches, branch)
 	}
@@ -98,6 +121,27 @@ func (b *BranchListBuilder) obtainBranches() []*models.Branch {
 	return branches
 }
 
+var (
+	aheadRegexp  = regexp.MustCompile(`ahead (\d+)`)
+	behindRegexp = regexp.MustCompile(`behind (\d+)`)
+)
+
+// parseTrack takes something like '[ahead 1, behind 2]' and returns the number
+// of commits ahead and behind
+func parseTrack(track string) (string, string) {
+	pushables := "0"
+	if match := aheadRegexp.FindStringSubmatch(track); len(match) > 1 {
+		pushables = match[1]
+	}
+
+	pullables := "0"
+	if match := behindRegexp.FindStringSubmatch(track); len(match) > 1 {
+		pullables = match[1]
+	}
+
+	return pushables, pullables
+}
+
 // Build the list of branches for the current repo
 func (b *BranchListBuilder) Build() []*models.Branch {
 	branches := b.obtainBranches()
@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/secureexec"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBranchListBuilderObtainBranches is a function.
+func TestBranchListBuilderObtainBranches(t *testing.T) {
+	type scenario struct {
+		testName         string
+		pushDefault      string
+		expectedArgs     []string
+		forEachRefOutput string
+		// set when git is too old for the push fields, in which case we expect
+		// to be asked again without them
+		legacyForEachRefOutput string
+		expectedBranches       []*models.Branch
+	}
+
+	format := "--format=%(HEAD)|%(refname:short)|%(upstream:short)|%(upstream:track)|%(upstream:remotename)|%(push)|%(push:track)|%(push:remotename)"
+	legacyArgs := []string{"for-each-ref", "--sort=-committerdate", "--format=%(HEAD)|%(refname:short)|%(upstream:short)|%(upstream:track)", "refs/heads"}
+
+	scenarios := []scenario{
+		{
+			testName:         "Pushing to the upstream's remote",
+			expectedArgs:     []string{"-c", "push.default=current", "for-each-ref", "--sort=-committerdate", format, "refs/heads"},
+			forEachRefOutput: "*|master|origin/master|[ahead 1]|origin|refs/remotes/origin/master|[ahead 1]|origin\n |feature||||||\n",
+			expectedBranches: []*models.Branch{
+				{Name: "master", Head: true, UpstreamName: "origin/master", Pushables: "1", Pullables: "0"},
+				{Name: "feature", Pushables: "?", Pullables: "?"},
+			},
+		},
+		{
+			testName:     "Pushing to a different remote",
+			pushDefault:  "current",
+			expectedArgs: []string{"for-each-ref", "--sort=-committerdate", format, "refs/heads"},
+			forEachRefOutput: "*|master|upstream/master|[behind 2]|upstream|refs/remotes/origin/master|[ahead 3]|origin\n" +
+				" |feature/thing|upstream/feature/thing||upstream|||upstream\n" +
+				" |new||||||fork\n",
+			expectedBranches: []*models.Branch{
+				{
+					Name: "master", Head: true, UpstreamName: "upstream/master", Pushables: "0", Pullables: "2",
+					PushRemote: "origin", PushRemotePushables: "3", PushRemotePullables: "0",
+				},
+				{Name: "feature/thing", UpstreamName: "upstream/feature/thing", Pushables: "0", Pullables: "0"},
+				{
+					Name: "new", Pushables: "?", Pullables: "?",
+					// we haven't pushed it to the fork yet
+					PushRemote: "fork", PushRemotePushables: "?", PushRemotePullables: "?",
+				},
+			},
+		},
+		{
+			testName:               "Git too old for the push fields",
+			pushDefault:            "current",
+			expectedArgs:           []string{"for-each-ref", "--sort=-committerdate", format, "refs/heads"},
+			legacyForEachRefOutput: "*|master|origin/master|[ahead 1]\n |feature||\n",
+			expectedBranches: []*models.Branch{
+				{Name: "master", Head: true, UpstreamName: "origin/master", Pushables: "1", Pullables: "0"},
+				{Name: "feature", Pushables: "?", Pullables: "?"},
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.getGitConfigValue = func(key string) (string, error) {
+				assert.EqualValues(t, "push.default", key)
+				return s.pushDefault, nil
+			}
+			gitCmd.OSCommand.Command = func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				if s.legacyForEachRefOutput == "" {
+					assert.EqualValues(t, s.expectedArgs, args)
+					return secureexec.Command("printf", "%s", s.forEachRefOutput)
+				}
+
+				if args[2] == format {
+					assert.EqualValues(t, s.expectedArgs, args)
+					return secureexec.Command("sh", "-c", "echo 'fatal: unknown field name: push' >&2; exit 128")
+				}
+				assert.EqualValues(t, legacyArgs, args)
+				return secureexec.Command("printf", "%s", s.legacyForEachRefOutput)
+			}
+
+			builder, err := NewBranchListBuilder(gitCmd.Log, gitCmd, nil)
+			assert.NoError(t, err)
+			assert.EqualValues(t, s.expectedBranches, builder.obtainBranches())
+		})
+	}
+}
@@ -18,11 +18,12 @@ func (c *GitCommand) GetFilesInDiff(from string, to string, reverse bool) ([]*mo
 		return nil, err
 	}
 
-	return c.getCommitFilesFromFilenames(filenames), nil
+	return c.getCommitFilesFromFilenames(filenames, to), nil
 }
 
-// filenames string is something like "file1\nfile2\nfile3"
-func (c *GitCommand) getCommitFilesFromFilenames(filenames string) []*models.CommitFile {
+// filenames string is something like "file1\nfile2\nfile3". We go by the LFS
+// attributes in the given commit.
+func (c *GitCommand) getCommitFilesFromFilenames(filenames string, commit string) []*models.CommitFile {
 	commitFiles := make([]*models.CommitFile, 0)
 
 	lines := strings.Split(strings.TrimRight(filenames, "\x00"), "\x00")
@@ -38,5 +39,17 @@ func (c *GitCommand) getCommitFilesFromFilenames(filenames string) []*models.Com
 		})
 	}
 
+	paths := make([]string, len(commitFiles))
+	for i, commitFile := range commitFiles {
+		paths[i] = commitFile.Name
+	}
+	lfsPaths, err := c.GetLfsPaths(paths, commit)
+	if err != nil {
+		c.Log.Error(err)
+	}
+	for _, commitFile := range commitFiles {
+		commitFile.IsLfs = lfsPaths[commitFile.Name]
+	}
+
 	return commitFiles
 }
@@ -28,6 +28,10 @@ import (
 
 const SEPARATION_CHAR = "|"
 
+// COMMITS_PAGE_SIZE is how many commits we load at a time. Some repos have
+// hundreds of thousands of commits so we load more as the user scrolls down.
+const COMMITS_PAGE_SIZE = 300
+
 // CommitListBuilder returns a list of Branch objects for the current repo
 type CommitListBuilder struct {
 	Log        *logrus.Entry
@@ -84,7 +88,8 @@ func (c *CommitListBuilder) extractCommitFromLine(line string) *models.Commit {
 }
 
 type GetCommitsOptions struct {
-	Limit                bool
+	// Limit is the most commits we'll load, or zero to load them all
+	Limit                int
 	FilterPath           string
 	IncludeRebaseCommits bool
 	RefName              string // e.g. "HEAD" or "my_branch"
@@ -139,16 +144,65 @@ func (c *CommitListBuilder) GetCommits(opts GetCommitsOptions) ([]*models.Commit
 		commits = append(commits, rebasingCommits...)
 	}
 
-	passedFirstPushedCommit := false
-	firstPushedCommit, err := c.getFirstPushedCommit(opts.RefName)
+	commits, err = c.appendLoggedCommits(commits, opts, 0)
 	if err != nil {
-		// must have no upstream branch so we'll consider everything as pushed
+		return nil, err
+	}
+
+	if rebaseMode != "" {
+		currentCommit := commits[len(rebasingCommits)]
+		youAreHere := style.FgYellow.Sprintf("<-- %s ---", c.Tr.YouAreHere)
+		currentCommit.Name = fmt.Sprintf("%s %s", youAreHere, currentCommit.Name)
+	}
+
+	return c.setCommitMergedStatuses(opts.RefName, commits)
+}
+
+// GetMoreCommits obtains the next page of commits to go after the given ones,
+// which we got from GetCommits with the same options. It returns all the commits
+// loaded so far.
+func (c *CommitListBuilder) GetMoreCommits(commits []*models.Commit, opts GetCommitsOptions) ([]*models.Commit, error) {
+	// the rebasing commits come from the todo file rather than git log
+	loggedCount := 0
+	for _, commit := range commits {
+		if commit.Status != "rebasing" {
+			loggedCount++
+		}
+	}
+
+	// copying so that we don't append onto the caller's slice
+	result := make([]*models.Commit, len(commits), len(commits)+opts.Limit)
+	copy(result, commits)
+
+	result, err := c.appendLoggedCommits(result, opts, loggedCount)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.setCommitMergedStatuses(opts.RefName, result)
+}
+
+// appendLoggedCommits runs git log, skipping the given number of commits, and
+// appends the results to the given commits, which we use to work out whether
+// we've already passed the first pushed commit
+func (c *CommitListBuilder) appendLoggedCommits(commits []*models.Commit, opts GetCommitsOptions, skip int) ([]*models.Commit, error) {
+	var firstPushedCommit string
+	passedFirstPushedCommit := false
+	if len(commits) > 0 && commits[len(commits)-1].Status != "unpushed" && commits[len(commits)-1].Status != "rebasing" {
+		// the unpushed commits all come before the pushed ones
 		passedFirstPushedCommit = true
+	} else {
+		var err error
+		firstPushedCommit, err = c.getFirstPushedCommit(opts.RefName)
+		if err != nil {
+			// must have no upstream branch so we'll consider everything as pushed
+			passedFirstPushedCommit = true
+		}
 	}
 
-	cmd := c.getLogCmd(opts)
+	cmd := c.getLogCmd(opts, skip)
 
-	err = oscommands.RunLineOutputCmd(cmd, func(line string) (bool, error) {
+	err := oscommands.RunLineOutputCmd(cmd, func(line string) (bool, error) {
 		if strings.Split(line, " ")[0] != "gpg:" {
 			commit := c.extractCommitFromLine(line)
 			if commit.Sha == firstPushedCommit {
@@ -163,17 +217,6 @@ func (c *CommitListBuilder) GetCommits(opts GetCommitsOptions) ([]*models.Commit
 		return nil, err
 	}
 
-	if rebaseMode != "" {
-		currentCommit := commits[len(rebasingCommits)]
-		youAreHere := style.FgYellow.Sprintf("<-- %s ---", c.Tr.YouAreHere)
-		currentCommit.Name = fmt.Sprintf("%s %s", youAreHere, currentCommit.Name)
-	}
-
-	commits, err = c.setCommitMergedStatuses(opts.RefName, commits)
-	if err != nil {
-		return nil, err
-	}
-
 	return commits, nil
 }
 
@@ -348,10 +391,13 @@ func (c *CommitListBuilder) getFirstPushedCommit(refName string) (string, error)
 }
 
 // getLog gets the git log.
-func (c *CommitListBuilder) getLogCmd(opts GetCommitsOptions) *exec.Cmd {
+func (c *CommitListBuilder) getLogCmd(opts GetCommitsOptions, skip int) *exec.Cmd {
 	limitFlag := ""
-	if opts.Limit {
-		limitFlag = "-300"
+	if opts.Limit > 0 {
+		limitFlag = fmt.Sprintf("-%d", opts.Limit)
+	}
+	if skip > 0 {
+		limitFlag += fmt.Sprintf(" --skip=%d", skip)
 	}
 
 	filterFlag := ""
@@ -4,6 +4,7 @@ import (
 	"os/exec"
 	"testing"
 
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
 	"github.com/jesseduffield/lazygit/pkg/i18n"
 	"github.com/jesseduffield/lazygit/pkg/secureexec"
@@ -112,3 +113,92 @@ func TestCommitListBuilderGetMergeBase(t *testing.T) {
 		})
 	}
 }
+
+// TestCommitListBuilderGetMoreCommits is a function.
+func TestCommitListBuilderGetMoreCommits(t *testing.T) {
+	type scenario struct {
+		testName      string
+		loadedCommits []*models.Commit
+		command       func(string, ...string) *exec.Cmd
+		test          func([]*models.Commit, error)
+	}
+
+	logOutput := "ccc|1600000000|Jesse| |bbb|third\nddd|1500000000|Jesse| |eee|fourth"
+
+	scenarios := []scenario{
+		{
+			"carries on from pushed and merged commits",
+			[]*models.Commit{
+				{Sha: "aaa", Status: "pushed"},
+				{Sha: "bbb", Status: "merged"},
+			},
+			func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+
+				switch args[0] {
+				case "log":
+					assert.Contains(t, args, "-2")
+					assert.Contains(t, args, "--skip=2")
+					return secureexec.Command("echo", logOutput)
+				case "symbolic-ref":
+					return secureexec.Command("echo", "master")
+				case "merge-base":
+					assert.EqualValues(t, []string{"merge-base", "HEAD", "master"}, args)
+					return secureexec.Command("echo", "bbb")
+				}
+				return nil
+			},
+			func(commits []*models.Commit, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, commits, 4)
+				assert.EqualValues(t, []string{"pushed", "merged", "merged", "merged"}, commitStatuses(commits))
+			},
+		},
+		{
+			"finds the first pushed commit on a later page",
+			[]*models.Commit{
+				{Sha: "rrr", Status: "rebasing"},
+				{Sha: "aaa", Status: "unpushed"},
+				{Sha: "bbb", Status: "unpushed"},
+			},
+			func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+
+				switch args[0] {
+				case "log":
+					// the rebasing commit doesn't come from git log so we don't skip it
+					assert.Contains(t, args, "--skip=2")
+					return secureexec.Command("echo", logOutput)
+				case "symbolic-ref":
+					return secureexec.Command("echo", "master")
+				case "merge-base":
+					if args[2] == "HEAD@{u}" {
+						return secureexec.Command("echo", "ddd")
+					}
+					return secureexec.Command("test")
+				}
+				return nil
+			},
+			func(commits []*models.Commit, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, []string{"rebasing", "unpushed", "unpushed", "unpushed", "pushed"}, commitStatuses(commits))
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			c := NewDummyCommitListBuilder()
+			c.OSCommand.SetCommand(s.command)
+			s.test(c.GetMoreCommits(s.loadedCommits, GetCommitsOptions{Limit: 2, RefName: "HEAD"}))
+		})
+	}
+}
+
+func commitStatuses(commits []*models.Commit) []string {
+	statuses := make([]string, len(commits))
+	for i, commit := range commits {
+		statuses[i] = commit.Status
+	}
+	return statuses
+}
@@ -67,6 +67,18 @@ func (c *GitCommand) GetStatusFiles(opts GetStatusFileOptions) []*models.File {
 		files = append(files, file)
 	}
 
+	paths := make([]string, len(files))
+	for i, file := range files {
+		paths[i] = file.Name
+	}
+	lfsPaths, err := c.GetLfsPaths(paths, "")
+	if err != nil {
+		c.Log.Error(err)
+	}
+	for _, file := range files {
+		file.IsLfs = lfsPaths[file.Name]
+	}
+
 	return files
 }
 
@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// we pass the shas of the lost commits as arguments so we don't want to hit the
+// limit on the length of a command line
+const lostCommitsBatchSize = 100
+
+// GetLostCommits returns the commits that can't be reached from any ref, newest
+// first. We don't count reflogs as refs, so this includes commits that you've
+// reset away from as well as dropped stash entries.
+func (c *GitCommand) GetLostCommits() ([]*models.Commit, error) {
+	output, err := c.OSCommand.RunCommandWithOutput("git fsck --unreachable --no-reflogs --no-progress")
+	if err != nil {
+		return nil, err
+	}
+
+	shas := parseUnreachableCommitShas(output)
+	commits := []*models.Commit{}
+	for start := 0; start < len(shas); start += lostCommitsBatchSize {
+		end := utils.Min(start+lostCommitsBatchSize, len(shas))
+		output, err := c.OSCommand.RunCommandWithOutput(
+			"git show --no-patch --format=\"%%H%s%%at%s%%an%s%%P%s%%s\" %s",
+			SEPARATION_CHAR,
+			SEPARATION_CHAR,
+			SEPARATION_CHAR,
+			SEPARATION_CHAR,
+			strings.Join(shas[start:end], " "),
+		)
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, parseLostCommits(output)...)
+	}
+
+	return sortLostCommits(commits), nil
+}
+
+// parseUnreachableCommitShas picks the commits out of the output of 'git fsck
+// --unreachable', which looks like:
+// unreachable blob 0e8b4d5c2e1d2c5a6b7e8f9a0b1c2d3e4f5a6b7c
+// unreachable commit 1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b0c
+func parseUnreachableCommitShas(output string) []string {
+	shas := []string{}
+	for _, line := range utils.SplitLines(output) {
+		fields := strings.Fields(line)
+		if len(fields) == 3 && fields[0] == "unreachable" && fields[1] == "commit" {
+			shas = append(shas, fields[2])
+		}
+	}
+	return shas
+}
+
+func parseLostCommits(output string) []*models.Commit {
+	commits := []*models.Commit{}
+	for _, line := range utils.SplitLines(output) {
+		fields := strings.SplitN(line, SEPARATION_CHAR, 5)
+		if len(fields) < 5 {
+			continue
+		}
+
+		unixTimestamp, _ := strconv.Atoi(fields[1])
+
+		commits = append(commits, &models.Commit{
+			Sha:           fields[0],
+			UnixTimestamp: int64(unixTimestamp),
+			Author:        fields[2],
+			Parents:       strings.Fields(fields[3]),
+			Name:          fields[4],
+		})
+	}
+	return commits
+}
+
+// sortLostCommits puts the newest commits first and leaves out the commits
+// that git stash made to hold the index and untracked files of a lost stash
+// entry, given that they come back with the entry itself
+func sortLostCommits(commits []*models.Commit) []*models.Commit {
+	stashParents := map[string]bool{}
+	for _, commit := range commits {
+		if commit.IsStash() {
+			for _, parent := range commit.Parents[1:] {
+				stashParents[parent] = true
+			}
+		}
+	}
+
+	result := []*models.Commit{}
+	for _, commit := range commits {
+		if !stashParents[commit.Sha] {
+			result = append(result, commit)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].UnixTimestamp > result[j].UnixTimestamp
+	})
+
+	return result
+}
+
+// ShowLostCommitCmdStr is like ShowCmdStr but shows the changes of a stash
+// commit relative to the commit it was made on, like 'git stash show' does
+func (c *GitCommand) ShowLostCommitCmdStr(commit *models.Commit, plain bool) string {
+	if !commit.IsStash() {
+		return c.ShowCmdStr(commit.Sha, "", plain)
+	}
+
+	colorArg := c.colorArg()
+	if plain {
+		colorArg = "never"
+	}
+
+	return fmt.Sprintf("git diff --stat -p --color=%s %s %s", colorArg, commit.Parents[0], commit.Sha)
+}
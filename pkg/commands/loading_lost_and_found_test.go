@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/secureexec"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandGetLostCommits is a function.
+func TestGitCommandGetLostCommits(t *testing.T) {
+	type scenario struct {
+		testName string
+		command  func(string, ...string) *exec.Cmd
+		test     func([]*models.Commit, error)
+	}
+
+	scenarios := []scenario{
+		{
+			"No unreachable commits",
+			func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				assert.EqualValues(t, []string{"fsck", "--unreachable", "--no-reflogs", "--no-progress"}, args)
+				return secureexec.Command("echo", "unreachable blob 0e8b4d5c2e1d2c5a6b7e8f9a0b1c2d3e4f5a6b7c")
+			},
+			func(commits []*models.Commit, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, commits, 0)
+			},
+		},
+		{
+			"Lost commits and a dropped stash entry",
+			func(cmd string, args ...string) *exec.Cmd {
+				if args[0] == "fsck" {
+					return secureexec.Command("echo", "unreachable commit aaa\nunreachable tree bbb\nunreachable commit ccc\nunreachable commit ddd\n")
+				}
+
+				assert.EqualValues(t, []string{"show", "--no-patch", "--format=%H|%at|%an|%P|%s", "aaa", "ccc", "ddd"}, args)
+				return secureexec.Command("echo", "aaa|1600000000|Jesse|eee|add a|b feature\nccc|1700000000|Jesse|eee ddd|WIP on master: eee add a|b feature\nddd|1700000000|Jesse|eee|index on master: eee add a|b feature\n")
+			},
+			func(commits []*models.Commit, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, []*models.Commit{
+					{
+						Sha:           "ccc",
+						Name:          "WIP on master: eee add a|b feature",
+						Author:        "Jesse",
+						UnixTimestamp: 1700000000,
+						Parents:       []string{"eee", "ddd"},
+					},
+					{
+						Sha:           "aaa",
+						Name:          "add a|b feature",
+						Author:        "Jesse",
+						UnixTimestamp: 1600000000,
+						Parents:       []string{"eee"},
+					},
+				}, commits)
+				assert.True(t, commits[0].IsStash())
+				assert.False(t, commits[1].IsStash())
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.Command = s.command
+
+			s.test(gitCmd.GetLostCommits())
+		})
+	}
+}
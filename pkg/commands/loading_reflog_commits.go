@@ -2,39 +2,67 @@ package commands
 
 import (
 	"fmt"
-	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
 )
 
+type GetReflogCommitsOptions struct {
+	FilterPath string
+	// Limit is the most entries we'll load, or zero to load them all
+	Limit int
+	// Skip is the number of newest entries to leave out, for loading the next
+	// page of entries after the ones we've already got
+	Skip int
+}
+
 // GetReflogCommits only returns the new reflog commits since the given lastReflogCommit
 // if none is passed (i.e. it's value is nil) then we get all the reflog commits
-func (c *GitCommand) GetReflogCommits(lastReflogCommit *models.Commit, filterPath string) ([]*models.Commit, bool, error) {
+func (c *GitCommand) GetReflogCommits(lastReflogCommit *models.Commit, opts GetReflogCommitsOptions) ([]*models.Commit, bool, error) {
 	commits := make([]*models.Commit, 0)
-	re := regexp.MustCompile(`(\w+).*HEAD@\{([^\}]+)\}: (.*)`)
+
+	pageArgs := ""
+	if opts.Limit > 0 {
+		pageArgs += fmt.Sprintf(" -n %d", opts.Limit)
+	}
+	if opts.Skip > 0 {
+		pageArgs += fmt.Sprintf(" --skip=%d", opts.Skip)
+	}
 
 	filterPathArg := ""
-	if filterPath != "" {
-		filterPathArg = fmt.Sprintf(" --follow -- %s", c.OSCommand.Quote(filterPath))
+	if opts.FilterPath != "" {
+		filterPathArg = fmt.Sprintf(" --follow -- %s", c.OSCommand.Quote(opts.FilterPath))
 	}
 
-	cmd := c.OSCommand.ExecutableFromString(fmt.Sprintf("git reflog --abbrev=20 --date=unix %s", filterPathArg))
+	// we get the parents too so that we can draw the commit graph
+	cmd := c.OSCommand.ExecutableFromString(
+		fmt.Sprintf(
+			"git reflog --abbrev=20 --date=unix --pretty=format:\"%%h%s%%gd%s%%p%s%%gs\"%s%s",
+			SEPARATION_CHAR,
+			SEPARATION_CHAR,
+			SEPARATION_CHAR,
+			pageArgs,
+			filterPathArg,
+		),
+	)
 	onlyObtainedNewReflogCommits := false
 	err := oscommands.RunLineOutputCmd(cmd, func(line string) (bool, error) {
-		match := re.FindStringSubmatch(line)
-		if len(match) <= 1 {
+		fields := strings.SplitN(line, SEPARATION_CHAR, 4)
+		if len(fields) < 4 {
 			return false, nil
 		}
 
-		unixTimestamp, _ := strconv.Atoi(match[2])
+		// the selector looks like 'HEAD@{1620000000}' because we've passed --date=unix
+		unixTimestamp, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(fields[1], "HEAD@{"), "}"))
 
 		commit := &models.Commit{
-			Sha:           match[1],
-			Name:          match[3],
+			Sha:           fields[0],
+			Name:          fields[3],
 			UnixTimestamp: int64(unixTimestamp),
 			Status:        "reflog",
+			Parents:       strings.Fields(fields[2]),
 		}
 
 		if lastReflogCommit != nil && commit.Sha == lastReflogCommit.Sha && commit.UnixTimestamp == lastReflogCommit.UnixTimestamp {
@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/secureexec"
+	"github.com/stretchr/testify/assert"
+)
+
+const reflogOutput = `c3c4b66b64c97ffeecde|HEAD@{1621000000}|5a6b7c8d9e0f1a2b3c4d|checkout: moving from A to B
+5a6b7c8d9e0f1a2b3c4d|HEAD@{1620000000}|9f8e7d6c5b4a39281706 1b2c3d4e5f6a7b8c9d0e|merge feature: Merge made by the 'recursive' strategy.
+9f8e7d6c5b4a39281706|HEAD@{1610000000}||commit (initial): first | commit`
+
+// TestGitCommandGetReflogCommits is a function.
+func TestGitCommandGetReflogCommits(t *testing.T) {
+	type scenario struct {
+		testName         string
+		lastReflogCommit *models.Commit
+		opts             GetReflogCommitsOptions
+		expectedArgs     []string
+		test             func([]*models.Commit, bool, error)
+	}
+
+	defaultArgs := []string{"reflog", "--abbrev=20", "--date=unix", "--pretty=format:%h|%gd|%p|%gs"}
+
+	scenarios := []scenario{
+		{
+			"All reflog commits",
+			nil,
+			GetReflogCommitsOptions{},
+			defaultArgs,
+			func(commits []*models.Commit, onlyObtainedNew bool, err error) {
+				assert.NoError(t, err)
+				assert.False(t, onlyObtainedNew)
+				assert.EqualValues(t, []*models.Commit{
+					{
+						Sha:           "c3c4b66b64c97ffeecde",
+						Name:          "checkout: moving from A to B",
+						Status:        "reflog",
+						UnixTimestamp: 1621000000,
+						Parents:       []string{"5a6b7c8d9e0f1a2b3c4d"},
+					},
+					{
+						Sha:           "5a6b7c8d9e0f1a2b3c4d",
+						Name:          "merge feature: Merge made by the 'recursive' strategy.",
+						Status:        "reflog",
+						UnixTimestamp: 1620000000,
+						Parents:       []string{"9f8e7d6c5b4a39281706", "1b2c3d4e5f6a7b8c9d0e"},
+					},
+					{
+						Sha:           "9f8e7d6c5b4a39281706",
+						Name:          "commit (initial): first | commit",
+						Status:        "reflog",
+						UnixTimestamp: 1610000000,
+						Parents:       []string{},
+					},
+				}, commits)
+			},
+		},
+		{
+			"Only new reflog commits",
+			&models.Commit{Sha: "5a6b7c8d9e0f1a2b3c4d", UnixTimestamp: 1620000000},
+			GetReflogCommitsOptions{},
+			defaultArgs,
+			func(commits []*models.Commit, onlyObtainedNew bool, err error) {
+				assert.NoError(t, err)
+				assert.True(t, onlyObtainedNew)
+				assert.Len(t, commits, 1)
+				assert.Equal(t, "c3c4b66b64c97ffeecde", commits[0].Sha)
+			},
+		},
+		{
+			"Next page of reflog commits",
+			nil,
+			GetReflogCommitsOptions{Limit: 300, Skip: 300},
+			append(defaultArgs, "-n", "300", "--skip=300"),
+			func(commits []*models.Commit, onlyObtainedNew bool, err error) {
+				assert.NoError(t, err)
+				assert.False(t, onlyObtainedNew)
+				assert.Len(t, commits, 3)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.Command = func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				assert.EqualValues(t, s.expectedArgs, args)
+
+				return secureexec.Command("echo", reflogOutput)
+			}
+
+			s.test(gitCmd.GetReflogCommits(s.lastReflogCommit, s.opts))
+		})
+	}
+}
@@ -1,34 +1,63 @@
 package commands
 
 import (
+	"strconv"
 	"strings"
 
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
-	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
+// each field is separated by a null byte and each tag is terminated by one, given
+// that a tag's message can span multiple lines
+const tagFormat = "%(refname:short)%00" +
+	"%(objecttype)%00" +
+	"%(objectname)%00" +
+	"%(*objectname)%00" +
+	"%(taggername)%00" +
+	"%(creatordate:unix)%00" +
+	"%(if)%(contents:signature)%(then)signed%(end)%00" +
+	"%(contents:subject)%00" +
+	"%(contents:body)%00"
+
 func (c *GitCommand) GetTags() ([]*models.Tag, error) {
-	// get remote branches, sorted  by creation date (descending)
+	// get tags, sorted by creation date (descending)
 	// see: https://git-scm.com/docs/git-tag#Documentation/git-tag.txt---sortltkeygt
-	remoteBranchesStr, err := c.OSCommand.RunCommandWithOutput(`git tag --list --sort=-creatordate`)
+	output, err := c.OSCommand.RunCommandWithOutput(`git for-each-ref --sort=-creatordate --format="%s" refs/tags`, tagFormat)
 	if err != nil {
 		return nil, err
 	}
 
-	content := utils.TrimTrailingNewline(remoteBranchesStr)
-	if content == "" {
-		return nil, nil
-	}
+	return parseTags(output), nil
+}
+
+func parseTags(output string) []*models.Tag {
+	tags := []*models.Tag{}
+	for _, record := range strings.Split(output, "\x00\n") {
+		fields := strings.Split(strings.TrimPrefix(record, "\n"), "\x00")
+		if len(fields) < 9 {
+			continue
+		}
+
+		unixTimestamp, _ := strconv.Atoi(fields[5])
 
-	split := strings.Split(content, "\n")
+		tag := &models.Tag{
+			Name:          fields[0],
+			Sha:           fields[2],
+			UnixTimestamp: int64(unixTimestamp),
+		}
 
-	// first step is to get our remotes from go-git
-	tags := make([]*models.Tag, len(split))
-	for i, tagName := range split {
-		tags[i] = &models.Tag{
-			Name: tagName,
+		// a lightweight tag points straight at a commit. An annotated tag points at a
+		// tag object, which in turn points at the commit
+		if fields[1] == "tag" {
+			tag.Annotated = true
+			tag.Sha = fields[3]
+			tag.Tagger = fields[4]
+			tag.Signed = fields[6] == "signed"
+			tag.Message = strings.TrimSpace(fields[7] + "\n\n" + fields[8])
 		}
+
+		tags = append(tags, tag)
 	}
 
-	return tags, nil
+	return tags
 }
@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// `git worktree list --porcelain` gives us something like:
+// worktree /path/to/repo
+// HEAD 0e8b4d5c2e1d2c5a6b7e8f9a0b1c2d3e4f5a6b7c
+// branch refs/heads/master
+//
+// worktree /path/to/repo-feature
+// HEAD 1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b0c
+// detached
+// locked reason goes here
+//
+// The first entry is always the main worktree.
+
+func (c *GitCommand) GetWorktrees() ([]*models.Worktree, error) {
+	output, err := c.OSCommand.RunCommandWithOutput("git worktree list --porcelain")
+	if err != nil {
+		return nil, err
+	}
+
+	currentPath, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	return parseWorktrees(output, currentPath), nil
+}
+
+func parseWorktrees(output string, currentPath string) []*models.Worktree {
+	worktrees := []*models.Worktree{}
+	var current *models.Worktree
+
+	for _, line := range utils.SplitLines(output) {
+		field, value := line, ""
+		if i := strings.Index(line, " "); i != -1 {
+			field, value = line[:i], line[i+1:]
+		}
+
+		if field == "worktree" {
+			current = &models.Worktree{
+				Path:    value,
+				Main:    len(worktrees) == 0,
+				Current: samePath(value, currentPath),
+			}
+			worktrees = append(worktrees, current)
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		switch field {
+		case "HEAD":
+			current.Head = value
+		case "branch":
+			current.Branch = strings.TrimPrefix(value, "refs/heads/")
+		case "bare":
+			current.Bare = true
+		case "locked":
+			current.Locked = true
+		case "prunable":
+			current.Prunable = true
+		}
+	}
+
+	return worktrees
+}
+
+func samePath(a string, b string) bool {
+	if a == b {
+		return true
+	}
+
+	resolvedA, err := filepath.EvalSymlinks(a)
+	if err != nil {
+		return false
+	}
+	resolvedB, err := filepath.EvalSymlinks(b)
+	if err != nil {
+		return false
+	}
+
+	return filepath.Clean(resolvedA) == filepath.Clean(resolvedB)
+}
@@ -0,0 +1,146 @@
+package commands
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/secureexec"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandGetWorktrees is a function.
+func TestGitCommandGetWorktrees(t *testing.T) {
+	currentPath, err := os.Getwd()
+	assert.NoError(t, err)
+
+	type scenario struct {
+		testName string
+		command  func(string, ...string) *exec.Cmd
+		test     func([]*models.Worktree, error)
+	}
+
+	scenarios := []scenario{
+		{
+			"Main worktree only",
+			func(string, ...string) *exec.Cmd {
+				return secureexec.Command("echo", "worktree "+currentPath+"\nHEAD 0e8b4d5c\nbranch refs/heads/master\n")
+			},
+			func(worktrees []*models.Worktree, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, []*models.Worktree{
+					{
+						Path:    currentPath,
+						Head:    "0e8b4d5c",
+						Branch:  "master",
+						Main:    true,
+						Current: true,
+					},
+				}, worktrees)
+			},
+		},
+		{
+			"Several worktrees",
+			func(string, ...string) *exec.Cmd {
+				return secureexec.Command(
+					"echo",
+					"worktree /repo\nHEAD 0e8b4d5c\nbranch refs/heads/master\n\n"+
+						"worktree /repo-feature\nHEAD 1b2c3d4e\nbranch refs/heads/feature/thing\nlocked\n\n"+
+						"worktree /repo-detached\nHEAD 5a6b7c8d\ndetached\nprunable gitdir file points to non-existent location\n",
+				)
+			},
+			func(worktrees []*models.Worktree, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, []*models.Worktree{
+					{
+						Path:   "/repo",
+						Head:   "0e8b4d5c",
+						Branch: "master",
+						Main:   true,
+					},
+					{
+						Path:   "/repo-feature",
+						Head:   "1b2c3d4e",
+						Branch: "feature/thing",
+						Locked: true,
+					},
+					{
+						Path:     "/repo-detached",
+						Head:     "5a6b7c8d",
+						Prunable: true,
+					},
+				}, worktrees)
+				assert.True(t, worktrees[2].IsDetached())
+			},
+		},
+		{
+			"Bare main repo",
+			func(string, ...string) *exec.Cmd {
+				return secureexec.Command("echo", "worktree /repo.git\nbare\n\nworktree /repo-main\nHEAD 0e8b4d5c\nbranch refs/heads/main\n")
+			},
+			func(worktrees []*models.Worktree, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, worktrees, 2)
+				assert.True(t, worktrees[0].Bare)
+				assert.False(t, worktrees[0].IsDetached())
+				assert.Equal(t, "main", worktrees[1].Branch)
+			},
+		},
+		{
+			"Command fails",
+			func(string, ...string) *exec.Cmd {
+				return secureexec.Command("test")
+			},
+			func(worktrees []*models.Worktree, err error) {
+				assert.Error(t, err)
+				assert.Nil(t, worktrees)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.Command = s.command
+
+			s.test(gitCmd.GetWorktrees())
+		})
+	}
+}
+
+// TestGitCommandRemoveWorktree is a function.
+func TestGitCommandRemoveWorktree(t *testing.T) {
+	type scenario struct {
+		testName string
+		force    bool
+		expected []string
+	}
+
+	scenarios := []scenario{
+		{
+			"Remove",
+			false,
+			[]string{"worktree", "remove", "/repo-feature"},
+		},
+		{
+			"Force remove",
+			true,
+			[]string{"worktree", "remove", "--force", "/repo-feature"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.Command = func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				assert.EqualValues(t, s.expected, args)
+
+				return secureexec.Command("echo")
+			}
+
+			assert.NoError(t, gitCmd.RemoveWorktree("/repo-feature", s.force))
+		})
+	}
+}
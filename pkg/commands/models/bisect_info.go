@@ -0,0 +1,82 @@
+package models
+
+type BisectStatus int
+
+const (
+	BisectStatusNone BisectStatus = iota
+	BisectStatusOld
+	BisectStatusNew
+	BisectStatusSkipped
+)
+
+// BisectInfo describes the state of a bisect, as recorded by git in the .git
+// directory. We read it fresh on each refresh so that if you quit lazygit
+// mid-bisect you can pick up where you left off.
+type BisectInfo struct {
+	// the ref that was checked out when the bisect began, which we return to upon
+	// resetting. If this is blank we're not bisecting
+	Start string
+
+	// the terms used to mark commits. By default these are 'bad' and 'good'
+	NewTerm string
+	OldTerm string
+
+	// the commit git has checked out for us to test
+	Current string
+
+	// maps a commit sha to how it has been marked
+	StatusMap map[string]BisectStatus
+
+	// the most recently marked new commit. Git only cares about the latest one
+	NewSha string
+	// all commits marked as old
+	OldShas []string
+
+	// the number of commits which may still be the first new commit, or -1
+	// if we don't yet have both a new and an old commit to go by
+	Candidates int
+
+	// set once git has worked out which commit introduced the change
+	FirstNewSha string
+}
+
+func NewBisectInfo() *BisectInfo {
+	return &BisectInfo{
+		NewTerm:    "bad",
+		OldTerm:    "good",
+		StatusMap:  map[string]BisectStatus{},
+		Candidates: -1,
+	}
+}
+
+func (b *BisectInfo) Started() bool {
+	return b.Start != ""
+}
+
+// Bisecting tells us whether we've marked both a new and an old commit, meaning
+// git is now checking out commits for us to test
+func (b *BisectInfo) Bisecting() bool {
+	return b.Started() && b.NewSha != "" && len(b.OldShas) > 0
+}
+
+func (b *BisectInfo) Finished() bool {
+	return b.FirstNewSha != ""
+}
+
+func (b *BisectInfo) Status(sha string) BisectStatus {
+	return b.StatusMap[sha]
+}
+
+// Term returns the word used to mark a commit with the given status
+func (b *BisectInfo) Term(status BisectStatus) string {
+	switch status {
+	case BisectStatusNew:
+		return b.NewTerm
+	case BisectStatusOld:
+		return b.OldTerm
+	case BisectStatusSkipped:
+		return "skip"
+	default:
+		return ""
+	}
+}
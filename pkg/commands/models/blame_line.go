@@ -0,0 +1,34 @@
+package models
+
+import "strings"
+
+// BlameLine : A line of a file along with the commit that last changed it
+type BlameLine struct {
+	Sha        string
+	Author     string
+	AuthorTime int64 // unix timestamp
+	Summary    string
+
+	// the commit before Sha and the path of the file in it, which are empty if
+	// the line was added in a root commit
+	PreviousSha  string
+	PreviousPath string
+
+	// the number of the line in the version of the file from Sha, starting at 1
+	OriginalLineNumber int
+
+	Content string
+}
+
+func (l *BlameLine) ShortSha() string {
+	if len(l.Sha) < 8 {
+		return l.Sha
+	}
+	return l.Sha[:8]
+}
+
+// IsCommitted is false for lines which have only been changed in the working
+// tree, in which case git gives a sha of all zeros
+func (l *BlameLine) IsCommitted() bool {
+	return strings.Trim(l.Sha, "0") != ""
+}
@@ -11,6 +11,16 @@ type Branch struct {
 	Pullables    string
 	UpstreamName string
 	Head         bool
+
+	// PushRemote is the remote we push the branch to when it's not the one we
+	// pull from, like our fork in a workflow where we pull from 'upstream' and
+	// push to 'origin'. It comes from branch.<name>.pushRemote or
+	// remote.pushDefault.
+	PushRemote string
+	// like Pushables and Pullables but against the branch we push to on the
+	// push remote, or "?" if it's not there yet
+	PushRemotePushables string
+	PushRemotePullables string
 }
 
 func (b *Branch) RefName() string {
@@ -43,6 +53,20 @@ func (b *Branch) HasCommitsToPull() bool {
 	return b.IsRealBranch() && b.Pullables != "0"
 }
 
+func (b *Branch) HasPushRemote() bool {
+	return b.IsRealBranch() && b.PushRemote != ""
+}
+
+func (b *Branch) MatchesPushRemote() bool {
+	return b.HasPushRemote() && b.PushRemotePushables == "0" && b.PushRemotePullables == "0"
+}
+
+// HasCommitsToPullFromPushRemote tells us whether pushing to the push remote
+// would need a force push
+func (b *Branch) HasCommitsToPullFromPushRemote() bool {
+	return b.HasPushRemote() && b.PushRemotePullables != "0" && b.PushRemotePullables != "?"
+}
+
 // for when we're in a detached head state
 func (b *Branch) IsRealBranch() bool {
 	return b.Pushables != "" && b.Pullables != ""
@@ -1,6 +1,14 @@
 package models
 
-import "fmt"
+import (
+	"fmt"
+	"regexp"
+)
+
+// a stash entry's commit has the index (and maybe the untracked files) as extra
+// parents and a subject like 'WIP on master: 123abc commit message' or
+// 'On master: my stash message'
+var stashSubjectRegexp = regexp.MustCompile(`^(WIP on|On) [^:]+: `)
 
 // Commit : A git commit
 type Commit struct {
@@ -39,3 +47,9 @@ func (c *Commit) Description() string {
 func (c *Commit) IsMerge() bool {
 	return len(c.Parents) > 1
 }
+
+// IsStash tells us whether the commit looks like it was made by 'git stash'
+// and so can be restored as a stash entry
+func (c *Commit) IsStash() bool {
+	return c.IsMerge() && stashSubjectRegexp.MatchString(c.Name)
+}
@@ -19,6 +19,7 @@ type File struct {
 	DisplayString           string
 	Type                    string // one of 'file', 'directory', and 'other'
 	ShortStatus             string // e.g. 'AD', ' A', 'M ', '??'
+	IsLfs                   bool   // whether the file is stored with Git LFS
 }
 
 // sometimes we need to deal with either a node (which contains a file) or an actual file
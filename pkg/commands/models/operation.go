@@ -0,0 +1,37 @@
+package models
+
+type OperationKind string
+
+const (
+	// HEAD moved from one commit to another e.g. by committing, resetting or rebasing
+	OPERATION_MOVE_HEAD OperationKind = "moveHead"
+	OPERATION_CHECKOUT  OperationKind = "checkout"
+
+	OPERATION_CREATE_BRANCH        OperationKind = "createBranch"
+	OPERATION_DELETE_BRANCH        OperationKind = "deleteBranch"
+	OPERATION_RENAME_BRANCH        OperationKind = "renameBranch"
+	OPERATION_DELETE_TAG           OperationKind = "deleteTag"
+	OPERATION_DROP_STASH           OperationKind = "dropStash"
+	OPERATION_DELETE_REMOTE_BRANCH OperationKind = "deleteRemoteBranch"
+)
+
+// Operation : A change made to the repo along with what we need to know to
+// reverse it. Which fields are set depends on the kind of operation.
+type Operation struct {
+	Kind          OperationKind
+	UnixTimestamp int64
+
+	// the reflog entry for operations we've learnt about from the reflog
+	Summary string
+
+	// the commits HEAD moved between, or for a checkout or rename, the names of
+	// the refs before and after
+	From string
+	To   string
+
+	// the branch, tag or remote branch affected and the commit it pointed to. For
+	// a stash entry, the name is the stash's message.
+	Name   string
+	Sha    string
+	Remote string
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"path"
+	"strings"
+)
+
+// SparseCheckoutCone is the set of directories checked out in a cone mode sparse
+// checkout. Everything inside those directories is checked out, as are the
+// files (but not the subdirectories) directly inside each of their ancestors.
+type SparseCheckoutCone struct {
+	Dirs []string
+}
+
+type ConeStatus int
+
+const (
+	ConeStatusOutside ConeStatus = iota
+	// only the files directly inside the directory are checked out, because it
+	// contains a directory in the cone
+	ConeStatusPartlyInside
+	ConeStatusInside
+)
+
+// Status tells us how much of the given directory is checked out
+func (c *SparseCheckoutCone) Status(dir string) ConeStatus {
+	status := ConeStatusOutside
+	for _, coneDir := range c.Dirs {
+		if coneDir == dir || isInsideDir(dir, coneDir) {
+			return ConeStatusInside
+		}
+		if isInsideDir(coneDir, dir) {
+			status = ConeStatusPartlyInside
+		}
+	}
+
+	return status
+}
+
+// Without returns the cone's directories with the given directory taken out.
+// If the directory is only in the cone because one of its ancestors is, we
+// replace that ancestor with everything alongside the directory and alongside
+// each of its ancestors in between. allDirs is every directory in the repo.
+func (c *SparseCheckoutCone) Without(dir string, allDirs []string) []string {
+	result := []string{}
+	for _, coneDir := range c.Dirs {
+		switch {
+		case coneDir == dir || isInsideDir(coneDir, dir):
+			continue
+		case isInsideDir(dir, coneDir):
+			for _, otherDir := range allDirs {
+				parent := path.Dir(otherDir)
+				onPath := parent == coneDir || (isInsideDir(parent, coneDir) && isInsideDir(dir, parent))
+				if onPath && otherDir != dir && !isInsideDir(dir, otherDir) {
+					result = append(result, otherDir)
+				}
+			}
+		default:
+			result = append(result, coneDir)
+		}
+	}
+
+	return result
+}
+
+func isInsideDir(p string, dir string) bool {
+	return strings.HasPrefix(p, dir+"/")
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSparseCheckoutConeStatus is a function.
+func TestSparseCheckoutConeStatus(t *testing.T) {
+	cone := &SparseCheckoutCone{Dirs: []string{"services/api", "libs"}}
+
+	type scenario struct {
+		dir      string
+		expected ConeStatus
+	}
+
+	scenarios := []scenario{
+		{"services/api", ConeStatusInside},
+		{"services/api/handlers", ConeStatusInside},
+		{"libs/shared", ConeStatusInside},
+		{"services", ConeStatusPartlyInside},
+		{"services/web", ConeStatusOutside},
+		{"services/apiclient", ConeStatusOutside},
+		{"docs", ConeStatusOutside},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.dir, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, cone.Status(s.dir))
+		})
+	}
+}
+
+// TestSparseCheckoutConeWithout is a function.
+func TestSparseCheckoutConeWithout(t *testing.T) {
+	allDirs := []string{
+		"docs",
+		"libs",
+		"libs/shared",
+		"libs/shared/util",
+		"libs/shared/util/strings",
+		"libs/shared/util/time",
+		"libs/ui",
+		"libs/ui/buttons",
+		"services",
+		"services/api",
+	}
+
+	type scenario struct {
+		testName string
+		coneDirs []string
+		dir      string
+		expected []string
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "Directory in the cone",
+			coneDirs: []string{"services/api", "docs"},
+			dir:      "services/api",
+			expected: []string{"docs"},
+		},
+		{
+			testName: "Directory containing directories in the cone",
+			coneDirs: []string{"libs/shared", "libs/ui", "docs"},
+			dir:      "libs",
+			expected: []string{"docs"},
+		},
+		{
+			testName: "Directory inside a directory in the cone",
+			coneDirs: []string{"libs", "docs"},
+			dir:      "libs/shared/util",
+			expected: []string{"libs/ui", "docs"},
+		},
+		{
+			testName: "Directory directly inside a directory in the cone",
+			coneDirs: []string{"libs"},
+			dir:      "libs/ui",
+			expected: []string{"libs/shared"},
+		},
+		{
+			testName: "Directory outside the cone",
+			coneDirs: []string{"docs"},
+			dir:      "services",
+			expected: []string{"docs"},
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.testName, func(t *testing.T) {
+			cone := &SparseCheckoutCone{Dirs: s.coneDirs}
+			assert.EqualValues(t, s.expected, cone.Without(s.dir, allDirs))
+		})
+	}
+}
@@ -3,6 +3,15 @@ package models
 // Tag : A git tag
 type Tag struct {
 	Name string
+	// the sha of the commit the tag points to
+	Sha string
+	// lightweight tags are just a ref, whereas annotated tags are objects in their
+	// own right with a tagger, date, and message
+	Annotated     bool
+	Signed        bool
+	Tagger        string
+	UnixTimestamp int64
+	Message       string
 }
 
 func (t *Tag) RefName() string {
@@ -0,0 +1,38 @@
+package models
+
+import "path/filepath"
+
+// Worktree : A git worktree
+type Worktree struct {
+	Path string
+	Head string
+	// the branch checked out in the worktree. Empty when the worktree has a detached head
+	Branch string
+	// true for the worktree that lives alongside the repo's .git directory
+	Main bool
+	// true for the worktree that lazygit is currently open in
+	Current  bool
+	Bare     bool
+	Locked   bool
+	Prunable bool
+}
+
+func (w *Worktree) RefName() string {
+	return w.Path
+}
+
+func (w *Worktree) ID() string {
+	return w.RefName()
+}
+
+func (w *Worktree) Description() string {
+	return w.RefName()
+}
+
+func (w *Worktree) Name() string {
+	return filepath.Base(w.Path)
+}
+
+func (w *Worktree) IsDetached() bool {
+	return w.Branch == "" && !w.Bare
+}
@@ -0,0 +1,410 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// We keep a journal of the operations performed on the repo so that they can be
+// undone and redone. Operations which move HEAD (committing, resetting,
+// checking out etc) are picked up from the reflog, whether or not they were
+// performed through lazygit. Other operations like deleting a branch or dropping
+// a stash entry leave no trace in the reflog, so we record them ourselves as
+// they happen, along with whatever we'll need to reverse them.
+// The journal lives in the .git directory so it survives restarting lazygit.
+
+// maxJournalOperations is how many operations we keep around to be undone
+const maxJournalOperations = 100
+
+// the journal is read and written by different goroutines
+var operationJournalMutex sync.Mutex
+
+type operationJournal struct {
+	UndoStack []*models.Operation
+	RedoStack []*models.Operation
+
+	// the newest reflog entry we've looked at, so that next time we only
+	// import the operations that have happened since
+	LastReflogSha       string
+	LastReflogTimestamp int64
+}
+
+// push records a new operation. Any redo entries are discarded because they
+// no longer follow on from the current state of the repo.
+func (j *operationJournal) push(operation *models.Operation) {
+	j.UndoStack = append(j.UndoStack, operation)
+	if len(j.UndoStack) > maxJournalOperations {
+		j.UndoStack = j.UndoStack[len(j.UndoStack)-maxJournalOperations:]
+	}
+	j.RedoStack = nil
+}
+
+func (j *operationJournal) peekUndo() *models.Operation {
+	if len(j.UndoStack) == 0 {
+		return nil
+	}
+	return j.UndoStack[len(j.UndoStack)-1]
+}
+
+func (j *operationJournal) peekRedo() *models.Operation {
+	if len(j.RedoStack) == 0 {
+		return nil
+	}
+	return j.RedoStack[len(j.RedoStack)-1]
+}
+
+// undo moves the given operation from the undo stack to the redo stack. It
+// returns false if the operation is no longer the next one to undo, which can
+// happen if something else has happened in the meantime.
+func (j *operationJournal) undo(operation *models.Operation) bool {
+	return j.shift(&j.UndoStack, &j.RedoStack, operation)
+}
+
+func (j *operationJournal) redo(operation *models.Operation) bool {
+	return j.shift(&j.RedoStack, &j.UndoStack, operation)
+}
+
+func (j *operationJournal) shift(from *[]*models.Operation, to *[]*models.Operation, operation *models.Operation) bool {
+	if len(*from) == 0 || *(*from)[len(*from)-1] != *operation {
+		return false
+	}
+
+	*from = (*from)[:len(*from)-1]
+	*to = append(*to, operation)
+
+	return true
+}
+
+// operationsFromReflog turns the given reflog entries (newest first) into the
+// operations that they describe (oldest first). previousSha is the commit HEAD
+// was at before the oldest of the entries, if known.
+func operationsFromReflog(reflogCommits []*models.Commit, previousSha string) []*models.Operation {
+	operations := []*models.Operation{}
+	var rebaseFinishCommit *models.Commit
+
+	for i, reflogCommit := range reflogCommits {
+		prevCommitSha := previousSha
+		if i+1 < len(reflogCommits) {
+			prevCommitSha = reflogCommits[i+1].Sha
+		}
+
+		var operation *models.Operation
+
+		if rebaseFinishCommit == nil {
+			if ok, _ := utils.FindStringSubmatch(reflogCommit.Name, `^\[lazygit (undo|redo)\]`); ok {
+				// we've already accounted for these when undoing or redoing
+				continue
+			} else if ok, _ := utils.FindStringSubmatch(reflogCommit.Name, `^rebase( -i)? \((abort|finish)\)`); ok {
+				rebaseFinishCommit = reflogCommit
+			} else if ok, match := utils.FindStringSubmatch(reflogCommit.Name, `^checkout: moving from ([\S]+) to ([\S]+)`); ok {
+				operation = &models.Operation{Kind: models.OPERATION_CHECKOUT, From: match[1], To: match[2]}
+			} else if ok, _ := utils.FindStringSubmatch(reflogCommit.Name, `^commit|^reset: moving to|^pull|^merge |^cherry-pick`); ok {
+				operation = &models.Operation{Kind: models.OPERATION_MOVE_HEAD, From: prevCommitSha, To: reflogCommit.Sha}
+			}
+		} else if ok, _ := utils.FindStringSubmatch(reflogCommit.Name, `^rebase( -i)? \(start\)`); ok {
+			// the individual steps of the rebase are undone all at once
+			operation = &models.Operation{Kind: models.OPERATION_MOVE_HEAD, From: prevCommitSha, To: rebaseFinishCommit.Sha}
+			reflogCommit = rebaseFinishCommit
+			rebaseFinishCommit = nil
+		}
+
+		// if we're going from one place to the same place (or from who knows where)
+		// there's nothing to undo
+		if operation == nil || operation.From == "" || operation.From == operation.To {
+			continue
+		}
+
+		operation.Summary = reflogCommit.Name
+		operation.UnixTimestamp = reflogCommit.UnixTimestamp
+		operations = append([]*models.Operation{operation}, operations...)
+	}
+
+	return operations
+}
+
+func (c *GitCommand) operationJournalPath() string {
+	return filepath.Join(c.DotGitDir, "lazygit", "operations.json")
+}
+
+func (c *GitCommand) readOperationJournal() (*operationJournal, error) {
+	journal := &operationJournal{}
+
+	content, err := ioutil.ReadFile(c.operationJournalPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return journal, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(content, journal); err != nil {
+		return nil, err
+	}
+
+	return journal, nil
+}
+
+// getOperationJournal reads the journal and brings it up to date with the
+// reflog. The caller must hold operationJournalMutex.
+func (c *GitCommand) getOperationJournal() (*operationJournal, error) {
+	journal, err := c.readOperationJournal()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.syncOperationJournal(journal); err != nil {
+		return nil, err
+	}
+
+	return journal, nil
+}
+
+// syncOperationJournal imports any new operations from the reflog
+func (c *GitCommand) syncOperationJournal(journal *operationJournal) error {
+	// mid-rebase we can't yet tell what the rebase as a whole did, so we wait
+	// until it's over
+	if rebaseMode, _ := c.RebaseMode(); rebaseMode != "" {
+		return nil
+	}
+
+	var lastReflogCommit *models.Commit
+	if journal.LastReflogSha != "" {
+		lastReflogCommit = &models.Commit{Sha: journal.LastReflogSha, UnixTimestamp: journal.LastReflogTimestamp}
+	}
+
+	reflogCommits, onlyObtainedNewReflogCommits, err := c.GetReflogCommits(lastReflogCommit, GetReflogCommitsOptions{})
+	if err != nil {
+		return err
+	}
+
+	if len(reflogCommits) == 0 {
+		return nil
+	}
+
+	// if we didn't come across the entry we were up to, the reflog has been
+	// expired or rewritten, in which case we'd rather skip ahead than import
+	// operations twice
+	if lastReflogCommit == nil || onlyObtainedNewReflogCommits {
+		for _, operation := range operationsFromReflog(reflogCommits, journal.LastReflogSha) {
+			journal.push(operation)
+		}
+	}
+
+	journal.LastReflogSha = reflogCommits[0].Sha
+	journal.LastReflogTimestamp = reflogCommits[0].UnixTimestamp
+
+	return nil
+}
+
+func (c *GitCommand) saveOperationJournal(journal *operationJournal) error {
+	content, err := json.Marshal(journal)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.operationJournalPath()), 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(c.operationJournalPath(), content, 0644)
+}
+
+func (c *GitCommand) updateOperationJournal(f func(*operationJournal) error) error {
+	operationJournalMutex.Lock()
+	defer operationJournalMutex.Unlock()
+
+	journal, err := c.getOperationJournal()
+	if err != nil {
+		return err
+	}
+
+	if err := f(journal); err != nil {
+		return err
+	}
+
+	return c.saveOperationJournal(journal)
+}
+
+// withJournal runs f and then records the operation it performed.
+// getOperation is called beforehand so that it can capture any state that f is
+// about to destroy, like the sha of a branch that's being deleted. If we can't
+// work out the operation we still run f, we just won't be able to undo it.
+func (c *GitCommand) withJournal(getOperation func() (*models.Operation, error), f func() error) error {
+	if !c.journalOperations {
+		return f()
+	}
+
+	operation, err := getOperation()
+	if err != nil {
+		c.Log.Error(err)
+		return f()
+	}
+
+	// we catch up with the reflog before running f so that the operations which
+	// came before this one end up before it in the journal
+	if err := c.updateOperationJournal(func(*operationJournal) error { return nil }); err != nil {
+		c.Log.Error(err)
+		return f()
+	}
+
+	// f may be waiting on the user to enter their credentials so we don't hold
+	// the lock while it runs
+	if err := f(); err != nil {
+		return err
+	}
+
+	operationJournalMutex.Lock()
+	defer operationJournalMutex.Unlock()
+
+	journal, err := c.readOperationJournal()
+	if err != nil {
+		return err
+	}
+
+	operation.UnixTimestamp = time.Now().Unix()
+	journal.push(operation)
+
+	return c.saveOperationJournal(journal)
+}
+
+// GetOperations returns the operations that can be undone and those that have
+// been undone and can be redone, newest first
+func (c *GitCommand) GetOperations() ([]*models.Operation, []*models.Operation, error) {
+	done := []*models.Operation{}
+	undone := []*models.Operation{}
+
+	err := c.updateOperationJournal(func(journal *operationJournal) error {
+		for i := len(journal.UndoStack) - 1; i >= 0; i-- {
+			done = append(done, journal.UndoStack[i])
+		}
+		// the first operation to be undone is the newest
+		undone = append(undone, journal.RedoStack...)
+		return nil
+	})
+
+	return done, undone, err
+}
+
+// NextOperationToUndo returns nil if there's nothing to undo
+func (c *GitCommand) NextOperationToUndo() (*models.Operation, error) {
+	var operation *models.Operation
+	err := c.updateOperationJournal(func(journal *operationJournal) error {
+		operation = journal.peekUndo()
+		return nil
+	})
+
+	return operation, err
+}
+
+// NextOperationToRedo returns nil if there's nothing to redo
+func (c *GitCommand) NextOperationToRedo() (*models.Operation, error) {
+	var operation *models.Operation
+	err := c.updateOperationJournal(func(journal *operationJournal) error {
+		operation = journal.peekRedo()
+		return nil
+	})
+
+	return operation, err
+}
+
+// MarkOperationUndone is to be called once an operation has been reversed, so
+// that the next undo moves on to the operation before it
+func (c *GitCommand) MarkOperationUndone(operation *models.Operation) error {
+	return c.updateOperationJournal(func(journal *operationJournal) error {
+		journal.undo(operation)
+		return nil
+	})
+}
+
+func (c *GitCommand) MarkOperationRedone(operation *models.Operation) error {
+	return c.updateOperationJournal(func(journal *operationJournal) error {
+		journal.redo(operation)
+		return nil
+	})
+}
+
+// UndoOperation reverses an operation which didn't move HEAD. Moving HEAD back
+// may require stashing changes, which is left to the caller.
+func (c *GitCommand) UndoOperation(operation *models.Operation, promptUserForCredential func(string) string) error {
+	switch operation.Kind {
+	case models.OPERATION_CREATE_BRANCH:
+		return c.RunCommand("git branch -D %s", operation.Name)
+	case models.OPERATION_DELETE_BRANCH:
+		return c.RunCommand("git branch %s %s", operation.Name, operation.Sha)
+	case models.OPERATION_RENAME_BRANCH:
+		return c.RunCommand("git branch --move %s %s", operation.To, operation.From)
+	case models.OPERATION_DELETE_TAG:
+		// going through update-ref means annotated tags get their tag object back
+		return c.RunCommand("git update-ref refs/tags/%s %s", operation.Name, operation.Sha)
+	case models.OPERATION_DROP_STASH:
+		return c.StashStore(operation.Sha, operation.Name)
+	case models.OPERATION_DELETE_REMOTE_BRANCH:
+		command := fmt.Sprintf("git push %s %s:refs/heads/%s", operation.Remote, operation.Sha, operation.Name)
+		return c.OSCommand.DetectUnamePass(command, promptUserForCredential)
+	}
+
+	return fmt.Errorf("Cannot undo operation of kind %s", operation.Kind)
+}
+
+// RedoOperation performs an undone operation again, without adding it to the
+// journal a second time
+func (c *GitCommand) RedoOperation(operation *models.Operation, promptUserForCredential func(string) string) error {
+	switch operation.Kind {
+	case models.OPERATION_CREATE_BRANCH:
+		return c.RunCommand("git branch %s %s", operation.Name, operation.Sha)
+	case models.OPERATION_DELETE_BRANCH:
+		return c.RunCommand("git branch -D %s", operation.Name)
+	case models.OPERATION_RENAME_BRANCH:
+		return c.RunCommand("git branch --move %s %s", operation.From, operation.To)
+	case models.OPERATION_DELETE_TAG:
+		return c.RunCommand("git tag -d %s", operation.Name)
+	case models.OPERATION_DROP_STASH:
+		// other stash entries may have come and gone since, so we look the entry
+		// up by its commit
+		index, err := c.stashIndexForSha(operation.Sha)
+		if err != nil {
+			return err
+		}
+		return c.RunCommand("git stash drop stash@{%d}", index)
+	case models.OPERATION_DELETE_REMOTE_BRANCH:
+		command := fmt.Sprintf("git push %s --delete %s", operation.Remote, operation.Name)
+		return c.OSCommand.DetectUnamePass(command, promptUserForCredential)
+	}
+
+	return fmt.Errorf("Cannot redo operation of kind %s", operation.Kind)
+}
+
+func (c *GitCommand) stashIndexForSha(sha string) (int, error) {
+	cmdStr := "git stash list --format=%H"
+	output, err := c.OSCommand.RunCommandWithOutput(cmdStr)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, line := range utils.SplitLines(output) {
+		if strings.TrimSpace(line) == sha {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("Stash entry %s no longer exists", utils.SafeTruncate(sha, 8))
+}
+
+// refSha returns the object the given ref points to
+func (c *GitCommand) refSha(ref string) (string, error) {
+	output, err := c.RunCommandWithOutput("git rev-parse --verify %s", ref)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(output), nil
+}
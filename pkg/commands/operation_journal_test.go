@@ -0,0 +1,171 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOperationsFromReflog is a function.
+func TestOperationsFromReflog(t *testing.T) {
+	type scenario struct {
+		testName      string
+		reflogCommits []*models.Commit
+		previousSha   string
+		expected      []*models.Operation
+	}
+
+	scenarios := []scenario{
+		{
+			"No reflog entries",
+			[]*models.Commit{},
+			"",
+			[]*models.Operation{},
+		},
+		{
+			"Commits and checkouts",
+			[]*models.Commit{
+				{Sha: "c", Name: "commit: second", UnixTimestamp: 3},
+				{Sha: "b", Name: "checkout: moving from master to feature", UnixTimestamp: 2},
+				{Sha: "b", Name: "commit: first", UnixTimestamp: 1},
+			},
+			"a",
+			[]*models.Operation{
+				{Kind: models.OPERATION_MOVE_HEAD, From: "a", To: "b", Summary: "commit: first", UnixTimestamp: 1},
+				{Kind: models.OPERATION_CHECKOUT, From: "master", To: "feature", Summary: "checkout: moving from master to feature", UnixTimestamp: 2},
+				{Kind: models.OPERATION_MOVE_HEAD, From: "b", To: "c", Summary: "commit: second", UnixTimestamp: 3},
+			},
+		},
+		{
+			"Oldest entry with no known previous commit is skipped",
+			[]*models.Commit{
+				{Sha: "b", Name: "reset: moving to HEAD~1", UnixTimestamp: 2},
+				{Sha: "a", Name: "commit (initial): first", UnixTimestamp: 1},
+			},
+			"",
+			[]*models.Operation{
+				{Kind: models.OPERATION_MOVE_HEAD, From: "a", To: "b", Summary: "reset: moving to HEAD~1", UnixTimestamp: 2},
+			},
+		},
+		{
+			"Undo and redo entries are skipped",
+			[]*models.Commit{
+				{Sha: "a", Name: "[lazygit undo]: updating HEAD", UnixTimestamp: 2},
+				{Sha: "b", Name: "commit: first", UnixTimestamp: 1},
+			},
+			"a",
+			[]*models.Operation{
+				{Kind: models.OPERATION_MOVE_HEAD, From: "a", To: "b", Summary: "commit: first", UnixTimestamp: 1},
+			},
+		},
+		{
+			"Rebase is treated as a single operation",
+			[]*models.Commit{
+				{Sha: "d", Name: "rebase -i (finish): returning to refs/heads/master", UnixTimestamp: 4},
+				{Sha: "d", Name: "rebase -i (pick): second", UnixTimestamp: 3},
+				{Sha: "c", Name: "rebase -i (pick): first", UnixTimestamp: 2},
+				{Sha: "b", Name: "rebase -i (start): checkout HEAD~2", UnixTimestamp: 1},
+			},
+			"a",
+			[]*models.Operation{
+				{Kind: models.OPERATION_MOVE_HEAD, From: "a", To: "d", Summary: "rebase -i (finish): returning to refs/heads/master", UnixTimestamp: 4},
+			},
+		},
+		{
+			"Moving to the same commit is skipped",
+			[]*models.Commit{
+				{Sha: "a", Name: "reset: moving to HEAD", UnixTimestamp: 1},
+			},
+			"a",
+			[]*models.Operation{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, operationsFromReflog(s.reflogCommits, s.previousSha))
+		})
+	}
+}
+
+// TestOperationJournal is a function.
+func TestOperationJournal(t *testing.T) {
+	type scenario struct {
+		testName string
+		test     func(*operationJournal)
+	}
+
+	first := &models.Operation{Kind: models.OPERATION_DELETE_BRANCH, Name: "first", Sha: "a"}
+	second := &models.Operation{Kind: models.OPERATION_DELETE_TAG, Name: "second", Sha: "b"}
+
+	scenarios := []scenario{
+		{
+			"Nothing to undo or redo",
+			func(journal *operationJournal) {
+				assert.Nil(t, journal.peekUndo())
+				assert.Nil(t, journal.peekRedo())
+				assert.False(t, journal.undo(first))
+				assert.False(t, journal.redo(first))
+			},
+		},
+		{
+			"Undo then redo",
+			func(journal *operationJournal) {
+				journal.push(first)
+				journal.push(second)
+
+				assert.Equal(t, second, journal.peekUndo())
+				assert.True(t, journal.undo(second))
+				assert.Equal(t, first, journal.peekUndo())
+				assert.True(t, journal.undo(first))
+				assert.Nil(t, journal.peekUndo())
+
+				assert.Equal(t, first, journal.peekRedo())
+				assert.True(t, journal.redo(first))
+				assert.Equal(t, second, journal.peekRedo())
+				assert.True(t, journal.redo(second))
+				assert.Nil(t, journal.peekRedo())
+			},
+		},
+		{
+			"Only the next operation can be undone",
+			func(journal *operationJournal) {
+				journal.push(first)
+				journal.push(second)
+
+				assert.False(t, journal.undo(first))
+				assert.Equal(t, second, journal.peekUndo())
+			},
+		},
+		{
+			"New operations clear the redo stack",
+			func(journal *operationJournal) {
+				journal.push(first)
+				assert.True(t, journal.undo(first))
+				journal.push(second)
+
+				assert.Nil(t, journal.peekRedo())
+				assert.Equal(t, second, journal.peekUndo())
+			},
+		},
+		{
+			"Old operations are discarded",
+			func(journal *operationJournal) {
+				for i := 0; i < maxJournalOperations; i++ {
+					journal.push(first)
+				}
+				journal.push(second)
+
+				assert.Len(t, journal.UndoStack, maxJournalOperations)
+				assert.Equal(t, second, journal.peekUndo())
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			s.test(&operationJournal{})
+		})
+	}
+}
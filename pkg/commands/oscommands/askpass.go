@@ -0,0 +1,164 @@
+package oscommands
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/go-errors/errors"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// ASKPASS_CLIENT_COMMAND is what LAZYGIT_CLIENT_COMMAND is set to when git or
+// ssh run lazygit to ask the user for a credential
+const ASKPASS_CLIENT_COMMAND = "ASKPASS"
+
+// the environment variable holding the path of the socket that the lazygit
+// instance which ran the command is listening on for prompts
+const askpassSocketEnvVar = "LAZYGIT_ASKPASS_SOCKET"
+
+// ErrAskpassCancelled is returned to the askpass client when the user didn't
+// give an answer
+var ErrAskpassCancelled = errors.New("credential prompt cancelled")
+
+type askpassRequest struct {
+	Prompt string
+}
+
+type askpassResponse struct {
+	Answer    string
+	Cancelled bool
+}
+
+// askpassServer answers the credential prompts of a single command, passing
+// their exact text on to promptUserForCredential
+type askpassServer struct {
+	dir      string
+	listener net.Listener
+
+	promptUserForCredential func(string) string
+
+	// git only asks one question at a time but we don't want to show two
+	// prompts at once if a command has processes asking in parallel
+	promptMutex sync.Mutex
+}
+
+func newAskpassServer(promptUserForCredential func(string) string) (*askpassServer, error) {
+	dir, err := ioutil.TempDir("", "lazygit-askpass")
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("unix", filepath.Join(dir, "socket"))
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+
+	server := &askpassServer{
+		dir:                     dir,
+		listener:                listener,
+		promptUserForCredential: promptUserForCredential,
+	}
+
+	go utils.Safe(server.serve)
+
+	return server, nil
+}
+
+func (s *askpassServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			// the listener has been closed
+			return
+		}
+
+		go utils.Safe(func() { s.handle(conn) })
+	}
+}
+
+func (s *askpassServer) handle(conn net.Conn) {
+	defer conn.Close()
+
+	var request askpassRequest
+	if err := json.NewDecoder(conn).Decode(&request); err != nil {
+		return
+	}
+
+	s.promptMutex.Lock()
+	answer := s.promptUserForCredential(request.Prompt)
+	s.promptMutex.Unlock()
+
+	_ = json.NewEncoder(conn).Encode(askpassResponse{
+		Answer:    answer,
+		Cancelled: answer == "",
+	})
+}
+
+// envVars returns the environment variables that make git and ssh ask us for
+// credentials by running the given askpass program
+func (s *askpassServer) envVars(askpassProgram string) []string {
+	return []string{
+		"LAZYGIT_CLIENT_COMMAND=" + ASKPASS_CLIENT_COMMAND,
+		askpassSocketEnvVar + "=" + s.listener.Addr().String(),
+		"GIT_ASKPASS=" + askpassProgram,
+		"SSH_ASKPASS=" + askpassProgram,
+		// newer versions of ssh otherwise only use SSH_ASKPASS when there's no
+		// terminal and DISPLAY is set
+		"SSH_ASKPASS_REQUIRE=force",
+	}
+}
+
+func (s *askpassServer) Close() error {
+	err := s.listener.Close()
+	_ = os.RemoveAll(s.dir)
+	return err
+}
+
+// RunAskpassClient is run when git or ssh run lazygit as their askpass
+// program. It passes the prompt on to the lazygit instance that ran the command
+// and writes the user's answer to out.
+func RunAskpassClient(prompt string, out io.Writer) error {
+	socketPath := os.Getenv(askpassSocketEnvVar)
+	if socketPath == "" {
+		return errors.New(askpassSocketEnvVar + " is not set")
+	}
+
+	conn, err := net.Dial("unix", socketPath)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := json.NewEncoder(conn).Encode(askpassRequest{Prompt: prompt}); err != nil {
+		return err
+	}
+
+	var response askpassResponse
+	if err := json.NewDecoder(conn).Decode(&response); err != nil {
+		return err
+	}
+
+	if response.Cancelled {
+		return ErrAskpassCancelled
+	}
+
+	_, err = io.WriteString(out, response.Answer+"\n")
+	return err
+}
+
+// getAskpassProgram returns the path of the lazygit executable for git and ssh
+// to run. Unlike GetLazygitPath it isn't quoted because they don't run it
+// through a shell.
+func getAskpassProgram() string {
+	ex, err := os.Executable()
+	if err != nil {
+		ex = os.Args[0]
+	}
+	return ex
+}
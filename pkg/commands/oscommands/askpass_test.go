@@ -0,0 +1,90 @@
+package oscommands
+
+import (
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMain lets the test binary stand in for lazygit when it's run by a
+// command as its askpass program
+func TestMain(m *testing.M) {
+	if os.Getenv("LAZYGIT_CLIENT_COMMAND") == ASKPASS_CLIENT_COMMAND {
+		prompt := ""
+		if len(os.Args) > 1 {
+			prompt = os.Args[1]
+		}
+		if err := RunAskpassClient(prompt, os.Stdout); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	os.Exit(m.Run())
+}
+
+// TestOSCommandDetectUnamePass is a function.
+func TestOSCommandDetectUnamePass(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the commands in this test need sh")
+	}
+
+	type scenario struct {
+		testName        string
+		command         string
+		answers         map[string]string
+		expectedPrompts []string
+		expectedError   bool
+	}
+
+	scenarios := []scenario{
+		{
+			testName:        "One-time code",
+			command:         `sh -c 'test "$("$GIT_ASKPASS" "Enter your one-time code:")" = 123456'`,
+			answers:         map[string]string{"Enter your one-time code:": "123456"},
+			expectedPrompts: []string{"Enter your one-time code:"},
+		},
+		{
+			testName: "Localised username and password prompts",
+			command:  `sh -c 'u=$("$GIT_ASKPASS" "Benutzername:") && p=$("$GIT_ASKPASS" "Passwort:") && test "$u:$p" = jesse:hunter2'`,
+			answers: map[string]string{
+				"Benutzername:": "jesse",
+				"Passwort:":     "hunter2",
+			},
+			expectedPrompts: []string{"Benutzername:", "Passwort:"},
+		},
+		{
+			testName:        "SSH passphrase",
+			command:         `sh -c 'test "$("$SSH_ASKPASS" "Enter passphrase for key:")" = secret'`,
+			answers:         map[string]string{"Enter passphrase for key:": "secret"},
+			expectedPrompts: []string{"Enter passphrase for key:"},
+		},
+		{
+			testName:        "Cancelled prompt",
+			command:         `sh -c '"$GIT_ASKPASS" "Password:"'`,
+			answers:         map[string]string{},
+			expectedPrompts: []string{"Password:"},
+			expectedError:   true,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.testName, func(t *testing.T) {
+			prompts := []string{}
+			err := NewDummyOSCommand().DetectUnamePass(s.command, func(prompt string) string {
+				prompts = append(prompts, prompt)
+				return s.answers[prompt]
+			})
+
+			if s.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.EqualValues(t, s.expectedPrompts, prompts)
+		})
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
-	"regexp"
 	"strings"
 	"sync"
 
@@ -48,8 +47,20 @@ type OSCommand struct {
 	CmdLogSpan string
 
 	removeFile func(string) error
+
+	// the program git and ssh run to ask us for credentials
+	askpassProgram string
+
+	// for commands that talk to a remote: onProgress is called with each of
+	// git's progress updates and closing cancel kills the command
+	onProgress func(Progress)
+	cancel     <-chan struct{}
 }
 
+// ErrCommandCancelled is returned when a command is killed because the user
+// cancelled it
+var ErrCommandCancelled = errors.New("command cancelled")
+
 // TODO: make these fields private
 type CmdLogEntry struct {
 	// e.g. 'git commit -m "haha"'
@@ -90,6 +101,7 @@ func NewOSCommand(log *logrus.Entry, config config.AppConfigurer) *OSCommand {
 		BeforeExecuteCmd: func(*exec.Cmd) {},
 		Getenv:           os.Getenv,
 		removeFile:       os.RemoveAll,
+		askpassProgram:   getAskpassProgram(),
 	}
 }
 
@@ -107,6 +119,16 @@ func (c *OSCommand) WithSpan(span string) *OSCommand {
 	return newOSCommand
 }
 
+// WithProgress returns a copy of the OSCommand that reports the progress of
+// commands that talk to a remote, and kills them when cancel is closed
+func (c *OSCommand) WithProgress(onProgress func(Progress), cancel <-chan struct{}) *OSCommand {
+	newOSCommand := &OSCommand{}
+	*newOSCommand = *c
+	newOSCommand.onProgress = onProgress
+	newOSCommand.cancel = cancel
+	return newOSCommand
+}
+
 func (c *OSCommand) LogExecCmd(cmd *exec.Cmd) {
 	c.LogCommand(strings.Join(cmd.Args, " "), true)
 }
@@ -212,11 +234,6 @@ func (c *OSCommand) ShellCommandFromString(commandStr string) *exec.Cmd {
 	return c.ExecutableFromString(shellCommand)
 }
 
-// RunCommandWithOutputLive runs RunCommandWithOutputLiveWrapper
-func (c *OSCommand) RunCommandWithOutputLive(command string, output func(string) string) error {
-	return RunCommandWithOutputLiveWrapper(c, command, output)
-}
-
 func (c *OSCommand) CatFile(filename string) (string, error) {
 	arr := append(c.Platform.CatCmd, filename)
 	cmdStr := strings.Join(arr, " ")
@@ -229,31 +246,56 @@ func (c *OSCommand) CatFile(filename string) (string, error) {
 	return output, err
 }
 
-// DetectUnamePass detect a username / password / passphrase question in a command
-// promptUserForCredential is a function that gets executed when this function detect you need to fillin a password or passphrase
-// The promptUserForCredential argument will be "username", "password" or "passphrase" and expects the user's password/passphrase or username back
+// DetectUnamePass runs a command that may ask for credentials, such as a
+// username, password, passphrase or one-time code. We act as GIT_ASKPASS and
+// SSH_ASKPASS for the command so each prompt is passed to
+// promptUserForCredential with its exact text, and its return value is the
+// answer. Returning an empty string cancels the prompt.
+// If the command is run with git's --progress flag, its progress updates are
+// passed to the OSCommand's onProgress rather than being part of any error.
 func (c *OSCommand) DetectUnamePass(command string, promptUserForCredential func(string) string) error {
-	ttyText := ""
-	errMessage := c.RunCommandWithOutputLive(command, func(word string) string {
-		ttyText = ttyText + " " + word
-
-		prompts := map[string]string{
-			`.+'s password:`:                         "password",
-			`Password\s*for\s*'.+':`:                 "password",
-			`Username\s*for\s*'.+':`:                 "username",
-			`Enter\s*passphrase\s*for\s*key\s*'.+':`: "passphrase",
-		}
+	server, err := newAskpassServer(promptUserForCredential)
+	if err != nil {
+		return err
+	}
+	defer server.Close()
 
-		for pattern, askFor := range prompts {
-			if match, _ := regexp.MatchString(pattern, ttyText); match {
-				ttyText = ""
-				return promptUserForCredential(askFor)
-			}
-		}
+	cmd := c.ExecutableFromString(command)
+	cmd.Env = append(cmd.Env, server.envVars(c.askpassProgram)...)
+	detachFromTerminal(cmd)
 
-		return ""
+	output := &progressWriter{onProgress: c.onProgress}
+	cmd.Stdout = output
+	cmd.Stderr = output
+
+	c.LogExecCmd(cmd)
+	c.BeforeExecuteCmd(cmd)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	cancelled := make(chan struct{})
+	done := make(chan struct{})
+	go utils.Safe(func() {
+		select {
+		case <-c.cancel:
+			close(cancelled)
+			_ = killProcessGroup(cmd)
+		case <-done:
+		}
 	})
-	return errMessage
+
+	err = cmd.Wait()
+	close(done)
+
+	select {
+	case <-cancelled:
+		return ErrCommandCancelled
+	default:
+	}
+
+	_, err = sanitisedCommandOutput(output.Bytes(), err)
+	return err
 }
 
 // RunCommand runs a command and just returns the error
@@ -0,0 +1,22 @@
+// +build !windows
+
+package oscommands
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// detachFromTerminal starts the command in its own session so that git and ssh
+// can't prompt on the terminal we're drawing the gui in, leaving askpass as the
+// only way to ask for credentials
+func detachFromTerminal(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+}
+
+// killProcessGroup kills a command started with detachFromTerminal along with
+// the processes it has started, like the ssh or https helper that git uses to
+// talk to a remote
+func killProcessGroup(cmd *exec.Cmd) error {
+	return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
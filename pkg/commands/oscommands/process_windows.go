@@ -0,0 +1,15 @@
+package oscommands
+
+import (
+	"os/exec"
+)
+
+// detachFromTerminal is a no-op on windows because SSH_ASKPASS_REQUIRE makes
+// git and ssh use askpass regardless of the console
+func detachFromTerminal(cmd *exec.Cmd) {}
+
+// killProcessGroup kills the command. Windows has no process groups for us to
+// kill but git's helpers will exit once their pipes to it are closed.
+func killProcessGroup(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
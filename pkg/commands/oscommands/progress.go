@@ -0,0 +1,97 @@
+package oscommands
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Progress is what git reports about how far through a push, pull or fetch it
+// is, e.g. 'Receiving objects:  45% (450/1000)'
+type Progress struct {
+	// e.g. 'Receiving objects' or 'Resolving deltas'
+	Phase   string
+	Percent int
+	Current int
+	Total   int
+	// true when the phase is happening on the remote, in which case git prefixes
+	// the line with 'remote: '
+	Remote bool
+}
+
+var progressRegexp = regexp.MustCompile(`^(remote: )?([^:]+):\s+(\d+)% \((\d+)/(\d+)\)`)
+
+// ParseProgress parses a line of git's --progress output, returning false if
+// the line isn't a progress update
+func ParseProgress(line string) (Progress, bool) {
+	match := progressRegexp.FindStringSubmatch(strings.TrimSpace(line))
+	if match == nil {
+		return Progress{}, false
+	}
+
+	// the regex only matches digits so we can ignore the errors here
+	percent, _ := strconv.Atoi(match[3])
+	current, _ := strconv.Atoi(match[4])
+	total, _ := strconv.Atoi(match[5])
+
+	return Progress{
+		Phase:   match[2],
+		Percent: percent,
+		Current: current,
+		Total:   total,
+		Remote:  match[1] != "",
+	}, true
+}
+
+// progressWriter collects the output of a command, passing git's progress
+// updates to onProgress rather than keeping them. Git ends a progress update
+// with a carriage return so it can overwrite it with the next one, so we split
+// on those as well as on newlines.
+type progressWriter struct {
+	onProgress func(Progress)
+
+	output  bytes.Buffer
+	pending []byte
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	w.pending = append(w.pending, p...)
+
+	for {
+		i := bytes.IndexAny(w.pending, "\r\n")
+		if i == -1 {
+			break
+		}
+
+		w.handleLine(string(w.pending[:i]))
+		w.pending = w.pending[i+1:]
+	}
+
+	return len(p), nil
+}
+
+func (w *progressWriter) handleLine(line string) {
+	if progress, ok := ParseProgress(line); ok {
+		if w.onProgress != nil {
+			w.onProgress(progress)
+		}
+		return
+	}
+
+	if strings.TrimSpace(line) == "" {
+		return
+	}
+
+	w.output.WriteString(line + "\n")
+}
+
+// Bytes returns the output other than the progress updates
+func (w *progressWriter) Bytes() []byte {
+	if len(w.pending) > 0 {
+		w.handleLine(string(w.pending))
+		w.pending = nil
+	}
+
+	return w.output.Bytes()
+}
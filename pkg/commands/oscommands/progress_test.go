@@ -0,0 +1,108 @@
+package oscommands
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseProgress is a function.
+func TestParseProgress(t *testing.T) {
+	type scenario struct {
+		testName         string
+		line             string
+		expectedProgress Progress
+		expectedOk       bool
+	}
+
+	scenarios := []scenario{
+		{
+			testName:         "Receiving objects",
+			line:             "Receiving objects:  45% (450/1000), 1.20 MiB | 1.10 MiB/s",
+			expectedProgress: Progress{Phase: "Receiving objects", Percent: 45, Current: 450, Total: 1000},
+			expectedOk:       true,
+		},
+		{
+			testName:         "Finished phase",
+			line:             "Resolving deltas: 100% (10/10), done.",
+			expectedProgress: Progress{Phase: "Resolving deltas", Percent: 100, Current: 10, Total: 10},
+			expectedOk:       true,
+		},
+		{
+			testName:         "Phase on the remote",
+			line:             "remote: Compressing objects:   5% (1/20)",
+			expectedProgress: Progress{Phase: "Compressing objects", Percent: 5, Current: 1, Total: 20, Remote: true},
+			expectedOk:       true,
+		},
+		{
+			testName:   "Phase without a percentage",
+			line:       "Enumerating objects: 5, done.",
+			expectedOk: false,
+		},
+		{
+			testName:   "Not progress",
+			line:       "To github.com:jesseduffield/lazygit.git",
+			expectedOk: false,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.testName, func(t *testing.T) {
+			progress, ok := ParseProgress(s.line)
+			assert.EqualValues(t, s.expectedOk, ok)
+			assert.EqualValues(t, s.expectedProgress, progress)
+		})
+	}
+}
+
+// TestProgressWriter is a function.
+func TestProgressWriter(t *testing.T) {
+	progresses := []Progress{}
+	writer := &progressWriter{onProgress: func(progress Progress) {
+		progresses = append(progresses, progress)
+	}}
+
+	// git overwrites each update with a carriage return, and the writes don't
+	// necessarily line up with the lines
+	chunks := []string{
+		"Writing objects:  50% (1/2)\rWriting obj",
+		"ects: 100% (2/2), done.\n",
+		"To example.com:repo.git\n",
+		" ! [rejected]        master -> master (fetch first)",
+	}
+	for _, chunk := range chunks {
+		_, err := writer.Write([]byte(chunk))
+		assert.NoError(t, err)
+	}
+
+	assert.EqualValues(t, []Progress{
+		{Phase: "Writing objects", Percent: 50, Current: 1, Total: 2},
+		{Phase: "Writing objects", Percent: 100, Current: 2, Total: 2},
+	}, progresses)
+	assert.EqualValues(t, "To example.com:repo.git\n ! [rejected]        master -> master (fetch first)\n", string(writer.Bytes()))
+}
+
+// TestOSCommandDetectUnamePassCancel is a function.
+func TestOSCommandDetectUnamePassCancel(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the command in this test needs sh")
+	}
+
+	cancel := make(chan struct{})
+	osCommand := NewDummyOSCommand().WithProgress(nil, cancel)
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		close(cancel)
+	}()
+
+	start := time.Now()
+	// the child process would keep the command's output open if we only killed sh
+	err := osCommand.DetectUnamePass(`sh -c 'sleep 10; true'`, func(string) string { return "" })
+
+	assert.Equal(t, ErrCommandCancelled, err)
+	assert.True(t, time.Since(start) < 5*time.Second, "expected the command to be killed")
+}
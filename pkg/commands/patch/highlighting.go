@@ -0,0 +1,89 @@
+package patch
+
+import (
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/gui/syntax"
+)
+
+// lineTokens returns the syntax tokens for the content of each line of the
+// patch after its '+'/'-'/' ' prefix, or nil for lines which aren't code or
+// whose language we don't know. The old and new versions of each hunk are
+// highlighted separately so that e.g. a block comment opened in a deleted line
+// doesn't spill over into the added lines.
+func (p *PatchParser) lineTokens() [][]syntax.Token {
+	if p.syntaxTokens != nil {
+		return p.syntaxTokens
+	}
+
+	p.syntaxTokens = make([][]syntax.Token, len(p.PatchLines))
+	oldPath := ""
+	path := ""
+	var oldHighlighter, newHighlighter *syntax.Highlighter
+
+	for index, line := range p.PatchLines {
+		if line.Content == "" {
+			continue
+		}
+
+		switch line.Kind {
+		case PATCH_HEADER:
+			if strings.HasPrefix(line.Content, "--- ") {
+				oldPath = pathFromFileHeader(line.Content)
+			} else if strings.HasPrefix(line.Content, "+++ ") {
+				path = pathFromFileHeader(line.Content)
+				// a deleted file's diff only has the old path
+				if path == "/dev/null" {
+					path = oldPath
+				}
+			}
+		case HUNK_HEADER:
+			oldHighlighter = syntax.NewHighlighter(path)
+			newHighlighter = syntax.NewHighlighter(path)
+		case CONTEXT:
+			if newHighlighter != nil {
+				oldHighlighter.Tokenize(line.Content[1:])
+				p.syntaxTokens[index] = newHighlighter.Tokenize(line.Content[1:])
+			}
+		case DELETION:
+			if oldHighlighter != nil {
+				p.syntaxTokens[index] = oldHighlighter.Tokenize(line.Content[1:])
+			}
+		case ADDITION:
+			if newHighlighter != nil {
+				p.syntaxTokens[index] = newHighlighter.Tokenize(line.Content[1:])
+			}
+		}
+	}
+
+	return p.syntaxTokens
+}
+
+// pathFromFileHeader takes a line like '+++ b/pkg/gui/gui.go' and returns
+// 'pkg/gui/gui.go'
+func pathFromFileHeader(header string) string {
+	path := strings.Trim(strings.TrimSpace(header[4:]), `"`)
+	if path == "/dev/null" {
+		return path
+	}
+
+	for _, prefix := range []string{"a/", "b/"} {
+		if strings.HasPrefix(path, prefix) {
+			return strings.TrimPrefix(path, prefix)
+		}
+	}
+
+	return path
+}
+
+// renderHighlighted renders a line of code, keeping the diff colour for its
+// prefix and for anything that isn't highlighted
+func (l *PatchLine) renderHighlighted(tokens []syntax.Token, selected bool, included bool) string {
+	textStyle := l.textStyle()
+	result := coloredString(textStyle, l.Content[:1], selected, included)
+	for _, token := range tokens {
+		result += coloredString(token.Style(textStyle), token.Text, selected, false)
+	}
+
+	return result
+}
@@ -0,0 +1,69 @@
+package patch
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/gui/syntax"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+const goDiff = `diff --git a/main.go b/main.go
+index e69de29..5b8f2d8 100644
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,3 @@
+ package main
+-/* old
++// new
+ var x = 1
+diff --git a/notes.txt b/notes.txt
+deleted file mode 100644
+--- a/notes.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-var
+`
+
+// TestLineTokens is a function.
+func TestLineTokens(t *testing.T) {
+	theme.SyntaxHighlighting = true
+	defer func() { theme.SyntaxHighlighting = false }()
+
+	lineTokens := NewPatchParser(logrus.NewEntry(logrus.New()), goDiff).lineTokens()
+
+	assert.EqualValues(t, [][]syntax.Token{
+		nil, nil, nil, nil, nil,
+		{{Kind: syntax.KEYWORD, Text: "package"}, {Kind: syntax.PLAIN, Text: " main"}},
+		{{Kind: syntax.COMMENT, Text: "/* old"}},
+		{{Kind: syntax.COMMENT, Text: "// new"}},
+		// the block comment opened on the old side doesn't affect the new side
+		{{Kind: syntax.KEYWORD, Text: "var"}, {Kind: syntax.PLAIN, Text: " x = "}, {Kind: syntax.NUMBER, Text: "1"}},
+		nil, nil, nil, nil, nil,
+		// we don't know how to highlight a text file
+		nil,
+		nil,
+	}, lineTokens)
+}
+
+// TestPathFromFileHeader is a function.
+func TestPathFromFileHeader(t *testing.T) {
+	type scenario struct {
+		header   string
+		expected string
+	}
+
+	scenarios := []scenario{
+		{"+++ b/pkg/gui/gui.go", "pkg/gui/gui.go"},
+		{"--- a/main.go", "main.go"},
+		{"+++ /dev/null", "/dev/null"},
+		{`+++ "b/with space.go"`, "with space.go"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.header, func(t *testing.T) {
+			assert.Equal(t, s.expected, pathFromFileHeader(s.header))
+		})
+	}
+}
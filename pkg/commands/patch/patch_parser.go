@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/gui/syntax"
 	"github.com/jesseduffield/lazygit/pkg/theme"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 	"github.com/sirupsen/logrus"
@@ -36,6 +37,9 @@ type PatchParser struct {
 	PatchHunks     []*PatchHunk
 	HunkStarts     []int
 	StageableLines []int // rename to mention we're talking about indexes
+
+	// lazily populated by lineTokens
+	syntaxTokens [][]syntax.Token
 }
 
 // NewPatchParser builds a new branch list builder
@@ -98,19 +102,22 @@ func (l *PatchLine) render(selected bool, included bool) string {
 		return coloredString(style.FgCyan, match[1], selected, included) + coloredString(theme.DefaultTextColor, match[2], selected, false)
 	}
 
-	textStyle := theme.DefaultTextColor
+	return coloredString(l.textStyle(), content, selected, included)
+}
+
+func (l *PatchLine) textStyle() style.TextStyle {
 	switch l.Kind {
 	case PATCH_HEADER:
-		textStyle = textStyle.SetBold()
+		return theme.DefaultTextColor.SetBold()
 	case ADDITION:
-		textStyle = style.FgGreen
+		return style.FgGreen
 	case DELETION:
-		textStyle = style.FgRed
+		return style.FgRed
 	case COMMIT_SHA:
-		textStyle = style.FgYellow
+		return style.FgYellow
 	}
 
-	return coloredString(textStyle, content, selected, included)
+	return theme.DefaultTextColor
 }
 
 func coloredString(textStyle style.TextStyle, str string, selected bool, included bool) string {
@@ -154,6 +161,10 @@ func parsePatch(patch string) ([]int, []int, []*PatchLine) {
 			} else {
 				lineKind = COMMIT_DESCRIPTION
 			}
+		} else if strings.HasPrefix(line, "diff") {
+			// the start of the next file's diff
+			pastFirstHunkHeader = false
+			lineKind = PATCH_HEADER
 		} else if firstChar == "@" {
 			pastFirstHunkHeader = true
 			hunkStarts = append(hunkStarts, index)
@@ -182,10 +193,15 @@ func parsePatch(patch string) ([]int, []int, []*PatchLine) {
 // Render returns the coloured string of the diff with any selected lines highlighted
 func (p *PatchParser) Render(firstLineIndex int, lastLineIndex int, incLineIndices []int) string {
 	renderedLines := make([]string, len(p.PatchLines))
+	lineTokens := p.lineTokens()
 	for index, patchLine := range p.PatchLines {
 		selected := index >= firstLineIndex && index <= lastLineIndex
 		included := utils.IncludesInt(incLineIndices, index)
-		renderedLines[index] = patchLine.render(selected, included)
+		if lineTokens[index] != nil {
+			renderedLines[index] = patchLine.renderHighlighted(lineTokens[index], selected, included)
+		} else {
+			renderedLines[index] = patchLine.render(selected, included)
+		}
 	}
 	result := strings.Join(renderedLines, "\n")
 	if strings.TrimSpace(utils.Decolorise(result)) == "" {
@@ -0,0 +1,281 @@
+package patch
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/mattn/go-runewidth"
+)
+
+// The job of this file is to render a patch side by side, with the old version
+// of each hunk on the left and the new version on the right. Deleted lines are
+// paired up with the added lines that replaced them, and the words that differ
+// between a pair are emphasised.
+
+// SideBySideRow is a row of a side-by-side diff, giving the indices of the patch
+// lines shown on the left and right, or -1 for a blank side. Context lines show
+// on both sides, and lines outside of hunks (e.g. headers) take up the full row,
+// in which case Left and Right are the same.
+type SideBySideRow struct {
+	Left      int
+	Right     int
+	FullWidth bool
+
+	leftNumber  int
+	rightNumber int
+}
+
+const sideBySideSeparator = "│"
+
+// SideBySideRows pairs up the lines of the patch into rows
+func (p *PatchParser) SideBySideRows() []*SideBySideRow {
+	rows := []*SideBySideRow{}
+	deletions := []int{}
+	additions := []int{}
+	oldLineNumber := 0
+	newLineNumber := 0
+
+	flush := func() {
+		for i := 0; i < len(deletions) || i < len(additions); i++ {
+			row := &SideBySideRow{Left: -1, Right: -1}
+			if i < len(deletions) {
+				row.Left = deletions[i]
+				if p.PatchLines[row.Left].Kind == DELETION {
+					row.leftNumber = oldLineNumber
+					oldLineNumber++
+				}
+			}
+			if i < len(additions) {
+				row.Right = additions[i]
+				if p.PatchLines[row.Right].Kind == ADDITION {
+					row.rightNumber = newLineNumber
+					newLineNumber++
+				}
+			}
+			rows = append(rows, row)
+		}
+		deletions = []int{}
+		additions = []int{}
+	}
+
+	previousKind := PATCH_HEADER
+	for index, line := range p.PatchLines {
+		// the only line without even a '+', '-' or ' ' is the blank one at the end
+		if line.Content == "" {
+			flush()
+			rows = append(rows, &SideBySideRow{Left: index, Right: index, FullWidth: true})
+			continue
+		}
+
+		switch line.Kind {
+		case DELETION:
+			// keep things in order if a deletion follows some additions
+			if len(additions) > 0 {
+				flush()
+			}
+			deletions = append(deletions, index)
+		case ADDITION:
+			additions = append(additions, index)
+		case NEWLINE_MESSAGE:
+			// this message is about the line before it so it goes on the same side
+			if previousKind == DELETION {
+				deletions = append(deletions, index)
+			} else if previousKind == ADDITION {
+				additions = append(additions, index)
+			} else {
+				flush()
+				rows = append(rows, &SideBySideRow{Left: index, Right: index})
+			}
+		case CONTEXT:
+			flush()
+			rows = append(rows, &SideBySideRow{Left: index, Right: index, leftNumber: oldLineNumber, rightNumber: newLineNumber})
+			oldLineNumber++
+			newLineNumber++
+		default:
+			flush()
+			if line.Kind == HUNK_HEADER {
+				if match := hunkHeaderRegexp.FindStringSubmatch(line.Content); match != nil {
+					oldLineNumber = utils.MustConvertToInt(match[1])
+					newLineNumber = utils.MustConvertToInt(match[2])
+				}
+			}
+			rows = append(rows, &SideBySideRow{Left: index, Right: index, FullWidth: true})
+		}
+		previousKind = line.Kind
+	}
+	flush()
+
+	return rows
+}
+
+// SideBySideRowForLine returns the index of the row showing the given line
+func SideBySideRowForLine(rows []*SideBySideRow, lineIdx int) int {
+	for i, row := range rows {
+		if row.Left == lineIdx || row.Right == lineIdx {
+			return i
+		}
+	}
+
+	if lineIdx < 0 || len(rows) == 0 {
+		return 0
+	}
+	return len(rows) - 1
+}
+
+// SideBySideLineForRow returns the index of the line shown in the given row,
+// preferring the left side
+func SideBySideLineForRow(rows []*SideBySideRow, rowIdx int) int {
+	if len(rows) == 0 {
+		return 0
+	}
+	if rowIdx < 0 {
+		rowIdx = 0
+	} else if rowIdx > len(rows)-1 {
+		rowIdx = len(rows) - 1
+	}
+
+	row := rows[rowIdx]
+	if row.Left != -1 {
+		return row.Left
+	}
+	return row.Right
+}
+
+// RenderSideBySide returns the coloured side-by-side diff fitting the given
+// width, with any selected lines highlighted
+func (p *PatchParser) RenderSideBySide(rows []*SideBySideRow, width int, firstLineIndex int, lastLineIndex int, incLineIndices []int) string {
+	maxLineNumber := 0
+	for _, row := range rows {
+		maxLineNumber = utils.Max(maxLineNumber, utils.Max(row.leftNumber, row.rightNumber))
+	}
+	numberWidth := len(strconv.Itoa(maxLineNumber))
+
+	// each side gets half the width, less the separator in the middle
+	sideWidth := (width - runewidth.StringWidth(sideBySideSeparator)) / 2
+	if sideWidth < 1 {
+		sideWidth = 1
+	}
+
+	isSelected := func(lineIdx int) bool {
+		return lineIdx != -1 && lineIdx >= firstLineIndex && lineIdx <= lastLineIndex
+	}
+
+	renderedRows := make([]string, len(rows))
+	for i, row := range rows {
+		if row.FullWidth {
+			line := p.PatchLines[row.Left]
+			renderedRows[i] = line.render(isSelected(row.Left), utils.IncludesInt(incLineIndices, row.Left))
+			continue
+		}
+
+		leftPieces, rightPieces := p.sideBySidePieces(row)
+
+		renderedRows[i] = renderSideBySideCell(leftPieces, row.leftNumber, numberWidth, sideWidth, isSelected(row.Left), utils.IncludesInt(incLineIndices, row.Left)) +
+			style.FgBlack.SetBold().Sprint(sideBySideSeparator) +
+			renderSideBySideCell(rightPieces, row.rightNumber, numberWidth, sideWidth, isSelected(row.Right), utils.IncludesInt(incLineIndices, row.Right))
+	}
+
+	result := strings.Join(renderedRows, "\n")
+	if strings.TrimSpace(utils.Decolorise(result)) == "" {
+		return ""
+	}
+	return result
+}
+
+type sideBySidePiece struct {
+	text      string
+	textStyle style.TextStyle
+}
+
+// sideBySidePieces splits the content of each side of the row into differently
+// styled pieces. When a deleted line is paired with an added line, the words
+// that differ between them are emphasised. Otherwise the line is syntax
+// highlighted if possible.
+func (p *PatchParser) sideBySidePieces(row *SideBySideRow) ([]sideBySidePiece, []sideBySidePiece) {
+	lineTokens := p.lineTokens()
+	piecesForLine := func(lineIdx int) []sideBySidePiece {
+		if lineIdx == -1 {
+			return nil
+		}
+		line := p.PatchLines[lineIdx]
+		textStyle := theme.DefaultTextColor
+		switch line.Kind {
+		case ADDITION:
+			textStyle = style.FgGreen
+		case DELETION:
+			textStyle = style.FgRed
+		}
+		if lineTokens[lineIdx] == nil {
+			return []sideBySidePiece{{text: line.Content, textStyle: textStyle}}
+		}
+
+		pieces := []sideBySidePiece{{text: line.Content[:1], textStyle: textStyle}}
+		for _, token := range lineTokens[lineIdx] {
+			pieces = append(pieces, sideBySidePiece{text: token.Text, textStyle: token.Style(textStyle)})
+		}
+		return pieces
+	}
+
+	if row.Left == -1 || row.Right == -1 || p.PatchLines[row.Left].Kind != DELETION || p.PatchLines[row.Right].Kind != ADDITION {
+		return piecesForLine(row.Left), piecesForLine(row.Right)
+	}
+
+	oldContent := p.PatchLines[row.Left].Content
+	newContent := p.PatchLines[row.Right].Content
+	left := []sideBySidePiece{{text: oldContent[:1], textStyle: style.FgRed}}
+	right := []sideBySidePiece{{text: newContent[:1], textStyle: style.FgGreen}}
+	for _, segment := range buildWordDiffSegments(tokenize(oldContent[1:]), tokenize(newContent[1:])) {
+		switch segment.Kind {
+		case WORD_CONTEXT:
+			left = append(left, sideBySidePiece{text: segment.Content, textStyle: style.FgRed})
+			right = append(right, sideBySidePiece{text: segment.Content, textStyle: style.FgGreen})
+		case WORD_DELETION:
+			left = append(left, sideBySidePiece{text: segment.Content, textStyle: style.FgRed.SetReverse()})
+		case WORD_ADDITION:
+			right = append(right, sideBySidePiece{text: segment.Content, textStyle: style.FgGreen.SetReverse()})
+		}
+	}
+
+	return left, right
+}
+
+// renderSideBySideCell renders one side of a row, truncated or padded to the
+// given width. The first piece holds the '+'/'-' which, like in the unified
+// view, is highlighted when the line is included in a custom patch.
+func renderSideBySideCell(pieces []sideBySidePiece, lineNumber int, numberWidth int, width int, selected bool, included bool) string {
+	result := ""
+	remaining := width
+
+	if len(pieces) > 0 && numberWidth+1 < width {
+		number := ""
+		if lineNumber > 0 {
+			number = strconv.Itoa(lineNumber)
+		}
+		result += coloredString(style.FgBlack.SetBold(), fmt.Sprintf("%*s ", numberWidth, number), selected, false)
+		remaining -= numberWidth + 1
+	}
+
+	for i, piece := range pieces {
+		if remaining <= 0 {
+			break
+		}
+
+		text := strings.Replace(piece.text, "\t", "    ", -1)
+		if runewidth.StringWidth(text) > remaining {
+			text = runewidth.Truncate(text, remaining, "")
+		}
+		remaining -= runewidth.StringWidth(text)
+
+		result += coloredString(piece.textStyle, text, selected, included && i == 0)
+	}
+
+	if remaining > 0 {
+		result += coloredString(theme.DefaultTextColor, strings.Repeat(" ", remaining), selected, false)
+	}
+
+	return result
+}
@@ -0,0 +1,134 @@
+package patch
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const twoFileDiff = `diff --git a/a b/a
+index dcd3485..1ba5540 100644
+--- a/a
++++ b/a
+@@ -1 +1 @@
+-apple
++grape
+diff --git a/b b/b
+index dcd3485..1ba5540 100644
+--- a/b
++++ b/b
+@@ -1 +1 @@
+-orange
++kiwi
+`
+
+// TestSideBySideRows is a function.
+func TestSideBySideRows(t *testing.T) {
+	type scenario struct {
+		testName string
+		diffText string
+		expected [][2]int
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "replaced lines",
+			diffText: wordDiff,
+			expected: [][2]int{{0, 0}, {1, 1}, {2, 2}, {3, 3}, {4, 4}, {5, 5}, {6, 7}, {8, 8}, {9, 10}, {11, 11}},
+		},
+		{
+			testName: "more lines removed than added",
+			diffText: wordDiffJoinedLines,
+			expected: [][2]int{{0, 0}, {1, 1}, {2, 2}, {3, 3}, {4, 4}, {5, 7}, {6, -1}, {8, 8}, {9, 9}},
+		},
+		{
+			testName: "headers of later files span the full row",
+			diffText: twoFileDiff,
+			expected: [][2]int{{0, 0}, {1, 1}, {2, 2}, {3, 3}, {4, 4}, {5, 6}, {7, 7}, {8, 8}, {9, 9}, {10, 10}, {11, 11}, {12, 13}, {14, 14}},
+		},
+		{
+			testName: "newline message stays with its line",
+			diffText: addNewlineToEndOfFile,
+			expected: [][2]int{{0, 0}, {1, 1}, {2, 2}, {3, 3}, {4, 4}, {5, 5}, {6, 6}, {7, 7}, {8, 10}, {9, -1}, {11, 11}},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			rows := NewPatchParser(nil, s.diffText).SideBySideRows()
+			result := [][2]int{}
+			for _, row := range rows {
+				result = append(result, [2]int{row.Left, row.Right})
+			}
+			assert.EqualValues(t, s.expected, result)
+		})
+	}
+}
+
+// TestRenderSideBySide is a function.
+func TestRenderSideBySide(t *testing.T) {
+	type scenario struct {
+		testName string
+		diffText string
+		width    int
+		expected string
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "replaced lines",
+			diffText: wordDiff,
+			width:    40,
+			expected: `diff --git a/filename b/filename
+index dcd3485..1ba5540 100644
+--- a/filename
++++ b/filename
+@@ -1,4 +1,4 @@
+1  apple           │1  apple           
+2 -the quick brown │2 +the slow brown d
+3  banana          │3  banana          
+4 -cherry          │4 +kiwi            
+ `,
+		},
+		{
+			testName: "line numbers from the hunk header",
+			diffText: addNewlineToEndOfFile,
+			width:    30,
+			expected: `diff --git a/filename b/filename
+index 80a73f1..e48a11c 100644
+--- a/filename
++++ b/filename
+@@ -60,4 +60,4 @@ grape
+60  ...       │60  ...       
+61  ...       │61  ...       
+62  ...       │62  ...       
+63 -last line │63 +last line 
+   \ No newlin│              
+ `,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			parser := NewPatchParser(nil, s.diffText)
+			result := utils.Decolorise(parser.RenderSideBySide(parser.SideBySideRows(), s.width, -1, -1, nil))
+			if !assert.Equal(t, s.expected, result) {
+				fmt.Println(result)
+			}
+		})
+	}
+}
+
+// TestSideBySideRowForLine is a function.
+func TestSideBySideRowForLine(t *testing.T) {
+	rows := NewPatchParser(nil, wordDiffJoinedLines).SideBySideRows()
+
+	assert.Equal(t, 5, SideBySideRowForLine(rows, 5))
+	assert.Equal(t, 6, SideBySideRowForLine(rows, 6))
+	assert.Equal(t, 5, SideBySideRowForLine(rows, 7))
+	assert.Equal(t, 8, SideBySideLineForRow(rows, 7))
+	assert.Equal(t, 6, SideBySideLineForRow(rows, 6))
+	assert.Equal(t, 9, SideBySideLineForRow(rows, 100))
+}
@@ -0,0 +1,441 @@
+package patch
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// The job of this file is to let us stage parts of a line. We take the block of
+// changed lines around a given line of a diff, work out which words changed
+// between the old and new versions of the block, and present those changes in
+// the style of `git diff --word-diff=porcelain`, i.e. one segment per line,
+// prefixed with ' ' for unchanged text, '-' for deleted text, '+' for added
+// text and '~' for a line break. Given a selection of segments we can then
+// build a patch which replaces the block with a version that only has the
+// selected changes applied.
+
+type WordDiffSegmentKind int
+
+const (
+	WORD_CONTEXT WordDiffSegmentKind = iota
+	WORD_DELETION
+	WORD_ADDITION
+	// a line break present in both the old and new text
+	WORD_NEWLINE
+)
+
+type WordDiffSegment struct {
+	Kind WordDiffSegmentKind
+	// for a deleted or added line break this is "\n"
+	Content string
+}
+
+func (s *WordDiffSegment) isChange() bool {
+	return s.Kind == WORD_DELETION || s.Kind == WORD_ADDITION
+}
+
+// WordDiff is the word-level diff of a block of changed lines within a hunk
+type WordDiff struct {
+	Segments []*WordDiffSegment
+	// indices of the segments which can be selected i.e. deletions and additions
+	ChangedSegments []int
+
+	hunk *PatchHunk
+	// the indices of the first and last lines of the block in the hunk's body
+	firstBodyIdx int
+	lastBodyIdx  int
+}
+
+// NewWordDiff returns the word diff of the block of changed lines containing
+// the given line of the diff, or nil if that line isn't a changed line
+func NewWordDiff(diff string, lineIdx int) *WordDiff {
+	for _, hunk := range GetHunksFromDiff(diff) {
+		if lineIdx < hunk.FirstLineIdx || lineIdx > hunk.LastLineIdx() {
+			continue
+		}
+
+		// the first line of the hunk is its header
+		bodyIdx := lineIdx - hunk.FirstLineIdx - 1
+		if bodyIdx < 0 || bodyIdx >= len(hunk.bodyLines) || !isChangedLine(hunk.bodyLines[bodyIdx]) {
+			return nil
+		}
+
+		firstBodyIdx := bodyIdx
+		for firstBodyIdx > 0 && isBlockLine(hunk.bodyLines[firstBodyIdx-1]) {
+			firstBodyIdx--
+		}
+		lastBodyIdx := bodyIdx
+		for lastBodyIdx < len(hunk.bodyLines)-1 && isBlockLine(hunk.bodyLines[lastBodyIdx+1]) {
+			lastBodyIdx++
+		}
+
+		oldText, newText := blockTexts(hunk.bodyLines[firstBodyIdx : lastBodyIdx+1])
+		oldTokens, newTokens := tokenize(oldText), tokenize(newText)
+		if len(oldTokens)*len(newTokens) > maxWordDiffCells {
+			// too big to diff word by word so we settle for diffing whole lines
+			oldTokens, newTokens = tokenizeLines(oldText), tokenizeLines(newText)
+		}
+		segments := buildWordDiffSegments(oldTokens, newTokens)
+
+		changedSegments := []int{}
+		for i, segment := range segments {
+			if segment.isChange() {
+				changedSegments = append(changedSegments, i)
+			}
+		}
+
+		return &WordDiff{
+			Segments:        segments,
+			ChangedSegments: changedSegments,
+			hunk:            hunk,
+			firstBodyIdx:    firstBodyIdx,
+			lastBodyIdx:     lastBodyIdx,
+		}
+	}
+
+	return nil
+}
+
+func isChangedLine(line string) bool {
+	return strings.HasPrefix(line, "-") || strings.HasPrefix(line, "+")
+}
+
+// the 'No newline at end of file' message belongs to the block too
+func isBlockLine(line string) bool {
+	return isChangedLine(line) || strings.HasPrefix(line, "\\")
+}
+
+// blockTexts returns the old and new versions of the block of changed lines
+func blockTexts(lines []string) (string, string) {
+	oldText := ""
+	newText := ""
+	lastKind := ""
+	for _, line := range lines {
+		line = strings.TrimSuffix(line, "\n")
+		switch line[:1] {
+		case "-":
+			oldText += line[1:] + "\n"
+			lastKind = "-"
+		case "+":
+			newText += line[1:] + "\n"
+			lastKind = "+"
+		case "\\":
+			if lastKind == "-" {
+				oldText = strings.TrimSuffix(oldText, "\n")
+			} else {
+				newText = strings.TrimSuffix(newText, "\n")
+			}
+		}
+	}
+
+	return oldText, newText
+}
+
+type tokenKind int
+
+const (
+	WORD tokenKind = iota
+	SPACE
+	OTHER
+)
+
+func runeTokenKind(r rune) tokenKind {
+	if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+		return WORD
+	} else if r == ' ' || r == '\t' {
+		return SPACE
+	}
+	return OTHER
+}
+
+// tokenize splits text into words, runs of whitespace, and individual
+// punctuation characters and line breaks
+func tokenize(text string) []string {
+	tokens := []string{}
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		kind := runeTokenKind(runes[i])
+		j := i + 1
+		if kind != OTHER {
+			for j < len(runes) && runeTokenKind(runes[j]) == kind {
+				j++
+			}
+		}
+		tokens = append(tokens, string(runes[i:j]))
+		i = j
+	}
+
+	return tokens
+}
+
+// tokenizeLines is like tokenize but treats each line as a single token
+func tokenizeLines(text string) []string {
+	tokens := []string{}
+	for _, line := range strings.SplitAfter(text, "\n") {
+		content := strings.TrimSuffix(line, "\n")
+		if content != "" {
+			tokens = append(tokens, content)
+		}
+		if content != line {
+			tokens = append(tokens, "\n")
+		}
+	}
+
+	return tokens
+}
+
+// maxWordDiffCells caps the size of the table we use to diff a block, which
+// grows with the number of old tokens times the number of new tokens. This is
+// a var so that tests can lower it.
+var maxWordDiffCells = 1 << 20
+
+// segmentBuilder groups tokens into segments, giving each line break its own
+// segment
+type segmentBuilder struct {
+	segments []*WordDiffSegment
+}
+
+func (b *segmentBuilder) add(kind WordDiffSegmentKind, token string) {
+	if token == "\n" {
+		if kind == WORD_CONTEXT {
+			kind = WORD_NEWLINE
+		}
+		b.segments = append(b.segments, &WordDiffSegment{Kind: kind, Content: token})
+		return
+	}
+
+	if len(b.segments) > 0 {
+		last := b.segments[len(b.segments)-1]
+		if last.Kind == kind && last.Content != "\n" {
+			last.Content += token
+			return
+		}
+	}
+	b.segments = append(b.segments, &WordDiffSegment{Kind: kind, Content: token})
+}
+
+// buildWordDiffSegments diffs the tokens via their longest common subsequence
+// and groups the result into segments. If there are too many tokens to do that
+// we treat all of the old tokens as replaced by all of the new ones.
+func buildWordDiffSegments(oldTokens []string, newTokens []string) []*WordDiffSegment {
+	builder := &segmentBuilder{}
+
+	if len(oldTokens)*len(newTokens) > maxWordDiffCells {
+		for _, token := range oldTokens {
+			builder.add(WORD_DELETION, token)
+		}
+		for _, token := range newTokens {
+			builder.add(WORD_ADDITION, token)
+		}
+		return builder.segments
+	}
+
+	// lengths[i][j] is the length of the LCS of oldTokens[i:] and newTokens[j:]
+	lengths := make([][]int, len(oldTokens)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(newTokens)+1)
+	}
+	for i := len(oldTokens) - 1; i >= 0; i-- {
+		for j := len(newTokens) - 1; j >= 0; j-- {
+			if oldTokens[i] == newTokens[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = utils.Max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	// we collect additions as we go so that within a change, deleted text comes
+	// before added text as with git's word diff
+	pendingAdditions := []string{}
+	flushAdditions := func() {
+		for _, token := range pendingAdditions {
+			builder.add(WORD_ADDITION, token)
+		}
+		pendingAdditions = []string{}
+	}
+
+	i, j := 0, 0
+	for i < len(oldTokens) || j < len(newTokens) {
+		switch {
+		case i < len(oldTokens) && j < len(newTokens) && oldTokens[i] == newTokens[j]:
+			flushAdditions()
+			builder.add(WORD_CONTEXT, oldTokens[i])
+			i++
+			j++
+		case j < len(newTokens) && (i == len(oldTokens) || lengths[i][j+1] >= lengths[i+1][j]):
+			pendingAdditions = append(pendingAdditions, newTokens[j])
+			j++
+		default:
+			builder.add(WORD_DELETION, oldTokens[i])
+			i++
+		}
+	}
+	flushAdditions()
+
+	return builder.segments
+}
+
+// texts returns the text of the block before and after applying the selected
+// changes. When reverse is true we start from the new text and undo the
+// selected changes, as is needed when unstaging or discarding.
+func (w *WordDiff) texts(segmentIndices []int, reverse bool) (string, string) {
+	source := ""
+	target := ""
+	for i, segment := range w.Segments {
+		selected := utils.IncludesInt(segmentIndices, i)
+
+		switch segment.Kind {
+		case WORD_CONTEXT, WORD_NEWLINE:
+			source += segment.Content
+			target += segment.Content
+		case WORD_DELETION:
+			if !reverse {
+				source += segment.Content
+			}
+			if selected == reverse {
+				target += segment.Content
+			}
+		case WORD_ADDITION:
+			if reverse {
+				source += segment.Content
+			}
+			if selected != reverse {
+				target += segment.Content
+			}
+		}
+	}
+
+	return source, target
+}
+
+// textToPatchLines turns text into patch lines with the given prefix, adding
+// the 'No newline at end of file' message where needed
+func textToPatchLines(text string, prefix string) []string {
+	if text == "" {
+		return []string{}
+	}
+
+	lines := []string{}
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if line != "" {
+			lines = append(lines, prefix+line)
+		}
+	}
+	if !strings.HasSuffix(text, "\n") {
+		lines[len(lines)-1] += "\n"
+		lines = append(lines, "\\ No newline at end of file\n")
+	}
+
+	return lines
+}
+
+// ModifiedPatchForSegments returns a patch which applies only the selected
+// segments' changes to the block. Changed lines of the hunk outside the block
+// are left alone. If reverse is true the patch undoes the selected changes
+// instead, for use on the index when unstaging or on the working tree when
+// discarding.
+func (w *WordDiff) ModifiedPatchForSegments(filename string, segmentIndices []int, reverse bool) string {
+	source, target := w.texts(segmentIndices, reverse)
+	if source == target {
+		return ""
+	}
+
+	bodyLines := []string{}
+	keptPreviousLine := false
+	for i, line := range w.hunk.bodyLines {
+		if line == "" {
+			break
+		}
+
+		if i == w.firstBodyIdx {
+			bodyLines = append(bodyLines, textToPatchLines(source, "-")...)
+			bodyLines = append(bodyLines, textToPatchLines(target, "+")...)
+			continue
+		}
+		if i > w.firstBodyIdx && i <= w.lastBodyIdx {
+			continue
+		}
+
+		// outside of the block, lines only in the version we're applying the
+		// patch to become context and lines only in the other version are dropped
+		firstChar, content := line[:1], line[1:]
+		switch {
+		case firstChar == " ", firstChar == "\\" && keptPreviousLine:
+			bodyLines = append(bodyLines, line)
+			keptPreviousLine = true
+		case (firstChar == "-" && !reverse) || (firstChar == "+" && reverse):
+			bodyLines = append(bodyLines, " "+content)
+			keptPreviousLine = true
+		default:
+			keptPreviousLine = false
+		}
+	}
+
+	oldLength := nLinesWithPrefix(bodyLines, []string{" ", "-"})
+	newLength := nLinesWithPrefix(bodyLines, []string{" ", "+"})
+
+	oldStart := w.hunk.oldStart
+	if reverse {
+		oldStart = w.hunk.newStart
+	}
+
+	newStart := oldStart
+	// same as for line-based patches: going from zero lines or to zero lines
+	// shifts the starting point
+	if oldLength == 0 {
+		newStart++
+	} else if newLength == 0 {
+		newStart--
+	}
+
+	header := w.hunk.formatHeader(oldStart, oldLength, newStart, newLength, w.hunk.heading)
+	fileHeader := fmt.Sprintf("--- a/%s\n+++ b/%s\n", filename, filename)
+
+	return fileHeader + header + strings.Join(bodyLines, "")
+}
+
+// Render returns the coloured porcelain-style word diff, with the segments
+// from firstIdx to lastIdx highlighted
+func (w *WordDiff) Render(firstIdx int, lastIdx int) string {
+	renderedLines := make([]string, len(w.Segments))
+	for i, segment := range w.Segments {
+		selected := i >= firstIdx && i <= lastIdx
+
+		content := segment.Content
+		if content == "\n" {
+			content = ""
+		}
+
+		var line string
+		textStyle := theme.DefaultTextColor
+		switch segment.Kind {
+		case WORD_CONTEXT:
+			line = " " + content
+		case WORD_NEWLINE:
+			line = "~"
+			textStyle = style.FgCyan
+		case WORD_DELETION:
+			line = "-" + content
+			textStyle = style.FgRed
+			if segment.Content == "\n" {
+				line = "-~"
+			}
+		case WORD_ADDITION:
+			line = "+" + content
+			textStyle = style.FgGreen
+			if segment.Content == "\n" {
+				line = "+~"
+			}
+		}
+
+		renderedLines[i] = coloredString(textStyle, line, selected, false)
+	}
+
+	return strings.Join(renderedLines, "\n")
+}
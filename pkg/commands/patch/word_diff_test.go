@@ -0,0 +1,281 @@
+package patch
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const wordDiff = `diff --git a/filename b/filename
+index dcd3485..1ba5540 100644
+--- a/filename
++++ b/filename
+@@ -1,4 +1,4 @@
+ apple
+-the quick brown fox
++the slow brown dog
+ banana
+-cherry
++kiwi
+`
+
+const wordDiffJoinedLines = `diff --git a/filename b/filename
+index dcd3485..1ba5540 100644
+--- a/filename
++++ b/filename
+@@ -1,3 +1,2 @@
+-foo(a,
+-    b)
++foo(a, c)
+ bar
+`
+
+// TestNewWordDiff is a function.
+func TestNewWordDiff(t *testing.T) {
+	type scenario struct {
+		testName string
+		diffText string
+		lineIdx  int
+		// if set, overrides maxWordDiffCells
+		maxCells int
+		expected []*WordDiffSegment
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "context line",
+			diffText: wordDiff,
+			lineIdx:  5,
+			expected: nil,
+		},
+		{
+			testName: "changed words",
+			diffText: wordDiff,
+			lineIdx:  7,
+			expected: []*WordDiffSegment{
+				{Kind: WORD_CONTEXT, Content: "the "},
+				{Kind: WORD_DELETION, Content: "quick"},
+				{Kind: WORD_ADDITION, Content: "slow"},
+				{Kind: WORD_CONTEXT, Content: " brown "},
+				{Kind: WORD_DELETION, Content: "fox"},
+				{Kind: WORD_ADDITION, Content: "dog"},
+				{Kind: WORD_NEWLINE, Content: "\n"},
+			},
+		},
+		{
+			testName: "joined lines",
+			diffText: wordDiffJoinedLines,
+			lineIdx:  5,
+			expected: []*WordDiffSegment{
+				{Kind: WORD_CONTEXT, Content: "foo(a,"},
+				{Kind: WORD_DELETION, Content: "\n"},
+				{Kind: WORD_DELETION, Content: "    b"},
+				{Kind: WORD_ADDITION, Content: " c"},
+				{Kind: WORD_CONTEXT, Content: ")"},
+				{Kind: WORD_NEWLINE, Content: "\n"},
+			},
+		},
+		{
+			testName: "too big to diff word by word",
+			diffText: wordDiff,
+			lineIdx:  7,
+			maxCells: 10,
+			expected: []*WordDiffSegment{
+				{Kind: WORD_DELETION, Content: "the quick brown fox"},
+				{Kind: WORD_ADDITION, Content: "the slow brown dog"},
+				{Kind: WORD_NEWLINE, Content: "\n"},
+			},
+		},
+		{
+			testName: "too big to diff line by line",
+			diffText: wordDiff,
+			lineIdx:  7,
+			maxCells: 3,
+			expected: []*WordDiffSegment{
+				{Kind: WORD_DELETION, Content: "the quick brown fox"},
+				{Kind: WORD_DELETION, Content: "\n"},
+				{Kind: WORD_ADDITION, Content: "the slow brown dog"},
+				{Kind: WORD_ADDITION, Content: "\n"},
+			},
+		},
+		{
+			testName: "missing newline at end of file",
+			diffText: addNewlineToEndOfFile,
+			lineIdx:  8,
+			expected: []*WordDiffSegment{
+				{Kind: WORD_CONTEXT, Content: "last line"},
+				{Kind: WORD_ADDITION, Content: "\n"},
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			if s.maxCells != 0 {
+				defaultMaxCells := maxWordDiffCells
+				maxWordDiffCells = s.maxCells
+				defer func() { maxWordDiffCells = defaultMaxCells }()
+			}
+
+			result := NewWordDiff(s.diffText, s.lineIdx)
+			if s.expected == nil {
+				assert.Nil(t, result)
+				return
+			}
+			assert.EqualValues(t, s.expected, result.Segments)
+		})
+	}
+}
+
+// TestModifiedPatchForSegments is a function.
+func TestModifiedPatchForSegments(t *testing.T) {
+	type scenario struct {
+		testName       string
+		diffText       string
+		lineIdx        int
+		segmentIndices []int
+		reverse        bool
+		expected       string
+	}
+
+	scenarios := []scenario{
+		{
+			testName:       "nothing selected",
+			diffText:       wordDiff,
+			lineIdx:        6,
+			segmentIndices: []int{},
+			reverse:        false,
+			expected:       "",
+		},
+		{
+			testName:       "only context selected",
+			diffText:       wordDiff,
+			lineIdx:        6,
+			segmentIndices: []int{0, 3},
+			reverse:        false,
+			expected:       "",
+		},
+		{
+			testName:       "one word replaced",
+			diffText:       wordDiff,
+			lineIdx:        6,
+			segmentIndices: []int{4, 5},
+			reverse:        false,
+			expected: `--- a/filename
++++ b/filename
+@@ -1,4 +1,4 @@
+ apple
+-the quick brown fox
++the quick brown dog
+ banana
+ cherry
+`,
+		},
+		{
+			testName:       "one word added without the other being removed",
+			diffText:       wordDiff,
+			lineIdx:        6,
+			segmentIndices: []int{2},
+			reverse:        false,
+			expected: `--- a/filename
++++ b/filename
+@@ -1,4 +1,4 @@
+ apple
+-the quick brown fox
++the quickslow brown fox
+ banana
+ cherry
+`,
+		},
+		{
+			testName:       "one word replaced, reversed",
+			diffText:       wordDiff,
+			lineIdx:        6,
+			segmentIndices: []int{4, 5},
+			reverse:        true,
+			expected: `--- a/filename
++++ b/filename
+@@ -1,4 +1,4 @@
+ apple
+-the slow brown dog
++the slow brown fox
+ banana
+ kiwi
+`,
+		},
+		{
+			testName:       "line break removed",
+			diffText:       wordDiffJoinedLines,
+			lineIdx:        5,
+			segmentIndices: []int{1},
+			reverse:        false,
+			expected: `--- a/filename
++++ b/filename
+@@ -1,3 +1,2 @@
+-foo(a,
+-    b)
++foo(a,    b)
+ bar
+`,
+		},
+		{
+			testName:       "line break removed, reversed",
+			diffText:       wordDiffJoinedLines,
+			lineIdx:        5,
+			segmentIndices: []int{1},
+			reverse:        true,
+			expected: `--- a/filename
++++ b/filename
+@@ -1,2 +1,3 @@
+-foo(a, c)
++foo(a,
++ c)
+ bar
+`,
+		},
+		{
+			testName:       "adding newline to end of file",
+			diffText:       addNewlineToEndOfFile,
+			lineIdx:        8,
+			segmentIndices: []int{1},
+			reverse:        false,
+			expected: `--- a/filename
++++ b/filename
+@@ -60,4 +60,4 @@ grape
+ ...
+ ...
+ ...
+-last line
+\ No newline at end of file
++last line
+`,
+		},
+		{
+			testName:       "adding newline to end of file, reversed",
+			diffText:       addNewlineToEndOfFile,
+			lineIdx:        8,
+			segmentIndices: []int{1},
+			reverse:        true,
+			expected: `--- a/filename
++++ b/filename
+@@ -60,4 +60,4 @@ grape
+ ...
+ ...
+ ...
+-last line
++last line
+\ No newline at end of file
+`,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			result := NewWordDiff(s.diffText, s.lineIdx).ModifiedPatchForSegments("filename", s.segmentIndices, s.reverse)
+			if !assert.Equal(t, s.expected, result) {
+				fmt.Println(result)
+			}
+		})
+	}
+}
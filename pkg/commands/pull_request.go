@@ -1,68 +1,12 @@
 package commands
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/go-errors/errors"
 	"github.com/jesseduffield/lazygit/pkg/config"
 )
 
-// Service is a service that repository is on (Github, Bitbucket, ...)
-type Service struct {
-	Name                            string
-	pullRequestURLIntoDefaultBranch func(owner string, repository string, from string) string
-	pullRequestURLIntoTargetBranch  func(owner string, repository string, from string, to string) string
-}
-
-// NewService builds a Service based on the host type
-func NewService(typeName string, repositoryDomain string, siteDomain string) *Service {
-	var service *Service
-
-	switch typeName {
-	case "github":
-		service = &Service{
-			Name: repositoryDomain,
-			pullRequestURLIntoDefaultBranch: func(owner string, repository string, from string) string {
-				return fmt.Sprintf("https://%s/%s/%s/compare/%s?expand=1", siteDomain, owner, repository, from)
-			},
-			pullRequestURLIntoTargetBranch: func(owner string, repository string, from string, to string) string {
-				return fmt.Sprintf("https://%s/%s/%s/compare/%s...%s?expand=1", siteDomain, owner, repository, to, from)
-			},
-		}
-	case "bitbucket":
-		service = &Service{
-			Name: repositoryDomain,
-			pullRequestURLIntoDefaultBranch: func(owner string, repository string, from string) string {
-				return fmt.Sprintf("https://%s/%s/%s/pull-requests/new?source=%s&t=1", siteDomain, owner, repository, from)
-			},
-			pullRequestURLIntoTargetBranch: func(owner string, repository string, from string, to string) string {
-				return fmt.Sprintf("https://%s/%s/%s/pull-requests/new?source=%s&dest=%s&t=1", siteDomain, owner, repository, from, to)
-			},
-		}
-	case "gitlab":
-		service = &Service{
-			Name: repositoryDomain,
-			pullRequestURLIntoDefaultBranch: func(owner string, repository string, from string) string {
-				return fmt.Sprintf("https://%s/%s/%s/merge_requests/new?merge_request[source_branch]=%s", siteDomain, owner, repository, from)
-			},
-			pullRequestURLIntoTargetBranch: func(owner string, repository string, from string, to string) string {
-				return fmt.Sprintf("https://%s/%s/%s/merge_requests/new?merge_request[source_branch]=%s&merge_request[target_branch]=%s", siteDomain, owner, repository, from, to)
-			},
-		}
-	}
-
-	return service
-}
-
-func (s *Service) PullRequestURL(owner string, repository string, from string, to string) string {
-	if to == "" {
-		return s.pullRequestURLIntoDefaultBranch(owner, repository, from)
-	} else {
-		return s.pullRequestURLIntoTargetBranch(owner, repository, from, to)
-	}
-}
-
 // PullRequest opens a link in browser to create new pull request
 // with selected branch
 type PullRequest struct {
@@ -77,22 +21,22 @@ type RepoInformation struct {
 }
 
 func getServices(config config.AppConfigurer) []*Service {
-	services := []*Service{
-		NewService("github", "github.com", "github.com"),
-		NewService("bitbucket", "bitbucket.org", "bitbucket.org"),
-		NewService("gitlab", "gitlab.com", "gitlab.com"),
-	}
+	userConfig := config.GetUserConfig()
+	providers := getServiceProviders(userConfig)
 
-	configServices := config.GetUserConfig().Services
+	services := []*Service{}
+	for _, defaultDomain := range defaultServiceDomains {
+		services = append(services, NewService(providers, defaultDomain.provider, defaultDomain.domain, defaultDomain.domain))
+	}
 
-	for repoDomain, typeAndDomain := range configServices {
+	for repoDomain, typeAndDomain := range userConfig.Services {
 		splitData := strings.Split(typeAndDomain, ":")
 		if len(splitData) != 2 {
 			// TODO log this misconfiguration
 			continue
 		}
 
-		service := NewService(splitData[0], repoDomain, splitData[1])
+		service := NewService(providers, splitData[0], repoDomain, splitData[1])
 		if service == nil {
 			// TODO log this unsupported service
 			continue
@@ -140,26 +84,31 @@ func (pr *PullRequest) getPullRequestURL(from string, to string) (string, error)
 	}
 
 	repoURL := pr.GitCommand.GetRemoteURL()
-	var gitService *Service
-
-	for _, service := range pr.GitServices {
-		if strings.Contains(repoURL, service.Name) {
-			gitService = service
-			break
-		}
-	}
-
+	gitService := getServiceForURL(pr.GitServices, repoURL)
 	if gitService == nil {
 		return "", errors.New(pr.GitCommand.Tr.UnsupportedGitService)
 	}
 
-	repoInfo := getRepoInfoFromURL(repoURL)
-
-	pullRequestURL := gitService.PullRequestURL(repoInfo.Owner, repoInfo.Repository, from, to)
+	pullRequestURL := gitService.PullRequestURL(repoURL, from, to)
+	if pullRequestURL == "" {
+		return "", errors.New(pr.GitCommand.Tr.ServiceURLNotConfigured)
+	}
 
 	return pullRequestURL, nil
 }
 
+// getServiceForURL returns the service whose domain appears in the given
+// remote URL, or nil if there isn't one
+func getServiceForURL(services []*Service, repoURL string) *Service {
+	for _, service := range services {
+		if strings.Contains(repoURL, service.Name) {
+			return service
+		}
+	}
+
+	return nil
+}
+
 func getRepoInfoFromURL(url string) *RepoInformation {
 	isHTTP := strings.HasPrefix(url, "http")
 
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/jesseduffield/lazygit/pkg/config"
 	"github.com/jesseduffield/lazygit/pkg/secureexec"
 	"github.com/stretchr/testify/assert"
 )
@@ -250,6 +251,37 @@ func TestCreatePullRequest(t *testing.T) {
 				assert.Equal(t, "https://gitlab.com/peter/public/calculator/merge_requests/new?merge_request[source_branch]=feature/commit-ui&merge_request[target_branch]=epic/ui", url)
 			},
 		},
+		{
+			testName:  "Opens a link to new pull request on a custom service provider",
+			from:      "feature/ui",
+			to:        "main",
+			remoteUrl: "git@git.custom.com:peter/calculator.git",
+			command: func(cmd string, args ...string) *exec.Cmd {
+				// Handle git remote url call
+				if strings.HasPrefix(cmd, "git") {
+					return secureexec.Command("echo", "git@git.custom.com:peter/calculator.git")
+				}
+
+				assert.Equal(t, cmd, "open")
+				assert.Equal(t, args, []string{"http://web.custom.com/peter/calculator/pulls/new/main...feature/ui"})
+				return secureexec.Command("echo")
+			},
+			test: func(url string, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "http://web.custom.com/peter/calculator/pulls/new/main...feature/ui", url)
+			},
+		},
+		{
+			testName:  "Throws an error if the service provider has no template for the URL",
+			from:      "feature/ui",
+			remoteUrl: "git@git.custom.com:peter/calculator.git",
+			command: func(cmd string, args ...string) *exec.Cmd {
+				return secureexec.Command("echo")
+			},
+			test: func(url string, err error) {
+				assert.Error(t, err)
+			},
+		},
 		{
 			testName:  "Throws an error if git service is unsupported",
 			from:      "feature/divide-operation",
@@ -268,12 +300,19 @@ func TestCreatePullRequest(t *testing.T) {
 			gitCommand := NewDummyGitCommand()
 			gitCommand.OSCommand.Command = s.command
 			gitCommand.OSCommand.Config.GetUserConfig().OS.OpenLinkCommand = "open {{link}}"
-			gitCommand.OSCommand.Config.GetUserConfig().Services = map[string]string{
+			gitCommand.Config.GetUserConfig().Services = map[string]string{
 				// valid configuration for a custom service URL
 				"git.work.com": "gitlab:code.work.com",
 				// invalid configurations for a custom service URL
 				"invalid.work.com":   "noservice:invalid.work.com",
 				"noservice.work.com": "noservice.work.com",
+				// service using a provider declared in the config
+				"git.custom.com": "custom:web.custom.com",
+			}
+			gitCommand.Config.GetUserConfig().ServiceProviders = map[string]config.ServiceProvider{
+				"custom": {
+					PullRequestURLIntoTargetBranch: "http://{{.webDomain}}/{{.owner}}/{{.repo}}/pulls/new/{{.to}}...{{.from}}",
+				},
 			}
 			gitCommand.getGitConfigValue = func(path string) (string, error) {
 				assert.Equal(t, path, "remote.origin.url")
@@ -284,3 +323,168 @@ func TestCreatePullRequest(t *testing.T) {
 		})
 	}
 }
+
+// TestServiceURLs is a function.
+func TestServiceURLs(t *testing.T) {
+	type scenario struct {
+		testName           string
+		provider           string
+		webDomain          string
+		remoteURL          string
+		expectedPRURL      string
+		expectedPRInto     string
+		expectedCommit     string
+		expectedBranch     string
+		expectedTag        string
+		expectedFile       string
+		expectedCommitFile string
+	}
+
+	scenarios := []scenario{
+		{
+			testName:           "github",
+			provider:           "github",
+			webDomain:          "github.com",
+			remoteURL:          "git@github.com:peter/calculator.git",
+			expectedPRURL:      "https://github.com/peter/calculator/compare/feature/ui?expand=1",
+			expectedPRInto:     "https://github.com/peter/calculator/compare/main...feature/ui?expand=1",
+			expectedCommit:     "https://github.com/peter/calculator/commit/abc123",
+			expectedBranch:     "https://github.com/peter/calculator/tree/feature/ui",
+			expectedTag:        "https://github.com/peter/calculator/releases/tag/v1.0",
+			expectedFile:       "https://github.com/peter/calculator/blob/feature/ui/pkg/sum.go#L12",
+			expectedCommitFile: "https://github.com/peter/calculator/blob/abc123/pkg/sum.go#L12",
+		},
+		{
+			testName:           "bitbucket",
+			provider:           "bitbucket",
+			webDomain:          "bitbucket.org",
+			remoteURL:          "https://my_username@bitbucket.org/johndoe/social_network.git",
+			expectedPRURL:      "https://bitbucket.org/johndoe/social_network/pull-requests/new?source=feature/ui&t=1",
+			expectedPRInto:     "https://bitbucket.org/johndoe/social_network/pull-requests/new?source=feature/ui&dest=main&t=1",
+			expectedCommit:     "https://bitbucket.org/johndoe/social_network/commits/abc123",
+			expectedBranch:     "https://bitbucket.org/johndoe/social_network/branch/feature/ui",
+			expectedTag:        "https://bitbucket.org/johndoe/social_network/src/v1.0",
+			expectedFile:       "https://bitbucket.org/johndoe/social_network/src/feature/ui/pkg/sum.go#lines-12",
+			expectedCommitFile: "https://bitbucket.org/johndoe/social_network/src/abc123/pkg/sum.go#lines-12",
+		},
+		{
+			testName:           "gitlab",
+			provider:           "gitlab",
+			webDomain:          "gitlab.com",
+			remoteURL:          "git@gitlab.com:peter/public/calculator.git",
+			expectedPRURL:      "https://gitlab.com/peter/public/calculator/merge_requests/new?merge_request[source_branch]=feature/ui",
+			expectedPRInto:     "https://gitlab.com/peter/public/calculator/merge_requests/new?merge_request[source_branch]=feature/ui&merge_request[target_branch]=main",
+			expectedCommit:     "https://gitlab.com/peter/public/calculator/-/commit/abc123",
+			expectedBranch:     "https://gitlab.com/peter/public/calculator/-/tree/feature/ui",
+			expectedTag:        "https://gitlab.com/peter/public/calculator/-/tags/v1.0",
+			expectedFile:       "https://gitlab.com/peter/public/calculator/-/blob/feature/ui/pkg/sum.go#L12",
+			expectedCommitFile: "https://gitlab.com/peter/public/calculator/-/blob/abc123/pkg/sum.go#L12",
+		},
+		{
+			testName:           "gitea",
+			provider:           "gitea",
+			webDomain:          "gitea.work.com",
+			remoteURL:          "git@gitea.work.com:peter/calculator.git",
+			expectedPRURL:      "https://gitea.work.com/peter/calculator/compare/feature/ui",
+			expectedPRInto:     "https://gitea.work.com/peter/calculator/compare/main...feature/ui",
+			expectedCommit:     "https://gitea.work.com/peter/calculator/commit/abc123",
+			expectedBranch:     "https://gitea.work.com/peter/calculator/src/branch/feature/ui",
+			expectedTag:        "https://gitea.work.com/peter/calculator/releases/tag/v1.0",
+			expectedFile:       "https://gitea.work.com/peter/calculator/src/feature/ui/pkg/sum.go#L12",
+			expectedCommitFile: "https://gitea.work.com/peter/calculator/src/abc123/pkg/sum.go#L12",
+		},
+		{
+			testName:           "azure devops with ssh remote url",
+			provider:           "azuredevops",
+			webDomain:          "dev.azure.com",
+			remoteURL:          "git@ssh.dev.azure.com:v3/myorg/myproject/calculator",
+			expectedPRURL:      "https://dev.azure.com/myorg/myproject/_git/calculator/pullrequestcreate?sourceRef=feature/ui",
+			expectedPRInto:     "https://dev.azure.com/myorg/myproject/_git/calculator/pullrequestcreate?sourceRef=feature/ui&targetRef=main",
+			expectedCommit:     "https://dev.azure.com/myorg/myproject/_git/calculator/commit/abc123",
+			expectedBranch:     "https://dev.azure.com/myorg/myproject/_git/calculator?version=GBfeature/ui",
+			expectedTag:        "https://dev.azure.com/myorg/myproject/_git/calculator?version=GTv1.0",
+			expectedFile:       "https://dev.azure.com/myorg/myproject/_git/calculator?path=/pkg/sum.go&version=GBfeature/ui&line=12",
+			expectedCommitFile: "https://dev.azure.com/myorg/myproject/_git/calculator?path=/pkg/sum.go&version=GCabc123&line=12",
+		},
+		{
+			testName:           "azure devops with http remote url",
+			provider:           "azuredevops",
+			webDomain:          "dev.azure.com",
+			remoteURL:          "https://myorg@dev.azure.com/myorg/myproject/_git/calculator",
+			expectedPRURL:      "https://dev.azure.com/myorg/myproject/_git/calculator/pullrequestcreate?sourceRef=feature/ui",
+			expectedPRInto:     "https://dev.azure.com/myorg/myproject/_git/calculator/pullrequestcreate?sourceRef=feature/ui&targetRef=main",
+			expectedCommit:     "https://dev.azure.com/myorg/myproject/_git/calculator/commit/abc123",
+			expectedBranch:     "https://dev.azure.com/myorg/myproject/_git/calculator?version=GBfeature/ui",
+			expectedTag:        "https://dev.azure.com/myorg/myproject/_git/calculator?version=GTv1.0",
+			expectedFile:       "https://dev.azure.com/myorg/myproject/_git/calculator?path=/pkg/sum.go&version=GBfeature/ui&line=12",
+			expectedCommitFile: "https://dev.azure.com/myorg/myproject/_git/calculator?path=/pkg/sum.go&version=GCabc123&line=12",
+		},
+		{
+			testName:           "bitbucket server with ssh remote url",
+			provider:           "bitbucketServer",
+			webDomain:          "bitbucket.work.com",
+			remoteURL:          "ssh://git@bitbucket.work.com:7999/CALC/calculator.git",
+			expectedPRURL:      "https://bitbucket.work.com/projects/CALC/repos/calculator/pull-requests?create&sourceBranch=feature/ui",
+			expectedPRInto:     "https://bitbucket.work.com/projects/CALC/repos/calculator/pull-requests?create&sourceBranch=feature/ui&targetBranch=main",
+			expectedCommit:     "https://bitbucket.work.com/projects/CALC/repos/calculator/commits/abc123",
+			expectedBranch:     "https://bitbucket.work.com/projects/CALC/repos/calculator/browse?at=feature/ui",
+			expectedTag:        "https://bitbucket.work.com/projects/CALC/repos/calculator/browse?at=refs/tags/v1.0",
+			expectedFile:       "https://bitbucket.work.com/projects/CALC/repos/calculator/browse/pkg/sum.go?at=feature/ui#12",
+			expectedCommitFile: "https://bitbucket.work.com/projects/CALC/repos/calculator/browse/pkg/sum.go?at=abc123#12",
+		},
+		{
+			testName:           "bitbucket server with http remote url",
+			provider:           "bitbucketServer",
+			webDomain:          "bitbucket.work.com",
+			remoteURL:          "https://bitbucket.work.com/scm/CALC/calculator.git",
+			expectedPRURL:      "https://bitbucket.work.com/projects/CALC/repos/calculator/pull-requests?create&sourceBranch=feature/ui",
+			expectedPRInto:     "https://bitbucket.work.com/projects/CALC/repos/calculator/pull-requests?create&sourceBranch=feature/ui&targetBranch=main",
+			expectedCommit:     "https://bitbucket.work.com/projects/CALC/repos/calculator/commits/abc123",
+			expectedBranch:     "https://bitbucket.work.com/projects/CALC/repos/calculator/browse?at=feature/ui",
+			expectedTag:        "https://bitbucket.work.com/projects/CALC/repos/calculator/browse?at=refs/tags/v1.0",
+			expectedFile:       "https://bitbucket.work.com/projects/CALC/repos/calculator/browse/pkg/sum.go?at=feature/ui#12",
+			expectedCommitFile: "https://bitbucket.work.com/projects/CALC/repos/calculator/browse/pkg/sum.go?at=abc123#12",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			providers := getServiceProviders(&config.UserConfig{})
+			service := NewService(providers, s.provider, s.webDomain, s.webDomain)
+
+			assert.Equal(t, s.expectedPRURL, service.PullRequestURL(s.remoteURL, "feature/ui", ""))
+			assert.Equal(t, s.expectedPRInto, service.PullRequestURL(s.remoteURL, "feature/ui", "main"))
+			assert.Equal(t, s.expectedCommit, service.CommitURL(s.remoteURL, "abc123"))
+			assert.Equal(t, s.expectedBranch, service.BranchURL(s.remoteURL, "feature/ui"))
+			assert.Equal(t, s.expectedTag, service.TagURL(s.remoteURL, "v1.0"))
+			assert.Equal(t, s.expectedFile, service.FileAtLineURL(s.remoteURL, "feature/ui", "pkg/sum.go", 12))
+			assert.Equal(t, s.expectedCommitFile, service.CommitFileAtLineURL(s.remoteURL, "abc123", "pkg/sum.go", 12))
+		})
+	}
+}
+
+// TestGetServiceProviders is a function.
+func TestGetServiceProviders(t *testing.T) {
+	providers := getServiceProviders(&config.UserConfig{
+		ServiceProviders: map[string]config.ServiceProvider{
+			"github": {
+				CommitURL: "https://{{.webDomain}}/{{.owner}}/{{.repo}}/commits/{{.sha}}",
+			},
+			"custom": {
+				CommitURL: "http://{{.webDomain}}/{{.owner}}/{{.repo}}/c/{{.sha}}",
+			},
+		},
+	})
+
+	// overriding one template of a built-in provider keeps the others
+	github := NewService(providers, "github", "github.com", "github.com")
+	assert.Equal(t, "https://github.com/peter/calculator/commits/abc123", github.CommitURL("git@github.com:peter/calculator.git", "abc123"))
+	assert.Equal(t, "https://github.com/peter/calculator/tree/main", github.BranchURL("git@github.com:peter/calculator.git", "main"))
+
+	// templates a custom provider doesn't declare resolve to nothing
+	custom := NewService(providers, "custom", "git.custom.com", "web.custom.com")
+	assert.Equal(t, "http://web.custom.com/peter/calculator/c/abc123", custom.CommitURL("git@git.custom.com:peter/calculator.git", "abc123"))
+	assert.Equal(t, "", custom.BranchURL("git@git.custom.com:peter/calculator.git", "main"))
+
+	assert.Nil(t, NewService(providers, "noservice", "git.work.com", "git.work.com"))
+}
@@ -0,0 +1,169 @@
+package commands
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// We keep a history of the git-rebase-todo file so that edits made to it through
+// lazygit (e.g. moving a commit or changing its action) can be undone and redone
+// mid-rebase. The reflog can't help us here because editing the todo doesn't
+// touch any refs.
+// The history lives inside the rebase-merge directory so that git cleans it up
+// for us once the rebase is finished or aborted.
+
+// rebaseTodoSnapshot is the content of the todo at a point in time, along with
+// the content of the 'done' file so that we know whether git has moved on since
+type rebaseTodoSnapshot struct {
+	Todo string
+	Done string
+}
+
+type rebaseTodoHistory struct {
+	UndoStack []rebaseTodoSnapshot
+	RedoStack []rebaseTodoSnapshot
+}
+
+// push records the todo as it was before an edit. Any redo entries are
+// discarded because they no longer follow on from the current todo.
+func (h *rebaseTodoHistory) push(snapshot rebaseTodoSnapshot) {
+	h.UndoStack = append(h.UndoStack, snapshot)
+	h.RedoStack = nil
+}
+
+// undo returns the snapshot to restore, given the current state of the todo.
+// If git has progressed the rebase since the snapshot was taken, restoring it
+// would bring back todo items that have already been done, so in that case we
+// throw away the history.
+func (h *rebaseTodoHistory) undo(current rebaseTodoSnapshot) (rebaseTodoSnapshot, bool) {
+	return h.shift(&h.UndoStack, &h.RedoStack, current)
+}
+
+func (h *rebaseTodoHistory) redo(current rebaseTodoSnapshot) (rebaseTodoSnapshot, bool) {
+	return h.shift(&h.RedoStack, &h.UndoStack, current)
+}
+
+func (h *rebaseTodoHistory) shift(from *[]rebaseTodoSnapshot, to *[]rebaseTodoSnapshot, current rebaseTodoSnapshot) (rebaseTodoSnapshot, bool) {
+	if len(*from) == 0 {
+		return rebaseTodoSnapshot{}, false
+	}
+
+	snapshot := (*from)[len(*from)-1]
+	if snapshot.Done != current.Done {
+		h.UndoStack = nil
+		h.RedoStack = nil
+		return rebaseTodoSnapshot{}, false
+	}
+
+	*from = (*from)[:len(*from)-1]
+	*to = append(*to, current)
+
+	return snapshot, true
+}
+
+func (c *GitCommand) rebaseTodoPath() string {
+	return filepath.Join(c.DotGitDir, "rebase-merge/git-rebase-todo")
+}
+
+func (c *GitCommand) rebaseTodoHistoryPath() string {
+	return filepath.Join(c.DotGitDir, "rebase-merge/lazygit-todo-history.json")
+}
+
+func (c *GitCommand) currentRebaseTodoSnapshot() (rebaseTodoSnapshot, error) {
+	todo, err := ioutil.ReadFile(c.rebaseTodoPath())
+	if err != nil {
+		return rebaseTodoSnapshot{}, err
+	}
+
+	// the done file won't exist until git has actioned the first todo item
+	done, err := ioutil.ReadFile(filepath.Join(c.DotGitDir, "rebase-merge/done"))
+	if err != nil && !os.IsNotExist(err) {
+		return rebaseTodoSnapshot{}, err
+	}
+
+	return rebaseTodoSnapshot{Todo: string(todo), Done: string(done)}, nil
+}
+
+func (c *GitCommand) getRebaseTodoHistory() (*rebaseTodoHistory, error) {
+	history := &rebaseTodoHistory{}
+
+	content, err := ioutil.ReadFile(c.rebaseTodoHistoryPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return history, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(content, history); err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+func (c *GitCommand) saveRebaseTodoHistory(history *rebaseTodoHistory) error {
+	content, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(c.rebaseTodoHistoryPath(), content, 0644)
+}
+
+// SnapshotRebaseTodo records the current state of the git-rebase-todo file so
+// that the edit we're about to make can be undone
+func (c *GitCommand) SnapshotRebaseTodo() error {
+	history, err := c.getRebaseTodoHistory()
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := c.currentRebaseTodoSnapshot()
+	if err != nil {
+		return err
+	}
+
+	history.push(snapshot)
+
+	return c.saveRebaseTodoHistory(history)
+}
+
+// UndoRebaseTodoChange restores the git-rebase-todo file to how it was before
+// the last edit. It returns false if there was nothing to undo.
+func (c *GitCommand) UndoRebaseTodoChange() (bool, error) {
+	return c.restoreRebaseTodo((*rebaseTodoHistory).undo)
+}
+
+// RedoRebaseTodoChange re-applies the last undone edit to the git-rebase-todo
+// file. It returns false if there was nothing to redo.
+func (c *GitCommand) RedoRebaseTodoChange() (bool, error) {
+	return c.restoreRebaseTodo((*rebaseTodoHistory).redo)
+}
+
+func (c *GitCommand) restoreRebaseTodo(getSnapshot func(*rebaseTodoHistory, rebaseTodoSnapshot) (rebaseTodoSnapshot, bool)) (bool, error) {
+	history, err := c.getRebaseTodoHistory()
+	if err != nil {
+		return false, err
+	}
+
+	current, err := c.currentRebaseTodoSnapshot()
+	if err != nil {
+		return false, err
+	}
+
+	snapshot, ok := getSnapshot(history, current)
+	// we save the history even if there's nothing to restore, given it may have
+	// been cleared for being out of date
+	if err := c.saveRebaseTodoHistory(history); err != nil {
+		return false, err
+	}
+
+	if !ok {
+		return false, nil
+	}
+
+	return true, ioutil.WriteFile(c.rebaseTodoPath(), []byte(snapshot.Todo), 0644)
+}
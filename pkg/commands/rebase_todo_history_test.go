@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRebaseTodoHistory is a function.
+func TestRebaseTodoHistory(t *testing.T) {
+	type scenario struct {
+		testName string
+		test     func(*rebaseTodoHistory)
+	}
+
+	first := rebaseTodoSnapshot{Todo: "pick a\npick b\n"}
+	second := rebaseTodoSnapshot{Todo: "pick b\npick a\n"}
+	third := rebaseTodoSnapshot{Todo: "pick b\ndrop a\n"}
+
+	scenarios := []scenario{
+		{
+			"Nothing to undo",
+			func(history *rebaseTodoHistory) {
+				_, ok := history.undo(first)
+				assert.False(t, ok)
+			},
+		},
+		{
+			"Nothing to redo",
+			func(history *rebaseTodoHistory) {
+				history.push(first)
+				_, ok := history.redo(second)
+				assert.False(t, ok)
+			},
+		},
+		{
+			"Undo then redo",
+			func(history *rebaseTodoHistory) {
+				history.push(first)
+				history.push(second)
+
+				snapshot, ok := history.undo(third)
+				assert.True(t, ok)
+				assert.Equal(t, second, snapshot)
+
+				snapshot, ok = history.undo(second)
+				assert.True(t, ok)
+				assert.Equal(t, first, snapshot)
+
+				_, ok = history.undo(first)
+				assert.False(t, ok)
+
+				snapshot, ok = history.redo(first)
+				assert.True(t, ok)
+				assert.Equal(t, second, snapshot)
+
+				snapshot, ok = history.redo(second)
+				assert.True(t, ok)
+				assert.Equal(t, third, snapshot)
+			},
+		},
+		{
+			"A new edit clears the redo stack",
+			func(history *rebaseTodoHistory) {
+				history.push(first)
+				_, ok := history.undo(second)
+				assert.True(t, ok)
+
+				history.push(first)
+				_, ok = history.redo(third)
+				assert.False(t, ok)
+			},
+		},
+		{
+			"History is discarded once git has moved on",
+			func(history *rebaseTodoHistory) {
+				history.push(first)
+				history.push(second)
+
+				_, ok := history.undo(rebaseTodoSnapshot{Todo: "drop a\n", Done: "pick b\n"})
+				assert.False(t, ok)
+				assert.Empty(t, history.UndoStack)
+				assert.Empty(t, history.RedoStack)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			s.test(&rebaseTodoHistory{})
+		})
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"io/ioutil"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"strings"
 
 	"github.com/go-errors/errors"
@@ -144,12 +143,16 @@ func (c *GitCommand) AmendTo(sha string) error {
 
 // EditRebaseTodo sets the action at a given index in the git-rebase-todo file
 func (c *GitCommand) EditRebaseTodo(index int, action string) error {
-	fileName := filepath.Join(c.DotGitDir, "rebase-merge/git-rebase-todo")
+	fileName := c.rebaseTodoPath()
 	bytes, err := ioutil.ReadFile(fileName)
 	if err != nil {
 		return err
 	}
 
+	if err := c.SnapshotRebaseTodo(); err != nil {
+		return err
+	}
+
 	content := strings.Split(string(bytes), "\n")
 	commitCount := c.getTodoCommitCount(content)
 
@@ -176,12 +179,16 @@ func (c *GitCommand) getTodoCommitCount(content []string) int {
 
 // MoveTodoDown moves a rebase todo item down by one position
 func (c *GitCommand) MoveTodoDown(index int) error {
-	fileName := filepath.Join(c.DotGitDir, "rebase-merge/git-rebase-todo")
+	fileName := c.rebaseTodoPath()
 	bytes, err := ioutil.ReadFile(fileName)
 	if err != nil {
 		return err
 	}
 
+	if err := c.SnapshotRebaseTodo(); err != nil {
+		return err
+	}
+
 	content := strings.Split(string(bytes), "\n")
 	commitCount := c.getTodoCommitCount(content)
 	contentIndex := commitCount - 1 - index
@@ -234,6 +241,25 @@ func (c *GitCommand) BeginInteractiveRebaseForCommit(commits []*models.Commit, c
 	return nil
 }
 
+// SplitCommit starts an interactive rebase stopping at the given commit and
+// undoes the commit, leaving its changes in the working tree so that they can
+// be committed piece by piece. Once they're all committed you'll want to call
+// `c.GenericMergeOrRebaseAction("rebase", "continue")`
+func (c *GitCommand) SplitCommit(commits []*models.Commit, commitIndex int) error {
+	if err := c.BeginInteractiveRebaseForCommit(commits, commitIndex); err != nil {
+		return err
+	}
+
+	if err := c.ResetSoft("HEAD^"); err != nil {
+		return err
+	}
+
+	// the soft reset leaves everything staged, so we unstage it for the user to
+	// pick out the first commit's changes. --intent-to-add stops files added by
+	// the commit from becoming untracked.
+	return c.RunCommand("git reset --intent-to-add")
+}
+
 // RebaseBranch interactive rebases onto a branch
 func (c *GitCommand) RebaseBranch(branchName string) error {
 	cmd, err := c.PrepareInteractiveRebaseCommand(branchName, "", false)
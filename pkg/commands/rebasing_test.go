@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"testing"
 
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/test"
 	"github.com/stretchr/testify/assert"
 )
@@ -94,3 +95,63 @@ func TestGitCommandSkipEditorCommand(t *testing.T) {
 
 	_ = cmd.runSkipEditorCommand("true")
 }
+
+// TestGitCommandSplitCommit is a function.
+func TestGitCommandSplitCommit(t *testing.T) {
+	type scenario struct {
+		testName    string
+		commits     []*models.Commit
+		commitIndex int
+		command     func(string, ...string) *exec.Cmd
+		test        func(error)
+	}
+
+	scenarios := []scenario{
+		{
+			"returns error when splitting the first commit",
+			[]*models.Commit{{Name: "commit", Sha: "123456"}},
+			0,
+			nil,
+			func(err error) {
+				assert.Error(t, err)
+			},
+		},
+		{
+			"stops at the commit and undoes it",
+			[]*models.Commit{
+				{Name: "commit", Sha: "123456"},
+				{Name: "commit2", Sha: "abcdef"},
+			},
+			0,
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{
+					Expect:  "git rebase --interactive --autostash --keep-empty abcdef",
+					Replace: "echo",
+				},
+				{
+					Expect:  "git reset --soft HEAD^",
+					Replace: "echo",
+				},
+				{
+					Expect:  "git reset --intent-to-add",
+					Replace: "echo",
+				},
+			}),
+			func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	gitCmd := NewDummyGitCommand()
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd.OSCommand.Command = s.command
+			gitCmd.getGitConfigValue = func(string) (string, error) {
+				return "", nil
+			}
+			s.test(gitCmd.SplitCommit(s.commits, s.commitIndex))
+		})
+	}
+}
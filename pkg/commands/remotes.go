@@ -2,6 +2,8 @@ package commands
 
 import (
 	"fmt"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
 )
 
 func (c *GitCommand) AddRemote(name string, url string) error {
@@ -21,8 +23,15 @@ func (c *GitCommand) UpdateRemoteUrl(remoteName string, updatedUrl string) error
 }
 
 func (c *GitCommand) DeleteRemoteBranch(remoteName string, branchName string, promptUserForCredential func(string) string) error {
-	command := fmt.Sprintf("git push %s --delete %s", remoteName, branchName)
-	return c.OSCommand.DetectUnamePass(command, promptUserForCredential)
+	getOperation := func() (*models.Operation, error) {
+		sha, err := c.refSha(fmt.Sprintf("refs/remotes/%s/%s", remoteName, branchName))
+		return &models.Operation{Kind: models.OPERATION_DELETE_REMOTE_BRANCH, Remote: remoteName, Name: branchName, Sha: sha}, err
+	}
+
+	return c.withJournal(getOperation, func() error {
+		command := fmt.Sprintf("git push %s --delete %s", remoteName, branchName)
+		return c.OSCommand.DetectUnamePass(command, promptUserForCredential)
+	})
 }
 
 // CheckRemoteBranchExists Returns remote branch
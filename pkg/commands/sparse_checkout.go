@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// IsSparseCheckout tells us whether only part of the repo is checked out
+func (c *GitCommand) IsSparseCheckout() bool {
+	return c.getConfigBool("core.sparseCheckout")
+}
+
+// GetSparseCheckoutCone returns nil if the whole repo is checked out
+func (c *GitCommand) GetSparseCheckoutCone() (*models.SparseCheckoutCone, error) {
+	if !c.IsSparseCheckout() {
+		return nil, nil
+	}
+
+	patterns, err := c.GetSparseCheckoutPatterns()
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SparseCheckoutCone{Dirs: patterns}, nil
+}
+
+// GetDirectories returns every directory in HEAD, including the ones a sparse
+// checkout has left out of the working tree
+func (c *GitCommand) GetDirectories() ([]string, error) {
+	output, err := c.RunCommandWithOutput("git ls-tree -d -r -z --name-only HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := []string{}
+	for _, dir := range strings.Split(output, "\x00") {
+		if dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	return dirs, nil
+}
+
+// GetSparseCheckoutPatterns returns the directories in the sparse checkout's
+// cone, or the raw patterns if the repo isn't using cone mode
+func (c *GitCommand) GetSparseCheckoutPatterns() ([]string, error) {
+	output, err := c.RunCommandWithOutput("git sparse-checkout list")
+	if err != nil {
+		return nil, err
+	}
+
+	patterns := []string{}
+	for _, line := range utils.SplitLines(output) {
+		if strings.TrimSpace(line) != "" {
+			patterns = append(patterns, line)
+		}
+	}
+
+	return patterns, nil
+}
+
+// SparseCheckoutInit starts a sparse checkout in cone mode, which leaves only
+// the files at the root of the repo checked out
+func (c *GitCommand) SparseCheckoutInit() error {
+	return c.RunCommand("git sparse-checkout init --cone")
+}
+
+func (c *GitCommand) SparseCheckoutAdd(path string) error {
+	return c.RunCommand("git sparse-checkout add %s", c.OSCommand.Quote(path))
+}
+
+// SparseCheckoutSet replaces the sparse checkout's patterns with the given
+// ones, which is how we remove a directory from the cone
+func (c *GitCommand) SparseCheckoutSet(paths []string) error {
+	quotedPaths := make([]string, len(paths))
+	for i, path := range paths {
+		quotedPaths[i] = c.OSCommand.Quote(path)
+	}
+
+	return c.RunCommand("git sparse-checkout set %s", strings.Join(quotedPaths, " "))
+}
+
+// SparseCheckoutReapply updates the working tree to match the patterns again,
+// e.g. after a merge has checked out files outside of the cone
+func (c *GitCommand) SparseCheckoutReapply() error {
+	return c.RunCommand("git sparse-checkout reapply")
+}
+
+func (c *GitCommand) SparseCheckoutDisable() error {
+	return c.RunCommand("git sparse-checkout disable")
+}
@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/secureexec"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandIsSparseCheckout is a function.
+func TestGitCommandIsSparseCheckout(t *testing.T) {
+	type scenario struct {
+		testName    string
+		configValue string
+		expected    bool
+	}
+
+	scenarios := []scenario{
+		{"Sparse checkout", "true", true},
+		{"Sparse checkout written another way", "yes", true},
+		{"Sparse checkout disabled", "false", false},
+		{"Not configured", "", false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.getGitConfigValue = func(key string) (string, error) {
+				assert.Equal(t, "core.sparseCheckout", key)
+				return s.configValue, nil
+			}
+
+			assert.Equal(t, s.expected, gitCmd.IsSparseCheckout())
+		})
+	}
+}
+
+// TestGitCommandGetSparseCheckoutPatterns is a function.
+func TestGitCommandGetSparseCheckoutPatterns(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.Command = func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"sparse-checkout", "list"}, args)
+
+		return secureexec.Command("printf", "services/api\nlibs/shared\n")
+	}
+
+	patterns, err := gitCmd.GetSparseCheckoutPatterns()
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"services/api", "libs/shared"}, patterns)
+}
+
+// TestGitCommandGetDirectories is a function.
+func TestGitCommandGetDirectories(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.Command = func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"ls-tree", "-d", "-r", "-z", "--name-only", "HEAD"}, args)
+
+		return secureexec.Command("printf", "libs\\0libs/shared\\0dir with spaces\\0")
+	}
+
+	dirs, err := gitCmd.GetDirectories()
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"libs", "libs/shared", "dir with spaces"}, dirs)
+}
+
+// TestGitCommandSparseCheckoutSet is a function.
+func TestGitCommandSparseCheckoutSet(t *testing.T) {
+	type scenario struct {
+		testName string
+		paths    []string
+		expected []string
+	}
+
+	scenarios := []scenario{
+		{"Several directories", []string{"services/api", "libs/shared"}, []string{"sparse-checkout", "set", "services/api", "libs/shared"}},
+		{"No directories", []string{}, []string{"sparse-checkout", "set"}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.Command = func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				assert.EqualValues(t, s.expected, args)
+
+				return secureexec.Command("echo")
+			}
+
+			assert.NoError(t, gitCmd.SparseCheckoutSet(s.paths))
+		})
+	}
+}
@@ -1,10 +1,39 @@
 package commands
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/patch"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
 
 // StashDo modify stash
 func (c *GitCommand) StashDo(index int, method string) error {
-	return c.RunCommand("git stash %s stash@{%d}", method, index)
+	run := func() error {
+		return c.RunCommand("git stash %s stash@{%d}", method, index)
+	}
+
+	// applying or popping a stash entry can be undone by discarding changes
+	// but dropping it is for good unless we keep hold of its commit
+	if method != "drop" {
+		return run()
+	}
+
+	getOperation := func() (*models.Operation, error) {
+		sha, err := c.refSha(fmt.Sprintf("stash@{%d}", index))
+		if err != nil {
+			return nil, err
+		}
+		message, err := c.RunCommandWithOutput("git log --walk-reflogs -1 --format=%%gs stash@{%d}", index)
+		return &models.Operation{Kind: models.OPERATION_DROP_STASH, Name: strings.TrimSpace(message), Sha: sha}, err
+	}
+
+	return c.withJournal(getOperation, run)
 }
 
 // StashSave save stash
@@ -13,9 +42,20 @@ func (c *GitCommand) StashSave(message string) error {
 	return c.RunCommand("git stash save %s", c.OSCommand.Quote(message))
 }
 
+// StashStore adds the given stash commit to the stash list e.g. to bring back
+// a dropped stash entry
+func (c *GitCommand) StashStore(sha string, message string) error {
+	return c.RunCommand("git stash store -m %s %s", c.OSCommand.Quote(message), sha)
+}
+
 // GetStashEntryDiff stash diff
-func (c *GitCommand) ShowStashEntryCmdStr(index int) string {
-	return fmt.Sprintf("git stash show -p --stat --color=%s stash@{%d}", c.colorArg(), index)
+func (c *GitCommand) ShowStashEntryCmdStr(index int, plain bool) string {
+	colorArg := c.colorArg()
+	if plain {
+		colorArg = "never"
+	}
+
+	return fmt.Sprintf("git stash show -p --stat --color=%s stash@{%d}", colorArg, index)
 }
 
 // StashSaveStagedChanges stashes only the currently staged changes. This takes a few steps
@@ -56,3 +96,73 @@ func (c *GitCommand) StashSaveStagedChanges(message string) error {
 
 	return nil
 }
+
+// StashSaveFiles stashes only the changes to the given paths. Untracked files
+// within those paths are only picked up when includeUntracked is set.
+func (c *GitCommand) StashSaveFiles(message string, paths []string, includeUntracked bool) error {
+	untrackedArg := ""
+	if includeUntracked {
+		untrackedArg = " --include-untracked"
+	}
+
+	quotedPaths := make([]string, len(paths))
+	for i, path := range paths {
+		quotedPaths[i] = c.OSCommand.Quote(path)
+	}
+
+	return c.RunCommand("git stash push%s -m %s -- %s", untrackedArg, c.OSCommand.Quote(message), strings.Join(quotedPaths, " "))
+}
+
+// StashSaveLines stashes only the selected lines of a file's unstaged diff. We
+// do this by temporarily discarding every other change in the file, stashing
+// what's left, and then re-applying the other changes. This expects the file to
+// be tracked and to have no staged changes, otherwise those would end up in the
+// stash entry too.
+func (c *GitCommand) StashSaveLines(message string, fileName string, diff string, firstLineIdx int, lastLineIdx int) error {
+	p := patch.NewPatchModifier(c.Log, fileName, diff)
+
+	otherLineIndices := []int{}
+	for i := 0; i <= p.OriginalPatchLength(); i++ {
+		if i < firstLineIdx || i > lastLineIdx {
+			otherLineIndices = append(otherLineIndices, i)
+		}
+	}
+
+	discardOtherChangesPatch := p.ModifiedPatchForLines(otherLineIndices, true, false)
+	restoreOtherChangesPatch := p.ModifiedPatchForLines(otherLineIndices, false, false)
+
+	// the other changes will only be in this patch until we've put them back,
+	// so we keep it somewhere the user can find it if that fails
+	restorePatchPath := filepath.Join(c.DotGitDir, "lazygit", time.Now().Format("Jan _2 15.04.05.000000000")+".patch")
+	if restoreOtherChangesPatch != "" {
+		if err := c.OSCommand.CreateFileWithContent(restorePatchPath, restoreOtherChangesPatch); err != nil {
+			return err
+		}
+	}
+
+	if discardOtherChangesPatch != "" {
+		if err := c.ApplyPatch(discardOtherChangesPatch); err != nil {
+			return err
+		}
+	}
+
+	if err := c.StashSaveFiles(message, []string{fileName}, false); err != nil {
+		// put back what we discarded so that nothing is lost
+		if discardOtherChangesPatch != "" && c.ApplyPatch(discardOtherChangesPatch, "reverse") == nil {
+			_ = c.OSCommand.Remove(restorePatchPath)
+		}
+		return err
+	}
+
+	if restoreOtherChangesPatch != "" {
+		if err := c.ApplyPatchFile(restorePatchPath); err != nil {
+			return errors.New(utils.ResolvePlaceholderString(
+				c.Tr.RestoreUnstashedLinesErr,
+				map[string]string{"error": err.Error(), "path": restorePatchPath},
+			))
+		}
+		return c.OSCommand.Remove(restorePatchPath)
+	}
+
+	return nil
+}
@@ -1,7 +1,11 @@
 package commands
 
 import (
+	"io/ioutil"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/jesseduffield/lazygit/pkg/secureexec"
@@ -33,3 +37,163 @@ func TestGitCommandStashSave(t *testing.T) {
 
 	assert.NoError(t, gitCmd.StashSave("A stash message"))
 }
+
+// TestGitCommandStashSaveFiles is a function.
+func TestGitCommandStashSaveFiles(t *testing.T) {
+	type scenario struct {
+		testName         string
+		paths            []string
+		includeUntracked bool
+		expectedArgs     []string
+	}
+
+	scenarios := []scenario{
+		{
+			"Single file",
+			[]string{"file1"},
+			false,
+			[]string{"stash", "push", "-m", "A stash message", "--", "file1"},
+		},
+		{
+			"Renamed file",
+			[]string{"new name", "old name"},
+			false,
+			[]string{"stash", "push", "-m", "A stash message", "--", "new name", "old name"},
+		},
+		{
+			"Directory with untracked files",
+			[]string{"dir"},
+			true,
+			[]string{"stash", "push", "--include-untracked", "-m", "A stash message", "--", "dir"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.Command = func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				assert.EqualValues(t, s.expectedArgs, args)
+
+				return secureexec.Command("echo")
+			}
+
+			assert.NoError(t, gitCmd.StashSaveFiles("A stash message", s.paths, s.includeUntracked))
+		})
+	}
+}
+
+// TestGitCommandStashSaveLines is a function.
+func TestGitCommandStashSaveLines(t *testing.T) {
+	diff := `diff --git a/file1 b/file1
+index 3f4a5b6..7c8d9e0 100644
+--- a/file1
++++ b/file1
+@@ -1,4 +1,4 @@
+ one
+-two
++2
+ three
+-four
++4
+@@ -10,3 +10,3 @@
+ ten
+-eleven
++11
+ twelve
+`
+
+	type scenario struct {
+		testName     string
+		firstLineIdx int
+		lastLineIdx  int
+		restoreFails bool
+		expected     []string
+	}
+
+	scenarios := []scenario{
+		{
+			testName:     "Some lines of a hunk",
+			firstLineIdx: 6,
+			lastLineIdx:  7,
+			expected: []string{
+				"apply\n--- a/file1\n+++ b/file1\n@@ -1,4 +1,4 @@\n one\n 2\n three\n+four\n-4\n@@ -10,3 +10,3 @@\n ten\n+eleven\n-11\n twelve\n",
+				"stash push -m A stash message -- file1",
+				"apply\n--- a/file1\n+++ b/file1\n@@ -1,4 +1,4 @@\n one\n two\n three\n-four\n+4\n@@ -10,3 +10,3 @@\n ten\n-eleven\n+11\n twelve\n",
+			},
+		},
+		{
+			testName:     "Whole hunk",
+			firstLineIdx: 4,
+			lastLineIdx:  10,
+			expected: []string{
+				"apply\n--- a/file1\n+++ b/file1\n@@ -10,3 +10,3 @@\n ten\n+eleven\n-11\n twelve\n",
+				"stash push -m A stash message -- file1",
+				"apply\n--- a/file1\n+++ b/file1\n@@ -10,3 +10,3 @@\n ten\n-eleven\n+11\n twelve\n",
+			},
+		},
+		{
+			testName:     "Putting back the other lines fails",
+			firstLineIdx: 4,
+			lastLineIdx:  10,
+			restoreFails: true,
+			expected: []string{
+				"apply\n--- a/file1\n+++ b/file1\n@@ -10,3 +10,3 @@\n ten\n+eleven\n-11\n twelve\n",
+				"stash push -m A stash message -- file1",
+				"apply\n--- a/file1\n+++ b/file1\n@@ -10,3 +10,3 @@\n ten\n-eleven\n+11\n twelve\n",
+			},
+		},
+		{
+			testName:     "Every line",
+			firstLineIdx: 0,
+			lastLineIdx:  15,
+			expected: []string{
+				"stash push -m A stash message -- file1",
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			dotGitDir, err := ioutil.TempDir("", "lazygit-stash-lines")
+			assert.NoError(t, err)
+			defer os.RemoveAll(dotGitDir)
+
+			invocations := []string{}
+			gitCmd := NewDummyGitCommand()
+			gitCmd.DotGitDir = dotGitDir
+			gitCmd.OSCommand.Command = func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				if args[0] != "apply" {
+					invocations = append(invocations, strings.Join(args, " "))
+					return secureexec.Command("echo")
+				}
+
+				// for a patch, we want to see what's in it rather than the path to it
+				path := args[len(args)-1]
+				content, err := ioutil.ReadFile(path)
+				assert.NoError(t, err)
+				invocations = append(invocations, strings.Join(args[:len(args)-1], " ")+"\n"+string(content))
+
+				if s.restoreFails && strings.HasPrefix(path, dotGitDir) {
+					return secureexec.Command("false")
+				}
+				return secureexec.Command("echo")
+			}
+
+			err = gitCmd.StashSaveLines("A stash message", "file1", diff, s.firstLineIdx, s.lastLineIdx)
+			assert.EqualValues(t, s.expected, invocations)
+
+			// the patch for putting back the other lines is only kept if we need it
+			leftoverPatches, _ := filepath.Glob(filepath.Join(dotGitDir, "lazygit", "*.patch"))
+			if s.restoreFails {
+				assert.Error(t, err)
+				assert.Len(t, leftoverPatches, 1)
+				assert.Contains(t, err.Error(), leftoverPatches[0])
+			} else {
+				assert.NoError(t, err)
+				assert.Empty(t, leftoverPatches)
+			}
+		})
+	}
+}
@@ -2,11 +2,27 @@ package commands
 
 import (
 	"fmt"
+	"strings"
 	"sync"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
 )
 
-// Push pushes to a branch
-func (c *GitCommand) Push(branchName string, force bool, upstream string, args string, promptUserForCredential func(string) string) error {
+// ForceWithLease is where we expect a branch to be on the remote when we force
+// push over it. If it's somewhere else, someone has pushed commits we haven't
+// seen and git refuses to overwrite them.
+type ForceWithLease struct {
+	// the branch on the remote e.g. 'refs/heads/master'
+	RefName     string
+	ExpectedSha string
+	// the ref we got ExpectedSha from e.g. 'refs/remotes/origin/master'
+	RemoteTrackingRef string
+}
+
+// Push pushes to a branch. When forcing without a lease, git compares the remote
+// branch against our remote-tracking ref, which a fetch may have moved onto
+// commits we haven't seen.
+func (c *GitCommand) Push(branchName string, force bool, lease *ForceWithLease, upstream string, args string, promptUserForCredential func(string) string) error {
 	followTagsFlag := "--follow-tags"
 	if c.GetConfigValue("push.followTags") == "false" {
 		followTagsFlag = ""
@@ -15,6 +31,9 @@ func (c *GitCommand) Push(branchName string, force bool, upstream string, args s
 	forceFlag := ""
 	if force {
 		forceFlag = "--force-with-lease"
+		if lease != nil {
+			forceFlag = c.OSCommand.Quote(fmt.Sprintf("--force-with-lease=%s:%s", lease.RefName, lease.ExpectedSha))
+		}
 	}
 
 	setUpstreamArg := ""
@@ -22,10 +41,45 @@ func (c *GitCommand) Push(branchName string, force bool, upstream string, args s
 		setUpstreamArg = "--set-upstream " + upstream
 	}
 
-	cmd := fmt.Sprintf("git push %s %s %s %s", followTagsFlag, forceFlag, setUpstreamArg, args)
+	cmd := fmt.Sprintf("git push --progress %s %s %s %s", followTagsFlag, forceFlag, setUpstreamArg, args)
 	return c.OSCommand.DetectUnamePass(cmd, promptUserForCredential)
 }
 
+// GetForceWithLease returns where the remote copy of the given branch is
+// according to its remote-tracking ref, or nil if the branch hasn't been pushed
+func (c *GitCommand) GetForceWithLease(branch *models.Branch) (*ForceWithLease, error) {
+	var refName, remoteTrackingRef string
+	if branch.HasPushRemote() {
+		// we push to the branch of the same name on the push remote
+		refName = "refs/heads/" + branch.Name
+		remoteTrackingRef = fmt.Sprintf("refs/remotes/%s/%s", branch.PushRemote, branch.Name)
+	} else if branch.IsTrackingRemote() {
+		refName = c.GetConfigValue(fmt.Sprintf("branch.%s.merge", branch.Name))
+		remoteTrackingRef = "refs/remotes/" + branch.UpstreamName
+	}
+
+	if refName == "" {
+		return nil, nil
+	}
+
+	output, err := c.RunCommandWithOutput("git rev-parse --verify --quiet %s", c.OSCommand.Quote(remoteTrackingRef))
+	if err != nil {
+		// we've got nothing to protect if the branch isn't on the remote yet
+		return nil, nil
+	}
+
+	return &ForceWithLease{
+		RefName:           refName,
+		ExpectedSha:       strings.TrimSpace(output),
+		RemoteTrackingRef: remoteTrackingRef,
+	}, nil
+}
+
+// IsAncestor tells us whether ancestor is reachable from ref
+func (c *GitCommand) IsAncestor(ancestor string, ref string) bool {
+	return c.OSCommand.RunCommand("git merge-base --is-ancestor %s %s", ancestor, ref) == nil
+}
+
 type FetchOptions struct {
 	PromptUserForCredential func(string) string
 	RemoteName              string
@@ -34,7 +88,7 @@ type FetchOptions struct {
 
 // Fetch fetch git repo
 func (c *GitCommand) Fetch(opts FetchOptions) error {
-	command := "git fetch"
+	command := "git fetch --progress"
 
 	if opts.RemoteName != "" {
 		command = fmt.Sprintf("%s %s", command, opts.RemoteName)
@@ -47,20 +101,26 @@ func (c *GitCommand) Fetch(opts FetchOptions) error {
 		if opts.PromptUserForCredential != nil {
 			return opts.PromptUserForCredential(question)
 		}
-		return "\n"
+		return ""
 	})
 }
 
 func (c *GitCommand) FastForward(branchName string, remoteName string, remoteBranchName string, promptUserForCredential func(string) string) error {
-	command := fmt.Sprintf("git fetch %s %s:%s", remoteName, remoteBranchName, branchName)
+	command := fmt.Sprintf("git fetch --progress %s %s:%s", remoteName, remoteBranchName, branchName)
 	return c.OSCommand.DetectUnamePass(command, promptUserForCredential)
 }
 
 func (c *GitCommand) FetchRemote(remoteName string, promptUserForCredential func(string) string) error {
-	command := fmt.Sprintf("git fetch %s", remoteName)
+	command := fmt.Sprintf("git fetch --progress %s", remoteName)
 	return c.OSCommand.DetectUnamePass(command, promptUserForCredential)
 }
 
+// FetchAllRemotes fetches every remote, pruning remote branches that have been
+// deleted from their remote
+func (c *GitCommand) FetchAllRemotes(promptUserForCredential func(string) string) error {
+	return c.OSCommand.DetectUnamePass("git fetch --progress --all --prune", promptUserForCredential)
+}
+
 func (c *GitCommand) GetPullMode(mode string) string {
 	if mode != "auto" {
 		return mode
@@ -4,6 +4,7 @@ import (
 	"os/exec"
 	"testing"
 
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/secureexec"
 	"github.com/stretchr/testify/assert"
 )
@@ -15,6 +16,7 @@ func TestGitCommandPush(t *testing.T) {
 		getGitConfigValue func(string) (string, error)
 		command           func(string, ...string) *exec.Cmd
 		forcePush         bool
+		lease             *ForceWithLease
 		test              func(error)
 	}
 
@@ -26,11 +28,12 @@ func TestGitCommandPush(t *testing.T) {
 			},
 			func(cmd string, args ...string) *exec.Cmd {
 				assert.EqualValues(t, "git", cmd)
-				assert.EqualValues(t, []string{"push", "--follow-tags"}, args)
+				assert.EqualValues(t, []string{"push", "--progress", "--follow-tags"}, args)
 
 				return secureexec.Command("echo")
 			},
 			false,
+			nil,
 			func(err error) {
 				assert.NoError(t, err)
 			},
@@ -42,11 +45,29 @@ func TestGitCommandPush(t *testing.T) {
 			},
 			func(cmd string, args ...string) *exec.Cmd {
 				assert.EqualValues(t, "git", cmd)
-				assert.EqualValues(t, []string{"push", "--follow-tags", "--force-with-lease"}, args)
+				assert.EqualValues(t, []string{"push", "--progress", "--follow-tags", "--force-with-lease"}, args)
 
 				return secureexec.Command("echo")
 			},
 			true,
+			nil,
+			func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"Push with force enabled and a lease, follow-tags on",
+			func(string) (string, error) {
+				return "", nil
+			},
+			func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				assert.EqualValues(t, []string{"push", "--progress", "--follow-tags", "--force-with-lease=refs/heads/test:b8e35f6a4f7b1b9e7f2a5f3d6c1a0e9d8c7b6a5f"}, args)
+
+				return secureexec.Command("echo")
+			},
+			true,
+			&ForceWithLease{RefName: "refs/heads/test", ExpectedSha: "b8e35f6a4f7b1b9e7f2a5f3d6c1a0e9d8c7b6a5f"},
 			func(err error) {
 				assert.NoError(t, err)
 			},
@@ -58,11 +79,12 @@ func TestGitCommandPush(t *testing.T) {
 			},
 			func(cmd string, args ...string) *exec.Cmd {
 				assert.EqualValues(t, "git", cmd)
-				assert.EqualValues(t, []string{"push"}, args)
+				assert.EqualValues(t, []string{"push", "--progress"}, args)
 
 				return secureexec.Command("echo")
 			},
 			false,
+			nil,
 			func(err error) {
 				assert.NoError(t, err)
 			},
@@ -74,10 +96,11 @@ func TestGitCommandPush(t *testing.T) {
 			},
 			func(cmd string, args ...string) *exec.Cmd {
 				assert.EqualValues(t, "git", cmd)
-				assert.EqualValues(t, []string{"push", "--follow-tags"}, args)
+				assert.EqualValues(t, []string{"push", "--progress", "--follow-tags"}, args)
 				return secureexec.Command("test")
 			},
 			false,
+			nil,
 			func(err error) {
 				assert.Error(t, err)
 			},
@@ -89,14 +112,79 @@ func TestGitCommandPush(t *testing.T) {
 			gitCmd := NewDummyGitCommand()
 			gitCmd.OSCommand.Command = s.command
 			gitCmd.getGitConfigValue = s.getGitConfigValue
-			err := gitCmd.Push("test", s.forcePush, "", "", func(passOrUname string) string {
-				return "\n"
+			err := gitCmd.Push("test", s.forcePush, s.lease, "", "", func(prompt string) string {
+				return ""
 			})
 			s.test(err)
 		})
 	}
 }
 
+// TestGitCommandGetForceWithLease is a function.
+func TestGitCommandGetForceWithLease(t *testing.T) {
+	type scenario struct {
+		testName         string
+		branch           *models.Branch
+		mergeConfigValue string
+		expectedRevParse string
+		revParseOutput   string
+		expectedLease    *ForceWithLease
+	}
+
+	scenarios := []scenario{
+		{
+			testName:         "Branch tracking a differently named upstream",
+			branch:           &models.Branch{Name: "mine", UpstreamName: "origin/main", Pushables: "1", Pullables: "0"},
+			mergeConfigValue: "refs/heads/main",
+			expectedRevParse: "refs/remotes/origin/main",
+			revParseOutput:   "a1b2c3\n",
+			expectedLease:    &ForceWithLease{RefName: "refs/heads/main", ExpectedSha: "a1b2c3", RemoteTrackingRef: "refs/remotes/origin/main"},
+		},
+		{
+			testName:         "Branch with a push remote",
+			branch:           &models.Branch{Name: "feature", UpstreamName: "upstream/master", Pushables: "0", Pullables: "0", PushRemote: "origin"},
+			expectedRevParse: "refs/remotes/origin/feature",
+			revParseOutput:   "d4e5f6\n",
+			expectedLease:    &ForceWithLease{RefName: "refs/heads/feature", ExpectedSha: "d4e5f6", RemoteTrackingRef: "refs/remotes/origin/feature"},
+		},
+		{
+			testName:         "Push remote without the branch on it yet",
+			branch:           &models.Branch{Name: "feature", Pushables: "?", Pullables: "?", PushRemote: "origin"},
+			expectedRevParse: "refs/remotes/origin/feature",
+			expectedLease:    nil,
+		},
+		{
+			testName:      "Branch without an upstream",
+			branch:        &models.Branch{Name: "feature", Pushables: "?", Pullables: "?"},
+			expectedLease: nil,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.getGitConfigValue = func(key string) (string, error) {
+				assert.EqualValues(t, "branch."+s.branch.Name+".merge", key)
+				return s.mergeConfigValue, nil
+			}
+			gitCmd.OSCommand.Command = func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				assert.EqualValues(t, []string{"rev-parse", "--verify", "--quiet", s.expectedRevParse}, args)
+
+				if s.revParseOutput == "" {
+					return secureexec.Command("false")
+				}
+				return secureexec.Command("printf", s.revParseOutput)
+			}
+
+			lease, err := gitCmd.GetForceWithLease(s.branch)
+			assert.NoError(t, err)
+			assert.EqualValues(t, s.expectedLease, lease)
+		})
+	}
+}
+
 type getPullModeScenario struct {
 	testName              string
 	getGitConfigValueMock func(string) (string, error)
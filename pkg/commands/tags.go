@@ -1,13 +1,47 @@
 package commands
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+)
 
 func (c *GitCommand) CreateLightweightTag(tagName string, commitSha string) error {
 	return c.RunCommand("git tag %s %s", tagName, commitSha)
 }
 
+// CreateAnnotatedTagCmdStr returns the command rather than running it, so that if
+// the tag is to be signed we can run it in a subprocess for the user to enter
+// their gpg passphrase
+func (c *GitCommand) CreateAnnotatedTagCmdStr(tagName string, commitSha string, message string, sign bool) string {
+	flag := "--annotate"
+	if sign {
+		flag = "--sign"
+	}
+
+	lineArgs := ""
+	for _, line := range strings.Split(message, "\n") {
+		lineArgs += fmt.Sprintf(" -m %s", c.OSCommand.Quote(line))
+	}
+
+	commitArg := ""
+	if commitSha != "" {
+		commitArg = " " + commitSha
+	}
+
+	return fmt.Sprintf("git tag %s %s%s%s", flag, tagName, lineArgs, commitArg)
+}
+
 func (c *GitCommand) DeleteTag(tagName string) error {
-	return c.RunCommand("git tag -d %s", tagName)
+	getOperation := func() (*models.Operation, error) {
+		sha, err := c.refSha("refs/tags/" + tagName)
+		return &models.Operation{Kind: models.OPERATION_DELETE_TAG, Name: tagName, Sha: sha}, err
+	}
+
+	return c.withJournal(getOperation, func() error {
+		return c.RunCommand("git tag -d %s", tagName)
+	})
 }
 
 func (c *GitCommand) PushTag(remoteName string, tagName string, promptUserForCredential func(string) string) error {
@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandCreateAnnotatedTagCmdStr is a function.
+func TestGitCommandCreateAnnotatedTagCmdStr(t *testing.T) {
+	type scenario struct {
+		testName  string
+		commitSha string
+		message   string
+		sign      bool
+		expected  string
+	}
+
+	scenarios := []scenario{
+		{
+			"Annotated tag on HEAD",
+			"",
+			"release",
+			false,
+			`git tag --annotate v1.0.0 -m "release"`,
+		},
+		{
+			"Signed tag on a commit with a multiline message",
+			"0e8b4d5c",
+			"release\n\nwith notes",
+			true,
+			`git tag --sign v1.0.0 -m "release" -m "" -m "with notes" 0e8b4d5c`,
+		},
+	}
+
+	gitCmd := NewDummyGitCommand()
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.Equal(t, s.expected, gitCmd.CreateAnnotatedTagCmdStr("v1.0.0", s.commitSha, s.message, s.sign))
+		})
+	}
+}
+
+// TestParseTags is a function.
+func TestParseTags(t *testing.T) {
+	output := "v1.1.0\x00tag\x00a1b2c3\x000e8b4d5c\x00Jesse Duffield\x001620000000\x00signed\x00release v1.1.0\x00notes line 1\nnotes line 2\n\x00\n" +
+		"v1.0.1\x00commit\x001b2c3d4e\x00\x00\x001610000000\x00\x00commit subject\x00\x00\n" +
+		"v1.0.0\x00tag\x00d4e5f6\x005a6b7c8d\x00Jesse Duffield\x001600000000\x00\x00release v1.0.0\x00\x00\n"
+
+	assert.EqualValues(t, []*models.Tag{
+		{
+			Name:          "v1.1.0",
+			Sha:           "0e8b4d5c",
+			Annotated:     true,
+			Signed:        true,
+			Tagger:        "Jesse Duffield",
+			UnixTimestamp: 1620000000,
+			Message:       "release v1.1.0\n\nnotes line 1\nnotes line 2",
+		},
+		{
+			Name:          "v1.0.1",
+			Sha:           "1b2c3d4e",
+			UnixTimestamp: 1610000000,
+		},
+		{
+			Name:          "v1.0.0",
+			Sha:           "5a6b7c8d",
+			Annotated:     true,
+			Tagger:        "Jesse Duffield",
+			UnixTimestamp: 1600000000,
+			Message:       "release v1.0.0",
+		},
+	}, parseTags(output))
+}
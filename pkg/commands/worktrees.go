@@ -0,0 +1,18 @@
+package commands
+
+func (c *GitCommand) NewWorktree(path string, branchName string) error {
+	return c.RunCommand("git worktree add %s %s", c.OSCommand.Quote(path), c.OSCommand.Quote(branchName))
+}
+
+func (c *GitCommand) RemoveWorktree(path string, force bool) error {
+	forceArg := ""
+	if force {
+		forceArg = " --force"
+	}
+
+	return c.RunCommand("git worktree remove%s %s", forceArg, c.OSCommand.Quote(path))
+}
+
+func (c *GitCommand) PruneWorktrees() error {
+	return c.RunCommand("git worktree prune")
+}
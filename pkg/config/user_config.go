@@ -15,7 +15,10 @@ type UserConfig struct {
 	DisableStartupPopups bool              `yaml:"disableStartupPopups"`
 	CustomCommands       []CustomCommand   `yaml:"customCommands"`
 	Services             map[string]string `yaml:"services"`
-	NotARepository       string            `yaml:"notARepository"`
+	// ServiceProviders lets you declare your own git services, or override
+	// the URLs of a built-in one, for use in Services
+	ServiceProviders map[string]ServiceProvider `yaml:"serviceProviders"`
+	NotARepository   string                     `yaml:"notARepository"`
 }
 
 type RefresherConfig struct {
@@ -43,12 +46,21 @@ type GuiConfig struct {
 }
 
 type ThemeConfig struct {
-	LightTheme           bool     `yaml:"lightTheme"`
-	ActiveBorderColor    []string `yaml:"activeBorderColor"`
-	InactiveBorderColor  []string `yaml:"inactiveBorderColor"`
-	OptionsTextColor     []string `yaml:"optionsTextColor"`
-	SelectedLineBgColor  []string `yaml:"selectedLineBgColor"`
-	SelectedRangeBgColor []string `yaml:"selectedRangeBgColor"`
+	LightTheme           bool                     `yaml:"lightTheme"`
+	ActiveBorderColor    []string                 `yaml:"activeBorderColor"`
+	InactiveBorderColor  []string                 `yaml:"inactiveBorderColor"`
+	OptionsTextColor     []string                 `yaml:"optionsTextColor"`
+	SelectedLineBgColor  []string                 `yaml:"selectedLineBgColor"`
+	SelectedRangeBgColor []string                 `yaml:"selectedRangeBgColor"`
+	SyntaxHighlighting   SyntaxHighlightingConfig `yaml:"syntaxHighlighting"`
+}
+
+type SyntaxHighlightingConfig struct {
+	Enabled      bool     `yaml:"enabled"`
+	KeywordColor []string `yaml:"keywordColor"`
+	StringColor  []string `yaml:"stringColor"`
+	CommentColor []string `yaml:"commentColor"`
+	NumberColor  []string `yaml:"numberColor"`
 }
 
 type CommitLengthConfig struct {
@@ -66,6 +78,7 @@ type GitConfig struct {
 	OverrideGpg         bool                          `yaml:"overrideGpg"`
 	DisableForcePushing bool                          `yaml:"disableForcePushing"`
 	CommitPrefixes      map[string]CommitPrefixConfig `yaml:"commitPrefixes"`
+	CommitValidation    CommitValidationConfig        `yaml:"commitValidation"`
 	ParseEmoji          bool                          `yaml:"parseEmoji"`
 }
 
@@ -89,21 +102,37 @@ type CommitPrefixConfig struct {
 	Replace string `yaml:"replace"`
 }
 
+// CommitValidationConfig holds the rules a commit message's subject is checked
+// against before committing
+type CommitValidationConfig struct {
+	// MaxSubjectLength of 0 means there's no limit
+	MaxSubjectLength int `yaml:"maxSubjectLength"`
+	// Conventional enforces the 'type(scope): description' format of
+	// Conventional Commits
+	Conventional bool     `yaml:"conventional"`
+	Types        []string `yaml:"types"`
+	// an empty list of scopes means any scope is allowed
+	Scopes       []string `yaml:"scopes"`
+	RequireScope bool     `yaml:"requireScope"`
+}
+
 type UpdateConfig struct {
 	Method string `yaml:"method"`
 	Days   int64  `yaml:"days"`
 }
 
 type KeybindingConfig struct {
-	Universal   KeybindingUniversalConfig   `yaml:"universal"`
-	Status      KeybindingStatusConfig      `yaml:"status"`
-	Files       KeybindingFilesConfig       `yaml:"files"`
-	Branches    KeybindingBranchesConfig    `yaml:"branches"`
-	Commits     KeybindingCommitsConfig     `yaml:"commits"`
-	Stash       KeybindingStashConfig       `yaml:"stash"`
-	CommitFiles KeybindingCommitFilesConfig `yaml:"commitFiles"`
-	Main        KeybindingMainConfig        `yaml:"main"`
-	Submodules  KeybindingSubmodulesConfig  `yaml:"submodules"`
+	Universal     KeybindingUniversalConfig     `yaml:"universal"`
+	Status        KeybindingStatusConfig        `yaml:"status"`
+	Files         KeybindingFilesConfig         `yaml:"files"`
+	Branches      KeybindingBranchesConfig      `yaml:"branches"`
+	Commits       KeybindingCommitsConfig       `yaml:"commits"`
+	Stash         KeybindingStashConfig         `yaml:"stash"`
+	CommitFiles   KeybindingCommitFilesConfig   `yaml:"commitFiles"`
+	Main          KeybindingMainConfig          `yaml:"main"`
+	Submodules    KeybindingSubmodulesConfig    `yaml:"submodules"`
+	Worktrees     KeybindingWorktreesConfig     `yaml:"worktrees"`
+	CommitMessage KeybindingCommitMessageConfig `yaml:"commitMessage"`
 }
 
 // damn looks like we have some inconsistencies here with -alt and -alt1
@@ -140,6 +169,7 @@ type KeybindingUniversalConfig struct {
 	New                          string `yaml:"new"`
 	Edit                         string `yaml:"edit"`
 	OpenFile                     string `yaml:"openFile"`
+	OpenInBrowser                string `yaml:"openInBrowser"`
 	ScrollUpMain                 string `yaml:"scrollUpMain"`
 	ScrollDownMain               string `yaml:"scrollDownMain"`
 	ScrollUpMainAlt1             string `yaml:"scrollUpMain-alt1"`
@@ -167,6 +197,10 @@ type KeybindingUniversalConfig struct {
 	AppendNewline                string `yaml:"appendNewline"`
 	ExtrasMenu                   string `yaml:"extrasMenu"`
 	ToggleWhitespaceInDiffView   string `yaml:"toggleWhitespaceInDiffView"`
+	ToggleSideBySideDiff         string `yaml:"toggleSideBySideDiff"`
+	Blame                        string `yaml:"blame"`
+	ViewOperationLog             string `yaml:"viewOperationLog"`
+	CancelRemoteOperation        string `yaml:"cancelRemoteOperation"`
 }
 
 type KeybindingStatusConfig struct {
@@ -176,19 +210,21 @@ type KeybindingStatusConfig struct {
 }
 
 type KeybindingFilesConfig struct {
-	CommitChanges            string `yaml:"commitChanges"`
-	CommitChangesWithoutHook string `yaml:"commitChangesWithoutHook"`
-	AmendLastCommit          string `yaml:"amendLastCommit"`
-	CommitChangesWithEditor  string `yaml:"commitChangesWithEditor"`
-	IgnoreFile               string `yaml:"ignoreFile"`
-	RefreshFiles             string `yaml:"refreshFiles"`
-	StashAllChanges          string `yaml:"stashAllChanges"`
-	ViewStashOptions         string `yaml:"viewStashOptions"`
-	ToggleStagedAll          string `yaml:"toggleStagedAll"`
-	ViewResetOptions         string `yaml:"viewResetOptions"`
-	Fetch                    string `yaml:"fetch"`
-	ToggleTreeView           string `yaml:"toggleTreeView"`
-	OpenMergeTool            string `yaml:"openMergeTool"`
+	CommitChanges             string `yaml:"commitChanges"`
+	CommitChangesWithoutHook  string `yaml:"commitChangesWithoutHook"`
+	AmendLastCommit           string `yaml:"amendLastCommit"`
+	CommitChangesWithEditor   string `yaml:"commitChangesWithEditor"`
+	IgnoreFile                string `yaml:"ignoreFile"`
+	RefreshFiles              string `yaml:"refreshFiles"`
+	StashAllChanges           string `yaml:"stashAllChanges"`
+	ViewStashOptions          string `yaml:"viewStashOptions"`
+	ToggleStagedAll           string `yaml:"toggleStagedAll"`
+	ViewResetOptions          string `yaml:"viewResetOptions"`
+	Fetch                     string `yaml:"fetch"`
+	ToggleTreeView            string `yaml:"toggleTreeView"`
+	OpenMergeTool             string `yaml:"openMergeTool"`
+	ViewLfsOptions            string `yaml:"viewLfsOptions"`
+	ViewSparseCheckoutOptions string `yaml:"viewSparseCheckoutOptions"`
 }
 
 type KeybindingBranchesConfig struct {
@@ -203,8 +239,12 @@ type KeybindingBranchesConfig struct {
 	ViewGitFlowOptions     string `yaml:"viewGitFlowOptions"`
 	FastForward            string `yaml:"fastForward"`
 	PushTag                string `yaml:"pushTag"`
+	CreateAnnotatedTag     string `yaml:"createAnnotatedTag"`
 	SetUpstream            string `yaml:"setUpstream"`
 	FetchRemote            string `yaml:"fetchRemote"`
+	FetchAllRemotes        string `yaml:"fetchAllRemotes"`
+	SetPushRemote          string `yaml:"setPushRemote"`
+	CreateWorktree         string `yaml:"createWorktree"`
 }
 
 type KeybindingCommitsConfig struct {
@@ -224,9 +264,12 @@ type KeybindingCommitsConfig struct {
 	CherryPickCopyRange          string `yaml:"cherryPickCopyRange"`
 	PasteCommits                 string `yaml:"pasteCommits"`
 	TagCommit                    string `yaml:"tagCommit"`
+	AnnotatedTagCommit           string `yaml:"annotatedTagCommit"`
 	CheckoutCommit               string `yaml:"checkoutCommit"`
 	ResetCherryPick              string `yaml:"resetCherryPick"`
 	CopyCommitMessageToClipboard string `yaml:"copyCommitMessageToClipboard"`
+	ViewBisectOptions            string `yaml:"viewBisectOptions"`
+	SplitCommit                  string `yaml:"splitCommit"`
 }
 
 type KeybindingStashConfig struct {
@@ -241,7 +284,11 @@ type KeybindingMainConfig struct {
 	ToggleDragSelect    string `yaml:"toggleDragSelect"`
 	ToggleDragSelectAlt string `yaml:"toggleDragSelect-alt"`
 	ToggleSelectHunk    string `yaml:"toggleSelectHunk"`
+	ToggleWordDiff      string `yaml:"toggleWordDiff"`
 	PickBothHunks       string `yaml:"pickBothHunks"`
+	PickBaseHunk        string `yaml:"pickBaseHunk"`
+	PickAllHunks        string `yaml:"pickAllHunks"`
+	BlameParent         string `yaml:"blameParent"`
 }
 
 type KeybindingSubmodulesConfig struct {
@@ -250,6 +297,14 @@ type KeybindingSubmodulesConfig struct {
 	BulkMenu string `yaml:"bulkMenu"`
 }
 
+type KeybindingWorktreesConfig struct {
+	Prune string `yaml:"prune"`
+}
+
+type KeybindingCommitMessageConfig struct {
+	AddTrailer string `yaml:"addTrailer"`
+}
+
 // OSConfig contains config on the level of the os
 type OSConfig struct {
 	// EditCommand is the command for editing a file
@@ -272,6 +327,24 @@ type CustomCommand struct {
 	Description string                `yaml:"description"`
 }
 
+// ServiceProvider holds the URL templates of a git service. Any template left
+// blank falls back to the built-in provider of the same name, if there is one.
+type ServiceProvider struct {
+	PullRequestURLIntoDefaultBranch string `yaml:"pullRequestURLIntoDefaultBranch"`
+	PullRequestURLIntoTargetBranch  string `yaml:"pullRequestURLIntoTargetBranch"`
+	CommitURL                       string `yaml:"commitURL"`
+	BranchURL                       string `yaml:"branchURL"`
+	TagURL                          string `yaml:"tagURL"`
+	FileAtLineURL                   string `yaml:"fileAtLineURL"`
+	// CommitFileAtLineURL is for viewing a file at a commit rather than a
+	// branch, for services that tell the two apart. Defaults to FileAtLineURL.
+	CommitFileAtLineURL string `yaml:"commitFileAtLineURL"`
+	// RemoteURLPatterns are regexes with named groups (e.g. (?P<owner>...))
+	// for pulling the owner and repo out of the remote URL. If none match we
+	// fall back to the default parsing.
+	RemoteURLPatterns []string `yaml:"remoteURLPatterns"`
+}
+
 type CustomCommandPrompt struct {
 	Type  string `yaml:"type"` // one of 'input' and 'menu'
 	Title string `yaml:"title"`
@@ -312,6 +385,13 @@ func GetDefaultConfig() *UserConfig {
 				OptionsTextColor:     []string{"blue"},
 				SelectedLineBgColor:  []string{"default"},
 				SelectedRangeBgColor: []string{"blue"},
+				SyntaxHighlighting: SyntaxHighlightingConfig{
+					Enabled:      false,
+					KeywordColor: []string{"magenta"},
+					StringColor:  []string{"yellow"},
+					CommentColor: []string{"blue"},
+					NumberColor:  []string{"cyan"},
+				},
 			},
 			CommitLength:             CommitLengthConfig{Show: true},
 			SkipNoStagedFilesWarning: false,
@@ -339,7 +419,14 @@ func GetDefaultConfig() *UserConfig {
 			AllBranchesLogCmd:   "git log --graph --all --color=always --abbrev-commit --decorate --date=relative  --pretty=medium",
 			DisableForcePushing: false,
 			CommitPrefixes:      map[string]CommitPrefixConfig(nil),
-			ParseEmoji:          false,
+			CommitValidation: CommitValidationConfig{
+				MaxSubjectLength: 0,
+				Conventional:     false,
+				Types:            []string{"build", "chore", "ci", "docs", "feat", "fix", "perf", "refactor", "revert", "style", "test"},
+				Scopes:           []string(nil),
+				RequireScope:     false,
+			},
+			ParseEmoji: false,
 		},
 		Refresher: RefresherConfig{
 			RefreshInterval: 10,
@@ -387,6 +474,7 @@ func GetDefaultConfig() *UserConfig {
 				New:                          "n",
 				Edit:                         "e",
 				OpenFile:                     "o",
+				OpenInBrowser:                "G",
 				OpenRecentRepos:              "<c-r>",
 				ScrollUpMain:                 "<pgup>",
 				ScrollDownMain:               "<pgdown>",
@@ -414,6 +502,10 @@ func GetDefaultConfig() *UserConfig {
 				AppendNewline:                "<a-enter>",
 				ExtrasMenu:                   "@",
 				ToggleWhitespaceInDiffView:   "<c-w>",
+				ToggleSideBySideDiff:         "|",
+				Blame:                        "B",
+				ViewOperationLog:             "Z",
+				CancelRemoteOperation:        "<c-x>",
 			},
 			Status: KeybindingStatusConfig{
 				CheckForUpdate:      "u",
@@ -421,19 +513,21 @@ func GetDefaultConfig() *UserConfig {
 				AllBranchesLogGraph: "a",
 			},
 			Files: KeybindingFilesConfig{
-				CommitChanges:            "c",
-				CommitChangesWithoutHook: "w",
-				AmendLastCommit:          "A",
-				CommitChangesWithEditor:  "C",
-				IgnoreFile:               "i",
-				RefreshFiles:             "r",
-				StashAllChanges:          "s",
-				ViewStashOptions:         "S",
-				ToggleStagedAll:          "a",
-				ViewResetOptions:         "D",
-				Fetch:                    "f",
-				ToggleTreeView:           "`",
-				OpenMergeTool:            "M",
+				CommitChanges:             "c",
+				CommitChangesWithoutHook:  "w",
+				AmendLastCommit:           "A",
+				CommitChangesWithEditor:   "C",
+				IgnoreFile:                "i",
+				RefreshFiles:              "r",
+				StashAllChanges:           "s",
+				ViewStashOptions:          "S",
+				ToggleStagedAll:           "a",
+				ViewResetOptions:          "D",
+				Fetch:                     "f",
+				ToggleTreeView:            "`",
+				OpenMergeTool:             "M",
+				ViewLfsOptions:            "L",
+				ViewSparseCheckoutOptions: "t",
 			},
 			Branches: KeybindingBranchesConfig{
 				CopyPullRequestURL:     "<c-y>",
@@ -447,8 +541,12 @@ func GetDefaultConfig() *UserConfig {
 				ViewGitFlowOptions:     "i",
 				FastForward:            "f",
 				PushTag:                "P",
+				CreateAnnotatedTag:     "a",
 				SetUpstream:            "u",
 				FetchRemote:            "f",
+				FetchAllRemotes:        "F",
+				SetPushRemote:          "U",
+				CreateWorktree:         "w",
 			},
 			Commits: KeybindingCommitsConfig{
 				SquashDown:                   "s",
@@ -467,9 +565,12 @@ func GetDefaultConfig() *UserConfig {
 				CherryPickCopyRange:          "C",
 				PasteCommits:                 "v",
 				TagCommit:                    "T",
+				AnnotatedTagCommit:           "a",
 				CheckoutCommit:               "<space>",
 				ResetCherryPick:              "<c-R>",
 				CopyCommitMessageToClipboard: "<c-y>",
+				ViewBisectOptions:            "b",
+				SplitCommit:                  "X",
 			},
 			Stash: KeybindingStashConfig{
 				PopStash: "g",
@@ -481,18 +582,29 @@ func GetDefaultConfig() *UserConfig {
 				ToggleDragSelect:    "v",
 				ToggleDragSelectAlt: "V",
 				ToggleSelectHunk:    "a",
+				ToggleWordDiff:      "I",
 				PickBothHunks:       "b",
+				PickBaseHunk:        "B",
+				PickAllHunks:        "A",
+				BlameParent:         "b",
 			},
 			Submodules: KeybindingSubmodulesConfig{
 				Init:     "i",
 				Update:   "u",
 				BulkMenu: "b",
 			},
+			Worktrees: KeybindingWorktreesConfig{
+				Prune: "P",
+			},
+			CommitMessage: KeybindingCommitMessageConfig{
+				AddTrailer: "<c-t>",
+			},
 		},
 		OS:                   GetPlatformDefaultConfig(),
 		DisableStartupPopups: false,
 		CustomCommands:       []CustomCommand(nil),
 		Services:             map[string]string(nil),
+		ServiceProviders:     map[string]ServiceProvider(nil),
 		NotARepository:       "prompt",
 	}
 }
@@ -1,10 +1,13 @@
 package gui
 
 import (
+	"fmt"
 	"sync"
 	"time"
 
 	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
@@ -12,6 +15,11 @@ type appStatus struct {
 	message    string
 	statusType string
 	id         int
+
+	// for a push, pull or fetch: how far through it git is, and a function to
+	// cancel it
+	progress *oscommands.Progress
+	cancel   func()
 }
 
 type statusManager struct {
@@ -50,6 +58,55 @@ func (m *statusManager) addWaitingStatus(message string) int {
 	return id
 }
 
+// addCancellableStatus adds a waiting status for an operation that the user can
+// cancel with cancelLatestOperation
+func (m *statusManager) addCancellableStatus(message string, cancel func()) int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.nextId++
+	id := m.nextId
+
+	newStatus := appStatus{
+		message:    message,
+		statusType: "waiting",
+		id:         id,
+		cancel:     cancel,
+	}
+	m.statuses = append([]appStatus{newStatus}, m.statuses...)
+
+	return id
+}
+
+func (m *statusManager) setProgress(id int, progress oscommands.Progress) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for i := range m.statuses {
+		if m.statuses[i].id == id {
+			m.statuses[i].progress = &progress
+		}
+	}
+}
+
+// cancelLatestOperation cancels the most recently started operation that can
+// be cancelled, returning false if there isn't one
+func (m *statusManager) cancelLatestOperation() bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for i, status := range m.statuses {
+		if status.cancel != nil {
+			status.cancel()
+			// so we don't cancel it twice
+			m.statuses[i].cancel = nil
+			return true
+		}
+	}
+
+	return false
+}
+
 func (m *statusManager) addToastStatus(message string) int {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
@@ -74,16 +131,28 @@ func (m *statusManager) addToastStatus(message string) int {
 }
 
 func (m *statusManager) getStatusString() string {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
 	if len(m.statuses) == 0 {
 		return ""
 	}
 	topStatus := m.statuses[0]
 	if topStatus.statusType == "waiting" {
-		return topStatus.message + " " + utils.Loader()
+		message := topStatus.message
+		if topStatus.progress != nil {
+			message += " " + formatProgress(*topStatus.progress)
+		}
+		return message + " " + utils.Loader()
 	}
 	return topStatus.message
 }
 
+// formatProgress returns something like 'Receiving objects 45%'
+func formatProgress(progress oscommands.Progress) string {
+	return fmt.Sprintf("%s %d%%", progress.Phase, progress.Percent)
+}
+
 func (gui *Gui) raiseToast(message string) {
 	gui.statusManager.addToastStatus(message)
 
@@ -107,6 +176,10 @@ func (gui *Gui) renderAppStatus() {
 
 // WithWaitingStatus wraps a function and shows a waiting status while the function is still executing
 func (gui *Gui) WithWaitingStatus(message string, f func() error) error {
+	if gui.runningScript() {
+		return gui.surfaceError(f())
+	}
+
 	go utils.Safe(func() {
 		id := gui.statusManager.addWaitingStatus(message)
 
@@ -125,3 +198,55 @@ func (gui *Gui) WithWaitingStatus(message string, f func() error) error {
 
 	return nil
 }
+
+// WithRemoteOperationStatus is like WithWaitingStatus but for a push, pull or
+// fetch. f is given a copy of gitCommand that shows git's progress in the
+// status, and the user can cancel the operation with the cancel keybinding.
+func (gui *Gui) WithRemoteOperationStatus(message string, gitCommand *commands.GitCommand, f func(gitCommand *commands.GitCommand) error) error {
+	if gui.runningScript() {
+		return gui.surfaceError(f(gitCommand))
+	}
+
+	go utils.Safe(func() {
+		cancel := make(chan struct{})
+		id := gui.statusManager.addCancellableStatus(message, func() { close(cancel) })
+
+		defer func() {
+			gui.statusManager.removeStatus(id)
+		}()
+
+		gui.renderAppStatus()
+
+		onProgress := func(progress oscommands.Progress) {
+			gui.statusManager.setProgress(id, progress)
+		}
+
+		err := f(gitCommand.WithProgress(onProgress, cancel))
+		if err == oscommands.ErrCommandCancelled {
+			gui.raiseToast(gui.Tr.RemoteOperationCancelled)
+			return
+		}
+		if err != nil {
+			gui.g.Update(func(g *gocui.Gui) error {
+				return gui.surfaceError(err)
+			})
+		}
+	})
+
+	return nil
+}
+
+func (gui *Gui) handleCancelRemoteOperation() error {
+	if !gui.statusManager.cancelLatestOperation() {
+		return nil
+	}
+
+	// the operation may have been waiting on the user for credentials, which it
+	// no longer needs
+	if gui.currentContext().GetKey() == CREDENTIALS_CONTEXT_KEY {
+		gui.clearEditorView(gui.Views.Credentials)
+		return gui.handleCloseCredentialsView()
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package gui
+
+import (
+	"fmt"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// refForLog returns the ref we should show commits for. Mid-bisect git has HEAD
+// detached somewhere in the middle of our branch, so we show the commits of
+// whatever we had checked out when we started, otherwise the commits we still
+// need to test would be hidden above HEAD.
+func (gui *Gui) refForLog() string {
+	info := gui.State.Modes.Bisecting.GetInfo()
+	if !info.Started() {
+		return "HEAD"
+	}
+
+	return info.Start
+}
+
+func (gui *Gui) handleOpenBisectMenu() error {
+	if ok, err := gui.validateNotInFilterMode(); err != nil || !ok {
+		return err
+	}
+
+	commit := gui.getSelectedLocalCommit()
+	if commit == nil {
+		return nil
+	}
+
+	info := gui.State.Modes.Bisecting.GetInfo()
+
+	markItem := func(status models.BisectStatus) *menuItem {
+		term := info.Term(status)
+		return &menuItem{
+			displayString: utils.ResolvePlaceholderString(
+				gui.Tr.LcBisectMark,
+				map[string]string{
+					"commit": commit.ShortSha(),
+					"term":   term,
+				},
+			),
+			onPress: func() error {
+				return gui.markBisectCommit(commit, term)
+			},
+		}
+	}
+
+	menuItems := []*menuItem{
+		markItem(models.BisectStatusNew),
+		markItem(models.BisectStatusOld),
+	}
+
+	if info.Started() {
+		menuItems = append(menuItems,
+			&menuItem{
+				displayString: utils.ResolvePlaceholderString(
+					gui.Tr.LcBisectSkip,
+					map[string]string{
+						"commit": commit.ShortSha(),
+					},
+				),
+				onPress: func() error {
+					return gui.markBisectCommit(commit, info.Term(models.BisectStatusSkipped))
+				},
+			},
+			&menuItem{
+				displayString: gui.Tr.LcResetBisect,
+				onPress:       gui.resetBisect,
+			},
+		)
+	}
+
+	return gui.createMenu(gui.Tr.BisectMenuTitle, menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) markBisectCommit(commit *models.Commit, term string) error {
+	if !gui.State.Modes.Bisecting.Active() {
+		if err := gui.GitCommand.WithSpan(gui.Tr.Spans.StartBisect).StartBisect(); err != nil {
+			return gui.surfaceError(err)
+		}
+	}
+
+	if err := gui.GitCommand.WithSpan(gui.Tr.Spans.MarkBisectCommit).MarkBisectCommit(commit.Sha, term); err != nil {
+		return gui.surfaceError(err)
+	}
+
+	return gui.afterBisectMarkRefresh()
+}
+
+// afterBisectMarkRefresh selects the commit git has checked out for us to test
+// next, or lets the user know that we've found the commit we were looking for
+func (gui *Gui) afterBisectMarkRefresh() error {
+	// refreshing synchronously so that we have the new bisect info to work with
+	if err := gui.refreshSidePanels(refreshOptions{mode: SYNC}); err != nil {
+		return err
+	}
+
+	info := gui.State.Modes.Bisecting.GetInfo()
+
+	if info.Finished() {
+		return gui.showBisectCompletePrompt(info)
+	}
+
+	for i, commit := range gui.State.Commits {
+		if commit.Sha == info.Current {
+			gui.State.Panels.Commits.SelectedLineIdx = i
+			return gui.State.Contexts.BranchCommits.HandleRender()
+		}
+	}
+
+	return nil
+}
+
+func (gui *Gui) showBisectCompletePrompt(info *models.BisectInfo) error {
+	commitDescription := utils.SafeTruncate(info.FirstNewSha, 8)
+	for _, commit := range gui.State.Commits {
+		if commit.Sha == info.FirstNewSha {
+			commitDescription = commit.Description()
+			break
+		}
+	}
+
+	prompt := utils.ResolvePlaceholderString(
+		gui.Tr.BisectCompletePrompt,
+		map[string]string{
+			"newTerm": info.NewTerm,
+			"commit":  commitDescription,
+		},
+	)
+
+	return gui.ask(askOpts{
+		title:         gui.Tr.BisectCompleteTitle,
+		prompt:        prompt,
+		handleConfirm: gui.resetBisect,
+	})
+}
+
+func (gui *Gui) resetBisect() error {
+	if err := gui.GitCommand.WithSpan(gui.Tr.Spans.ResetBisect).ResetBisect(); err != nil {
+		return gui.surfaceError(err)
+	}
+
+	return gui.refreshSidePanels(refreshOptions{mode: BLOCK_UI})
+}
+
+// bisectStatusDescription is what we show in the information view while bisecting
+func (gui *Gui) bisectStatusDescription() string {
+	info := gui.State.Modes.Bisecting.GetInfo()
+
+	var description string
+	switch {
+	case info.Finished():
+		description = utils.ResolvePlaceholderString(
+			gui.Tr.LcBisectFoundFirstCommit,
+			map[string]string{
+				"newTerm": info.NewTerm,
+				"commit":  utils.SafeTruncate(info.FirstNewSha, 8),
+			},
+		)
+	case info.Candidates >= 0:
+		description = utils.ResolvePlaceholderString(
+			gui.Tr.LcBisectCandidatesLeft,
+			map[string]string{
+				"count": fmt.Sprintf("%d", info.Candidates),
+			},
+		)
+	default:
+		description = utils.ResolvePlaceholderString(
+			gui.Tr.LcBisectWaitingForCommits,
+			map[string]string{
+				"newTerm": info.NewTerm,
+				"oldTerm": info.OldTerm,
+			},
+		)
+	}
+
+	return style.FgGreen.Sprintf(
+		"%s: %s %s",
+		gui.Tr.LcBisecting,
+		description,
+		style.AttrUnderline.Sprint(gui.Tr.ResetInParentheses),
+	)
+}
@@ -0,0 +1,169 @@
+package gui
+
+import (
+	"fmt"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+func (gui *Gui) getSelectedBlameLine() *models.BlameLine {
+	state := gui.State.Panels.Blame
+	if state.SelectedLineIdx < 0 || state.SelectedLineIdx >= len(state.lines) {
+		return nil
+	}
+
+	return state.lines[state.SelectedLineIdx]
+}
+
+func (gui *Gui) handleBlameFile() error {
+	file := gui.getSelectedFile()
+	if file == nil {
+		return nil
+	}
+
+	if !file.Tracked {
+		return gui.createErrorPanel(gui.Tr.CantBlameUntrackedFile)
+	}
+
+	return gui.blameFile(file.Name, "", 0)
+}
+
+func (gui *Gui) handleBlameCommitFile() error {
+	file := gui.getSelectedCommitFile()
+	if file == nil {
+		return nil
+	}
+
+	return gui.blameFile(file.Name, gui.State.CommitFileManager.GetParent(), 0)
+}
+
+// blameFile shows the blame of the file at the given ref in the main view. An
+// empty ref means the file in the working tree.
+func (gui *Gui) blameFile(path string, ref string, selectedLineIdx int) error {
+	lines, err := gui.GitCommand.GetBlame(path, ref)
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+
+	state := gui.State.Panels.Blame
+	state.path = path
+	state.ref = ref
+	state.lines = lines
+	state.SelectedLineIdx = utils.Max(0, utils.Min(selectedLineIdx, len(lines)-1))
+
+	return gui.pushContext(gui.State.Contexts.Blame)
+}
+
+func (gui *Gui) refreshBlamePanel() error {
+	state := gui.State.Panels.Blame
+
+	title := utils.ResolvePlaceholderString(gui.Tr.BlameFileTitle, map[string]string{"path": state.path})
+	if state.ref != "" {
+		ref := state.ref
+		if len(ref) == 40 {
+			ref = ref[:8]
+		}
+		title = utils.ResolvePlaceholderString(gui.Tr.BlameFileAtRefTitle, map[string]string{"path": state.path, "ref": ref})
+	}
+
+	gui.focusBlameLine()
+
+	return gui.refreshMainViews(refreshMainOpts{
+		main: &viewUpdateOpts{
+			title:  title,
+			noWrap: true,
+			task:   NewRenderStringWithoutScrollTask(presentation.RenderBlame(state.lines, state.path, state.SelectedLineIdx)),
+		},
+	})
+}
+
+// focusBlameLine scrolls the main view just enough to show the selected line
+func (gui *Gui) focusBlameLine() {
+	view := gui.Views.Main
+	selectedLineIdx := gui.State.Panels.Blame.SelectedLineIdx
+
+	_, viewHeight := view.Size()
+	bufferHeight := viewHeight - 1
+	_, origin := view.Origin()
+
+	if selectedLineIdx < origin {
+		origin = selectedLineIdx
+	} else if selectedLineIdx > origin+bufferHeight {
+		origin = selectedLineIdx - bufferHeight
+	}
+
+	gui.g.Update(func(*gocui.Gui) error {
+		return view.SetOrigin(0, origin)
+	})
+}
+
+func (gui *Gui) handleBlameSelectLine(change int) error {
+	state := gui.State.Panels.Blame
+	newIdx := utils.Max(0, utils.Min(state.SelectedLineIdx+change, len(state.lines)-1))
+	if newIdx == state.SelectedLineIdx {
+		return nil
+	}
+	state.SelectedLineIdx = newIdx
+
+	return gui.refreshBlamePanel()
+}
+
+func (gui *Gui) handleBlamePrevLine() error {
+	return gui.handleBlameSelectLine(-1)
+}
+
+func (gui *Gui) handleBlameNextLine() error {
+	return gui.handleBlameSelectLine(1)
+}
+
+func (gui *Gui) handleBlameViewCommit() error {
+	line := gui.getSelectedBlameLine()
+	if line == nil {
+		return nil
+	}
+
+	if !line.IsCommitted() {
+		return gui.createErrorPanel(gui.Tr.CantViewUncommittedLine)
+	}
+
+	return gui.switchToSubCommitsContext(line.Sha)
+}
+
+// handleBlameParent blames the file as it was just before the commit that last
+// changed the selected line, which is how you dig past e.g. a formatting commit
+func (gui *Gui) handleBlameParent() error {
+	line := gui.getSelectedBlameLine()
+	if line == nil {
+		return nil
+	}
+
+	if !line.IsCommitted() {
+		return gui.createErrorPanel(gui.Tr.CantViewUncommittedLine)
+	}
+
+	if line.PreviousSha == "" {
+		return gui.createErrorPanel(gui.Tr.NoPreviousCommitToBlame)
+	}
+
+	// the line may not exist in the previous commit but its old position is
+	// usually close to where it ends up
+	return gui.blameFile(line.PreviousPath, line.PreviousSha, line.OriginalLineNumber-1)
+}
+
+func (gui *Gui) handleEscapeBlame() error {
+	return gui.returnFromContext()
+}
+
+func (gui *Gui) getBlameOptions() map[string]string {
+	keybindingConfig := gui.Config.GetUserConfig().Keybinding
+
+	return map[string]string{
+		fmt.Sprintf("%s %s", gui.getKeyDisplay(keybindingConfig.Universal.PrevItem), gui.getKeyDisplay(keybindingConfig.Universal.NextItem)): gui.Tr.LcSelectLine,
+		gui.getKeyDisplay(keybindingConfig.Universal.GoInto): gui.Tr.LcViewCommitOfLine,
+		gui.getKeyDisplay(keybindingConfig.Main.BlameParent): gui.Tr.LcBlameParent,
+		gui.getKeyDisplay(keybindingConfig.Universal.Return): gui.Tr.LcReturn,
+	}
+}
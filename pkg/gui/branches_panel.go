@@ -8,6 +8,7 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
 	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
@@ -58,7 +59,7 @@ func (gui *Gui) refreshBranches() {
 		// which allows us to order them correctly. So if we're filtering we'll just
 		// manually load all the reflog commits here
 		var err error
-		reflogCommits, _, err = gui.GitCommand.GetReflogCommits(nil, "")
+		reflogCommits, _, err = gui.GitCommand.GetReflogCommits(nil, commands.GetReflogCommitsOptions{})
 		if err != nil {
 			gui.Log.Error(err)
 		}
@@ -121,15 +122,11 @@ func (gui *Gui) handleCopyPullRequestURLPress() error {
 }
 
 func (gui *Gui) handleGitFetch() error {
-	if err := gui.createLoaderPanel(gui.Tr.FetchWait); err != nil {
-		return err
-	}
-	go utils.Safe(func() {
-		err := gui.fetch(true, "Fetch")
+	return gui.WithRemoteOperationStatus(gui.Tr.FetchWait, gui.GitCommand.WithSpan("Fetch"), func(gitCommand *commands.GitCommand) error {
+		err := gui.fetch(gitCommand, true)
 		gui.handleCredentialsPopup(err)
-		_ = gui.refreshSidePanels(refreshOptions{mode: ASYNC})
+		return gui.refreshSidePanels(refreshOptions{mode: ASYNC})
 	})
-	return nil
 }
 
 func (gui *Gui) handleForceCheckout() error {
@@ -154,6 +151,8 @@ type handleCheckoutRefOptions struct {
 	EnvVars       []string
 	onRefNotFound func(ref string) error
 	span          string
+	// called once the ref has been checked out
+	onSuccess func() error
 }
 
 func (gui *Gui) handleCheckoutRef(ref string, options handleCheckoutRefOptions) error {
@@ -164,11 +163,11 @@ func (gui *Gui) handleCheckoutRef(ref string, options handleCheckoutRefOptions)
 
 	cmdOptions := commands.CheckoutOptions{Force: false, EnvVars: options.EnvVars}
 
-	onSuccess := func() {
+	resetSelections := func() {
 		gui.State.Panels.Branches.SelectedLineIdx = 0
 		gui.State.Panels.Commits.SelectedLineIdx = 0
 		// loading a heap of commits is slow so we limit them whenever doing a reset
-		gui.State.Panels.Commits.LimitCommits = true
+		gui.State.Panels.Commits.pagination.reset()
 	}
 
 	gitCommand := gui.GitCommand.WithSpan(options.span)
@@ -195,7 +194,12 @@ func (gui *Gui) handleCheckoutRef(ref string, options handleCheckoutRefOptions)
 							return gui.surfaceError(err)
 						}
 
-						onSuccess()
+						resetSelections()
+						if options.onSuccess != nil {
+							if err := options.onSuccess(); err != nil {
+								return err
+							}
+						}
 						if err := gitCommand.StashDo(0, "pop"); err != nil {
 							if err := gui.refreshSidePanels(refreshOptions{mode: BLOCK_UI}); err != nil {
 								return err
@@ -210,8 +214,12 @@ func (gui *Gui) handleCheckoutRef(ref string, options handleCheckoutRefOptions)
 			if err := gui.surfaceError(err); err != nil {
 				return err
 			}
+		} else if options.onSuccess != nil {
+			if err := options.onSuccess(); err != nil {
+				return err
+			}
 		}
-		onSuccess()
+		resetSelections()
 
 		return gui.refreshSidePanels(refreshOptions{mode: BLOCK_UI})
 	})
@@ -253,7 +261,11 @@ func (gui *Gui) createNewBranchWithName(newBranchName string) error {
 		return nil
 	}
 
-	if err := gui.GitCommand.NewBranch(newBranchName, branch.Name); err != nil {
+	return gui.createNewBranch(newBranchName, branch.Name)
+}
+
+func (gui *Gui) createNewBranch(newBranchName string, baseBranchName string) error {
+	if err := gui.GitCommand.WithSpan(gui.Tr.Spans.CreateBranch).NewBranch(newBranchName, baseBranchName); err != nil {
 		return gui.surfaceError(err)
 	}
 
@@ -270,14 +282,15 @@ func (gui *Gui) deleteBranch(force bool) error {
 	if selectedBranch == nil {
 		return nil
 	}
-	checkedOutBranch := gui.getCheckedOutBranch()
-	if checkedOutBranch.Name == selectedBranch.Name {
-		return gui.createErrorPanel(gui.Tr.CantDeleteCheckOutBranch)
-	}
 	return gui.deleteNamedBranch(selectedBranch, force)
 }
 
 func (gui *Gui) deleteNamedBranch(selectedBranch *models.Branch, force bool) error {
+	checkedOutBranch := gui.getCheckedOutBranch()
+	if checkedOutBranch != nil && checkedOutBranch.Name == selectedBranch.Name {
+		return gui.createErrorPanel(gui.Tr.CantDeleteCheckOutBranch)
+	}
+
 	title := gui.Tr.DeleteBranch
 	var templateStr string
 	if force {
@@ -373,6 +386,7 @@ func (gui *Gui) handleRebaseOntoBranch(selectedBranchName string) error {
 		title:  gui.Tr.RebasingTitle,
 		prompt: prompt,
 		handleConfirm: func() error {
+			gui.recordForcePushLease()
 			err := gui.GitCommand.WithSpan(gui.Tr.Spans.RebaseBranch).RebaseBranch(selectedBranchName)
 			return gui.handleGenericMergeCommandResult(err)
 		},
@@ -410,18 +424,15 @@ func (gui *Gui) handleFastForward() error {
 			"to":   branch.Name,
 		},
 	)
-	go utils.Safe(func() {
-		_ = gui.createLoaderPanel(message)
-
+	return gui.WithRemoteOperationStatus(message, gui.GitCommand.WithSpan(span), func(gitCommand *commands.GitCommand) error {
 		if gui.State.Panels.Branches.SelectedLineIdx == 0 {
-			_ = gui.pullWithMode("ff-only", PullFilesOptions{span: span})
-		} else {
-			err := gui.GitCommand.WithSpan(span).FastForward(branch.Name, remoteName, remoteBranchName, gui.promptUserForCredential)
-			gui.handleCredentialsPopup(err)
-			_ = gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{BRANCHES}})
+			return gui.pullWithMode(gitCommand, "ff-only", PullFilesOptions{})
 		}
+
+		err := gitCommand.FastForward(branch.Name, remoteName, remoteBranchName, gui.promptUserForCredential)
+		gui.handleCredentialsPopup(err)
+		return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{BRANCHES}})
 	})
-	return nil
 }
 
 func (gui *Gui) handleCreateResetToBranchMenu() error {
@@ -566,3 +577,51 @@ func (gui *Gui) findBranchNameSuggestions(input string) []*types.Suggestion {
 func sanitizedBranchName(input string) string {
 	return strings.Replace(input, " ", "-", -1)
 }
+
+func (gui *Gui) handleCreateSetPushRemoteMenu() error {
+	branch := gui.getSelectedBranch()
+	if branch == nil || !branch.IsRealBranch() {
+		return nil
+	}
+
+	setPushRemote := func(remoteName string) error {
+		if err := gui.GitCommand.WithSpan(gui.Tr.Spans.SetBranchPushRemote).SetBranchPushRemote(branch.Name, remoteName); err != nil {
+			return gui.surfaceError(err)
+		}
+
+		return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{BRANCHES}})
+	}
+
+	menuItems := make([]*menuItem, 0, len(gui.State.Remotes)+1)
+	for _, remote := range gui.State.Remotes {
+		remote := remote
+		url := ""
+		if len(remote.Urls) > 0 {
+			url = remote.Urls[0]
+		}
+		menuItems = append(menuItems, &menuItem{
+			displayStrings: []string{remote.Name, style.FgBlue.Sprint(url)},
+			onPress: func() error {
+				return setPushRemote(remote.Name)
+			},
+		})
+	}
+
+	// unsetting branch.<name>.pushRemote means we fall back to remote.pushDefault,
+	// or failing that, the upstream's remote
+	menuItems = append(menuItems, &menuItem{
+		displayStrings: []string{gui.Tr.LcDefaultPushRemote, ""},
+		onPress: func() error {
+			return setPushRemote("")
+		},
+	})
+
+	title := utils.ResolvePlaceholderString(
+		gui.Tr.SetPushRemoteTitle,
+		map[string]string{
+			"branchName": branch.Name,
+		},
+	)
+
+	return gui.createMenu(title, menuItems, createMenuOptions{showCancel: true})
+}
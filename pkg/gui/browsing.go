@@ -0,0 +1,121 @@
+package gui
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// These handlers open whatever is selected on the git service the origin remote
+// is hosted on (Github, Gitlab, ...), using the URLs defined for that service
+
+func (gui *Gui) browse(url string, err error) error {
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+
+	return gui.OSCommand.WithSpan(gui.Tr.Spans.OpenInBrowser).OpenLink(url)
+}
+
+// browseRefForWorkingTree returns the ref to view files from the working tree
+// at. We can't link to uncommitted changes so the checked out branch is the
+// best we can do.
+func (gui *Gui) browseRefForWorkingTree() string {
+	branch := gui.getCheckedOutBranch()
+	if branch == nil {
+		return "HEAD"
+	}
+
+	return branch.Name
+}
+
+var shaRegexp = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+// commitFileAtLineURL returns the URL for viewing a file from the commit files
+// panel, as long as the commit it's from can be found on the git service
+func (gui *Gui) commitFileAtLineURL(path string, line int) (string, error) {
+	if parentContext, ok := gui.State.Contexts.CommitFiles.GetParentContext(); ok {
+		switch parentContext.GetKey() {
+		case STASH_CONTEXT_KEY, LOST_AND_FOUND_CONTEXT_KEY:
+			return "", errors.New(gui.Tr.CannotBrowseLocalOnlyCommit)
+		}
+	}
+
+	ref := gui.State.Panels.CommitFiles.refName
+	if shaRegexp.MatchString(ref) {
+		return gui.GitCommand.CommitFileAtLineURL(ref, path, line)
+	}
+
+	for _, branch := range gui.State.Branches {
+		if branch.Name == ref {
+			return gui.GitCommand.FileAtLineURL(ref, path, line)
+		}
+	}
+
+	return "", errors.New(utils.ResolvePlaceholderString(gui.Tr.CannotBrowseRef, map[string]string{"ref": ref}))
+}
+
+func (gui *Gui) handleBrowseCommit() error {
+	commit := gui.getSelectedLocalCommit()
+	if commit == nil {
+		return nil
+	}
+
+	return gui.browse(gui.GitCommand.CommitURL(commit.Sha))
+}
+
+func (gui *Gui) handleBrowseCommitFile() error {
+	node := gui.getSelectedCommitFileNode()
+	if node == nil {
+		return nil
+	}
+
+	return gui.browse(gui.commitFileAtLineURL(node.GetPath(), 1))
+}
+
+func (gui *Gui) handleBrowseFile() error {
+	node := gui.getSelectedFileNode()
+	if node == nil {
+		return nil
+	}
+
+	return gui.browse(gui.GitCommand.FileAtLineURL(gui.browseRefForWorkingTree(), node.GetPath(), 1))
+}
+
+func (gui *Gui) handleBrowseBranch() error {
+	branch := gui.getSelectedBranch()
+	if branch == nil {
+		return nil
+	}
+
+	return gui.browse(gui.GitCommand.BranchURL(branch.Name))
+}
+
+func (gui *Gui) handleBrowseTag() error {
+	tag := gui.getSelectedTag()
+	if tag == nil {
+		return nil
+	}
+
+	return gui.browse(gui.GitCommand.TagURL(tag.Name))
+}
+
+// handleBrowseSelectedLine opens the file being staged (or added to a patch)
+// at the selected line
+func (gui *Gui) handleBrowseSelectedLine() error {
+	return gui.withLBLActiveCheck(func(state *LblPanelState) error {
+		switch gui.State.MainContext {
+		case gui.State.Contexts.PatchBuilding.GetKey():
+			return gui.browse(gui.commitFileAtLineURL(gui.getSelectedCommitFileName(), state.CurrentLineNumber()))
+		case gui.State.Contexts.Staging.GetKey():
+			file := gui.getSelectedFile()
+			if file == nil {
+				return nil
+			}
+			return gui.browse(gui.GitCommand.FileAtLineURL(gui.browseRefForWorkingTree(), file.Name, state.CurrentLineNumber()))
+		default:
+			return nil
+		}
+	})
+}
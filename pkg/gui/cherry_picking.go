@@ -76,6 +76,8 @@ func (gui *Gui) commitsListForContext() []*models.Commit {
 		return gui.State.FilteredReflogCommits
 	case SUB_COMMITS_CONTEXT_KEY:
 		return gui.State.SubCommits
+	case LOST_AND_FOUND_CONTEXT_KEY:
+		return gui.State.LostCommits
 	default:
 		gui.Log.Errorf("no commit list for context %s", context.GetKey())
 		return nil
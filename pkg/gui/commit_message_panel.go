@@ -5,12 +5,31 @@ import (
 	"strings"
 
 	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
 	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
 func (gui *Gui) handleCommitConfirm() error {
 	message := gui.trimmedContent(gui.Views.CommitMessage)
+
+	if onConfirm := gui.State.Panels.CommitMessage.onConfirm; onConfirm != nil {
+		if message == "" {
+			return gui.createErrorPanel(gui.State.Panels.CommitMessage.emptyMessageErr)
+		}
+		gui.resetCommitMessagePanel()
+		if err := gui.returnFromContext(); err != nil {
+			return err
+		}
+		return onConfirm(message)
+	}
+
+	return gui.commit(message)
+}
+
+func (gui *Gui) commit(message string) error {
 	if message == "" {
 		return gui.createErrorPanel(gui.Tr.CommitWithoutMessageErr)
 	}
@@ -18,21 +37,57 @@ func (gui *Gui) handleCommitConfirm() error {
 	skipHookPrefix := gui.Config.GetUserConfig().Git.SkipHookPrefix
 	if skipHookPrefix != "" && strings.HasPrefix(message, skipHookPrefix) {
 		flags = "--no-verify"
+	} else if problems := gui.GitCommand.ValidateCommitMessage(message); len(problems) > 0 {
+		// like hooks, validation is skipped for messages with the skip hook prefix
+		return gui.createErrorPanel(gui.Tr.CommitMessageInvalid + "\n\n" + strings.Join(problems, "\n"))
 	}
 
 	cmdStr := gui.GitCommand.CommitCmdStr(message, flags)
 	gui.OnRunCommand(oscommands.NewCmdLogEntry(cmdStr, gui.Tr.Spans.Commit, true))
-	return gui.withGpgHandling(cmdStr, gui.Tr.CommittingStatus, func() error {
+	return gui.withGpgHandling(cmdStr, gui.GitCommand.UsingGpg(), gui.Tr.CommittingStatus, func() error {
 		_ = gui.returnFromContext()
 		gui.clearEditorView(gui.Views.CommitMessage)
-		return nil
+		return gui.continueSplitCommitIfDone()
 	})
 }
 
 func (gui *Gui) handleCommitClose() error {
+	if gui.State.Panels.CommitMessage.onConfirm != nil {
+		gui.resetCommitMessagePanel()
+	}
+
 	return gui.returnFromContext()
 }
 
+// promptForMessage reuses the commit message panel to get a (potentially
+// multiline) message for something other than a commit, e.g. an annotated tag.
+// Like a commit message, the message can't be empty.
+func (gui *Gui) promptForMessage(title string, emptyMessageErr string, onConfirm func(message string) error) error {
+	gui.State.Panels.CommitMessage.onConfirm = onConfirm
+	gui.State.Panels.CommitMessage.emptyMessageErr = emptyMessageErr
+	gui.State.Panels.CommitMessage.draft = gui.Views.CommitMessage.Buffer()
+	gui.State.Panels.CommitMessage.draftCursorX, gui.State.Panels.CommitMessage.draftCursorY = gui.Views.CommitMessage.Cursor()
+	gui.Views.CommitMessage.Title = title
+	gui.clearEditorView(gui.Views.CommitMessage)
+
+	return gui.pushContext(gui.State.Contexts.CommitMessage)
+}
+
+func (gui *Gui) resetCommitMessagePanel() {
+	state := gui.State.Panels.CommitMessage
+	view := gui.Views.CommitMessage
+
+	view.Title = gui.Tr.CommitMessage
+	if err := gui.renderStringSync(view, state.draft); err != nil {
+		gui.Log.Error(err)
+	}
+	if err := view.SetCursor(state.draftCursorX, state.draftCursorY); err != nil {
+		gui.Log.Error(err)
+	}
+
+	*state = commitMessagePanelState{}
+}
+
 func (gui *Gui) handleCommitMessageFocused() error {
 	message := utils.ResolvePlaceholderString(
 		gui.Tr.CommitMessageConfirm,
@@ -43,6 +98,8 @@ func (gui *Gui) handleCommitMessageFocused() error {
 		},
 	)
 
+	message += ", " + gui.getKeyDisplay(gui.Config.GetUserConfig().Keybinding.CommitMessage.AddTrailer) + ": " + gui.Tr.LcAddTrailer
+
 	gui.renderString(gui.Views.Options, message)
 	return nil
 }
@@ -53,9 +110,100 @@ func (gui *Gui) getBufferLength(view *gocui.View) string {
 
 // RenderCommitLength is a function.
 func (gui *Gui) RenderCommitLength() {
-	if !gui.Config.GetUserConfig().Gui.CommitLength.Show {
-		return
+	subtitle := ""
+	if gui.Config.GetUserConfig().Gui.CommitLength.Show {
+		subtitle = gui.getBufferLength(gui.Views.CommitMessage)
+	}
+
+	gui.Views.CommitMessage.Subtitle = subtitle + gui.getCommitValidationResult()
+}
+
+// getCommitValidationResult tells the user, as they type, whether their commit
+// message will pass validation
+func (gui *Gui) getCommitValidationResult() string {
+	// the panel is also used for things like tag messages, which aren't validated
+	if gui.State.Panels.CommitMessage.onConfirm != nil {
+		return ""
+	}
+
+	rules := gui.Config.GetUserConfig().Git.CommitValidation
+	if rules.MaxSubjectLength == 0 && !rules.Conventional {
+		return ""
 	}
 
-	gui.Views.CommitMessage.Subtitle = gui.getBufferLength(gui.Views.CommitMessage)
+	message := gui.trimmedContent(gui.Views.CommitMessage)
+	if message == "" {
+		return ""
+	}
+
+	problems := gui.GitCommand.ValidateCommitMessage(message)
+	if len(problems) == 0 {
+		return style.FgGreen.Sprint(" ✓ " + gui.Tr.CommitMessageValid + " ")
+	}
+
+	return style.FgRed.Sprint(" ✗ " + problems[0] + " ")
+}
+
+func (gui *Gui) handleCommitMessageAddTrailer() error {
+	// trailers only make sense for commits, not e.g. annotated tags
+	if gui.State.Panels.CommitMessage.onConfirm != nil {
+		return nil
+	}
+
+	identity := gui.GitCommand.GetUserIdentity()
+
+	menuItems := []*menuItem{
+		{
+			displayString: utils.ResolvePlaceholderString(gui.Tr.SignedOffByTrailer, map[string]string{"identity": identity}),
+			onPress: func() error {
+				return gui.addCommitMessageTrailer("Signed-off-by: " + identity)
+			},
+		},
+		{
+			displayString: gui.Tr.CoAuthoredByTrailer,
+			onPress: func() error {
+				authors, err := gui.GitCommand.GetCommitAuthors()
+				if err != nil {
+					return gui.surfaceError(err)
+				}
+
+				return gui.prompt(promptOpts{
+					title: gui.Tr.CoAuthorPromptTitle,
+					findSuggestionsFunc: func(input string) []*types.Suggestion {
+						matchingAuthors := utils.FuzzySearch(input, authors)
+						suggestions := make([]*types.Suggestion, len(matchingAuthors))
+						for i, author := range matchingAuthors {
+							suggestions[i] = &types.Suggestion{Value: author, Label: author}
+						}
+						return suggestions
+					},
+					handleConfirm: func(coAuthor string) error {
+						if coAuthor == "" {
+							return nil
+						}
+						return gui.addCommitMessageTrailer("Co-authored-by: " + coAuthor)
+					},
+				})
+			},
+		},
+	}
+
+	return gui.createMenu(gui.Tr.AddTrailer, menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) addCommitMessageTrailer(trailer string) error {
+	view := gui.Views.CommitMessage
+	cx, cy := view.Cursor()
+
+	message := commands.AddTrailer(strings.TrimSpace(view.Buffer()), trailer)
+	if err := gui.renderStringSync(view, message); err != nil {
+		return err
+	}
+	// we've only added to the end of the message so the cursor can stay put
+	if err := view.SetCursor(cx, cy); err != nil {
+		return err
+	}
+
+	gui.RenderCommitLength()
+	return nil
 }
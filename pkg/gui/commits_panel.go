@@ -23,14 +23,7 @@ func (gui *Gui) getSelectedLocalCommit() *models.Commit {
 
 func (gui *Gui) handleCommitSelect() error {
 	state := gui.State.Panels.Commits
-	if state.SelectedLineIdx > 290 && state.LimitCommits {
-		state.LimitCommits = false
-		go utils.Safe(func() {
-			if err := gui.refreshCommitsWithLimit(); err != nil {
-				_ = gui.surfaceError(err)
-			}
-		})
-	}
+	gui.loadMoreIfNecessary(&state.pagination, state.SelectedLineIdx, len(gui.State.Commits), gui.loadMoreCommits)
 
 	gui.escapeLineByLinePanel()
 
@@ -39,10 +32,9 @@ func (gui *Gui) handleCommitSelect() error {
 	if commit == nil {
 		task = NewRenderStringTask(gui.Tr.NoCommitsThisBranch)
 	} else {
-		cmd := gui.OSCommand.ExecutableFromString(
-			gui.GitCommand.ShowCmdStr(commit.Sha, gui.State.Modes.Filtering.GetPath()),
-		)
-		task = NewRunPtyTask(cmd)
+		task = gui.diffTask(func(plain bool) string {
+			return gui.GitCommand.ShowCmdStr(commit.Sha, gui.State.Modes.Filtering.GetPath(), plain)
+		})
 	}
 
 	return gui.refreshMainViews(refreshMainOpts{
@@ -116,22 +108,64 @@ func (gui *Gui) refreshCommitsWithLimit() error {
 
 	builder := commands.NewCommitListBuilder(gui.Log, gui.GitCommand, gui.OSCommand, gui.Tr)
 
+	// the bisect state decides which commits we show, so we read it first
+	gui.State.Modes.Bisecting.SetInfo(gui.GitCommand.GetBisectInfo())
+
+	pagination := &gui.State.Panels.Commits.pagination
 	commits, err := builder.GetCommits(
 		commands.GetCommitsOptions{
-			Limit:                gui.State.Panels.Commits.LimitCommits,
+			Limit:                pagination.limit,
 			FilterPath:           gui.State.Modes.Filtering.GetPath(),
 			IncludeRebaseCommits: true,
-			RefName:              "HEAD",
+			RefName:              gui.refForLog(),
+		},
+	)
+	if err != nil {
+		return err
+	}
+	gui.State.Commits = commits
+	pagination.afterRefresh(loggedCommitCount(commits))
+
+	return gui.postRefreshUpdate(gui.State.Contexts.BranchCommits)
+}
+
+// loadMoreCommits loads the next page of commits onto the end of the list
+func (gui *Gui) loadMoreCommits() error {
+	gui.Mutexes.BranchCommitsMutex.Lock()
+	defer gui.Mutexes.BranchCommitsMutex.Unlock()
+
+	builder := commands.NewCommitListBuilder(gui.Log, gui.GitCommand, gui.OSCommand, gui.Tr)
+
+	commits, err := builder.GetMoreCommits(
+		gui.State.Commits,
+		commands.GetCommitsOptions{
+			Limit:      commands.COMMITS_PAGE_SIZE,
+			FilterPath: gui.State.Modes.Filtering.GetPath(),
+			RefName:    gui.refForLog(),
 		},
 	)
 	if err != nil {
 		return err
 	}
+	loaded := len(commits) - len(gui.State.Commits)
 	gui.State.Commits = commits
+	gui.State.Panels.Commits.pagination.afterLoadingMore(loaded)
 
 	return gui.postRefreshUpdate(gui.State.Contexts.BranchCommits)
 }
 
+// loggedCommitCount tells us how many of the commits came from git log, as
+// opposed to the rebase todo file
+func loggedCommitCount(commits []*models.Commit) int {
+	count := 0
+	for _, commit := range commits {
+		if commit.Status != "rebasing" {
+			count++
+		}
+	}
+	return count
+}
+
 func (gui *Gui) refreshRebaseCommits() error {
 	gui.Mutexes.BranchCommitsMutex.Lock()
 	defer gui.Mutexes.BranchCommitsMutex.Unlock()
@@ -171,6 +205,7 @@ func (gui *Gui) handleCommitSquashDown() error {
 		prompt: gui.Tr.SureSquashThisCommit,
 		handleConfirm: func() error {
 			return gui.WithWaitingStatus(gui.Tr.SquashingStatus, func() error {
+				gui.recordForcePushLease()
 				err := gui.GitCommand.WithSpan(gui.Tr.Spans.SquashCommitDown).InteractiveRebase(gui.State.Commits, gui.State.Panels.Commits.SelectedLineIdx, "squash")
 				return gui.handleGenericMergeCommandResult(err)
 			})
@@ -200,6 +235,7 @@ func (gui *Gui) handleCommitFixup() error {
 		prompt: gui.Tr.SureFixupThisCommit,
 		handleConfirm: func() error {
 			return gui.WithWaitingStatus(gui.Tr.FixingStatus, func() error {
+				gui.recordForcePushLease()
 				err := gui.GitCommand.WithSpan(gui.Tr.Spans.FixupCommit).InteractiveRebase(gui.State.Commits, gui.State.Panels.Commits.SelectedLineIdx, "fixup")
 				return gui.handleGenericMergeCommandResult(err)
 			})
@@ -238,6 +274,7 @@ func (gui *Gui) handleRenameCommit() error {
 		title:          gui.Tr.LcRenameCommit,
 		initialContent: message,
 		handleConfirm: func(response string) error {
+			gui.recordForcePushLease()
 			if err := gui.GitCommand.WithSpan(gui.Tr.Spans.RewordCommit).RenameCommit(response); err != nil {
 				return gui.surfaceError(err)
 			}
@@ -260,6 +297,7 @@ func (gui *Gui) handleRenameCommitEditor() error {
 		return nil
 	}
 
+	gui.recordForcePushLease()
 	subProcess, err := gui.GitCommand.WithSpan(gui.Tr.Spans.RewordCommit).RewordCommit(gui.State.Commits, gui.State.Panels.Commits.SelectedLineIdx)
 	if err != nil {
 		return gui.surfaceError(err)
@@ -319,6 +357,7 @@ func (gui *Gui) handleCommitDelete() error {
 		prompt: gui.Tr.DeleteCommitPrompt,
 		handleConfirm: func() error {
 			return gui.WithWaitingStatus(gui.Tr.DeletingStatus, func() error {
+				gui.recordForcePushLease()
 				err := gui.GitCommand.WithSpan(gui.Tr.Spans.DropCommit).InteractiveRebase(gui.State.Commits, gui.State.Panels.Commits.SelectedLineIdx, "drop")
 				return gui.handleGenericMergeCommandResult(err)
 			})
@@ -356,6 +395,7 @@ func (gui *Gui) handleCommitMoveDown() error {
 	}
 
 	return gui.WithWaitingStatus(gui.Tr.MovingStatus, func() error {
+		gui.recordForcePushLease()
 		err := gui.GitCommand.WithSpan(span).MoveCommitDown(gui.State.Commits, index)
 		if err == nil {
 			gui.State.Panels.Commits.SelectedLineIdx++
@@ -394,6 +434,7 @@ func (gui *Gui) handleCommitMoveUp() error {
 	}
 
 	return gui.WithWaitingStatus(gui.Tr.MovingStatus, func() error {
+		gui.recordForcePushLease()
 		err := gui.GitCommand.WithSpan(span).MoveCommitDown(gui.State.Commits, index-1)
 		if err == nil {
 			gui.State.Panels.Commits.SelectedLineIdx--
@@ -416,6 +457,7 @@ func (gui *Gui) handleCommitEdit() error {
 	}
 
 	return gui.WithWaitingStatus(gui.Tr.RebasingStatus, func() error {
+		gui.recordForcePushLease()
 		err = gui.GitCommand.WithSpan(gui.Tr.Spans.EditCommit).InteractiveRebase(gui.State.Commits, gui.State.Panels.Commits.SelectedLineIdx, "edit")
 		return gui.handleGenericMergeCommandResult(err)
 	})
@@ -431,6 +473,7 @@ func (gui *Gui) handleCommitAmendTo() error {
 		prompt: gui.Tr.AmendCommitPrompt,
 		handleConfirm: func() error {
 			return gui.WithWaitingStatus(gui.Tr.AmendingStatus, func() error {
+				gui.recordForcePushLease()
 				err := gui.GitCommand.WithSpan(gui.Tr.Spans.AmendCommit).AmendTo(gui.State.Commits[gui.State.Panels.Commits.SelectedLineIdx].Sha)
 				return gui.handleGenericMergeCommandResult(err)
 			})
@@ -563,6 +606,7 @@ func (gui *Gui) handleSquashAllAboveFixupCommits() error {
 		prompt: prompt,
 		handleConfirm: func() error {
 			return gui.WithWaitingStatus(gui.Tr.SquashingStatus, func() error {
+				gui.recordForcePushLease()
 				err := gui.GitCommand.WithSpan(gui.Tr.Spans.SquashAllAboveFixupCommits).SquashAllAboveFixupCommits(commit.Sha)
 				return gui.handleGenericMergeCommandResult(err)
 			})
@@ -571,9 +615,6 @@ func (gui *Gui) handleSquashAllAboveFixupCommits() error {
 }
 
 func (gui *Gui) handleTagCommit() error {
-	// TODO: bring up menu asking if you want to make a lightweight or annotated tag
-	// if annotated, switch to a subprocess to create the message
-
 	commit := gui.getSelectedLocalCommit()
 	if commit == nil {
 		return nil
@@ -582,6 +623,15 @@ func (gui *Gui) handleTagCommit() error {
 	return gui.handleCreateLightweightTag(commit.Sha)
 }
 
+func (gui *Gui) handleAnnotatedTagCommit() error {
+	commit := gui.getSelectedLocalCommit()
+	if commit == nil {
+		return nil
+	}
+
+	return gui.handleCreateTagMenu(commit.Sha)
+}
+
 func (gui *Gui) handleCreateLightweightTag(commitSha string) error {
 	return gui.prompt(promptOpts{
 		title: gui.Tr.TagNameTitle,
@@ -620,29 +670,47 @@ func (gui *Gui) handleCreateCommitResetMenu() error {
 
 func (gui *Gui) handleOpenSearchForCommitsPanel(_viewName string) error {
 	// we usually lazyload these commits but now that we're searching we need to load them now
-	if gui.State.Panels.Commits.LimitCommits {
-		gui.State.Panels.Commits.LimitCommits = false
-		if err := gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{COMMITS}}); err != nil {
-			return err
-		}
+	if err := gui.loadAllCommitsForCurrentContext(ASYNC); err != nil {
+		return err
 	}
 
 	return gui.handleOpenSearch("commits")
 }
 
 func (gui *Gui) handleGotoBottomForCommitsPanel() error {
-	// we usually lazyload these commits but now that we're searching we need to load them now
-	if gui.State.Panels.Commits.LimitCommits {
-		gui.State.Panels.Commits.LimitCommits = false
-		if err := gui.refreshSidePanels(refreshOptions{mode: SYNC, scope: []RefreshableView{COMMITS}}); err != nil {
-			return err
-		}
+	// we usually lazyload these commits but now that we're going to the bottom we need to load them now
+	if err := gui.loadAllCommitsForCurrentContext(SYNC); err != nil {
+		return err
+	}
+
+	currentContext := gui.currentSideListContext()
+	if currentContext == nil {
+		return nil
 	}
 
-	for _, context := range gui.getListContexts() {
-		if context.ViewName == "commits" {
-			return context.handleGotoBottom()
+	return currentContext.handleGotoBottom()
+}
+
+// loadAllCommitsForCurrentContext stops paginating the commits or reflog list,
+// whichever is showing in the commits view, and loads the whole thing
+func (gui *Gui) loadAllCommitsForCurrentContext(mode RefreshMode) error {
+	switch gui.currentSideContext().GetKey() {
+	case BRANCH_COMMITS_CONTEXT_KEY:
+		pagination := &gui.State.Panels.Commits.pagination
+		if pagination.limit == 0 {
+			return nil
+		}
+		pagination.loadAll()
+		return gui.refreshSidePanels(refreshOptions{mode: mode, scope: []RefreshableView{COMMITS}})
+	case REFLOG_COMMITS_CONTEXT_KEY:
+		pagination := &gui.State.Panels.ReflogCommits.pagination
+		if pagination.limit == 0 {
+			return nil
 		}
+		pagination.loadAll()
+		// otherwise we'd only look for reflog entries newer than the ones we have
+		gui.State.ReflogCommits = nil
+		return gui.refreshSidePanels(refreshOptions{mode: mode, scope: []RefreshableView{REFLOG}})
 	}
 
 	return nil
@@ -7,6 +7,7 @@
 package gui
 
 import (
+	"errors"
 	"strings"
 
 	"github.com/jesseduffield/gocui"
@@ -205,6 +206,10 @@ func (gui *Gui) prepareConfirmationPanel(title, prompt string, hasLoader bool, f
 }
 
 func (gui *Gui) createPopupPanel(opts createPopupPanelOpts) error {
+	if gui.runningScript() {
+		return gui.answerPopupFromScript(opts)
+	}
+
 	gui.g.Update(func(g *gocui.Gui) error {
 		// remove any previous keybindings
 		gui.clearConfirmationViewKeyBindings()
@@ -316,6 +321,10 @@ func (gui *Gui) wrappedHandler(f func() error) func(g *gocui.Gui, v *gocui.View)
 }
 
 func (gui *Gui) createErrorPanel(message string) error {
+	if gui.runningScript() {
+		return gui.failScriptStep(errors.New(strings.TrimSpace(message)))
+	}
+
 	coloredMessage := style.FgRed.Sprint(strings.TrimSpace(message))
 	if err := gui.refreshSidePanels(refreshOptions{mode: ASYNC}); err != nil {
 		return err
@@ -415,7 +415,7 @@ func (gui *Gui) changeMainViewsContext(contextKey ContextKey) {
 	}
 
 	switch contextKey {
-	case MAIN_NORMAL_CONTEXT_KEY, MAIN_PATCH_BUILDING_CONTEXT_KEY, MAIN_STAGING_CONTEXT_KEY, MAIN_MERGING_CONTEXT_KEY:
+	case MAIN_NORMAL_CONTEXT_KEY, MAIN_PATCH_BUILDING_CONTEXT_KEY, MAIN_STAGING_CONTEXT_KEY, MAIN_MERGING_CONTEXT_KEY, MAIN_BLAME_CONTEXT_KEY:
 		gui.Views.Main.Context = string(contextKey)
 		gui.Views.Secondary.Context = string(contextKey)
 	default:
@@ -9,15 +9,18 @@ const (
 	REMOTES_CONTEXT_KEY             ContextKey = "remotes"
 	REMOTE_BRANCHES_CONTEXT_KEY     ContextKey = "remoteBranches"
 	TAGS_CONTEXT_KEY                ContextKey = "tags"
+	WORKTREES_CONTEXT_KEY           ContextKey = "worktrees"
 	BRANCH_COMMITS_CONTEXT_KEY      ContextKey = "commits"
 	REFLOG_COMMITS_CONTEXT_KEY      ContextKey = "reflogCommits"
 	SUB_COMMITS_CONTEXT_KEY         ContextKey = "subCommits"
 	COMMIT_FILES_CONTEXT_KEY        ContextKey = "commitFiles"
 	STASH_CONTEXT_KEY               ContextKey = "stash"
+	LOST_AND_FOUND_CONTEXT_KEY      ContextKey = "lostAndFound"
 	MAIN_NORMAL_CONTEXT_KEY         ContextKey = "normal"
 	MAIN_MERGING_CONTEXT_KEY        ContextKey = "merging"
 	MAIN_PATCH_BUILDING_CONTEXT_KEY ContextKey = "patchBuilding"
 	MAIN_STAGING_CONTEXT_KEY        ContextKey = "staging"
+	MAIN_BLAME_CONTEXT_KEY          ContextKey = "blame"
 	MENU_CONTEXT_KEY                ContextKey = "menu"
 	CREDENTIALS_CONTEXT_KEY         ContextKey = "credentials"
 	CONFIRMATION_CONTEXT_KEY        ContextKey = "confirmation"
@@ -35,15 +38,18 @@ var allContextKeys = []ContextKey{
 	REMOTES_CONTEXT_KEY,
 	REMOTE_BRANCHES_CONTEXT_KEY,
 	TAGS_CONTEXT_KEY,
+	WORKTREES_CONTEXT_KEY,
 	BRANCH_COMMITS_CONTEXT_KEY,
 	REFLOG_COMMITS_CONTEXT_KEY,
 	SUB_COMMITS_CONTEXT_KEY,
 	COMMIT_FILES_CONTEXT_KEY,
 	STASH_CONTEXT_KEY,
+	LOST_AND_FOUND_CONTEXT_KEY,
 	MAIN_NORMAL_CONTEXT_KEY,
 	MAIN_MERGING_CONTEXT_KEY,
 	MAIN_PATCH_BUILDING_CONTEXT_KEY,
 	MAIN_STAGING_CONTEXT_KEY,
+	MAIN_BLAME_CONTEXT_KEY,
 	MENU_CONTEXT_KEY,
 	CREDENTIALS_CONTEXT_KEY,
 	CONFIRMATION_CONTEXT_KEY,
@@ -63,16 +69,19 @@ type ContextTree struct {
 	Remotes        *ListContext
 	RemoteBranches *ListContext
 	Tags           *ListContext
+	Worktrees      *ListContext
 	BranchCommits  *ListContext
 	CommitFiles    *ListContext
 	ReflogCommits  *ListContext
 	SubCommits     *ListContext
 	Stash          *ListContext
+	LostAndFound   *ListContext
 	Suggestions    *ListContext
 	Normal         Context
 	Staging        Context
 	PatchBuilding  Context
 	Merging        Context
+	Blame          Context
 	Credentials    Context
 	Confirmation   Context
 	CommitMessage  Context
@@ -89,10 +98,12 @@ func (gui *Gui) allContexts() []Context {
 		gui.State.Contexts.Remotes,
 		gui.State.Contexts.RemoteBranches,
 		gui.State.Contexts.Tags,
+		gui.State.Contexts.Worktrees,
 		gui.State.Contexts.BranchCommits,
 		gui.State.Contexts.CommitFiles,
 		gui.State.Contexts.ReflogCommits,
 		gui.State.Contexts.Stash,
+		gui.State.Contexts.LostAndFound,
 		gui.State.Contexts.Menu,
 		gui.State.Contexts.Confirmation,
 		gui.State.Contexts.Credentials,
@@ -101,6 +112,7 @@ func (gui *Gui) allContexts() []Context {
 		gui.State.Contexts.Staging,
 		gui.State.Contexts.Merging,
 		gui.State.Contexts.PatchBuilding,
+		gui.State.Contexts.Blame,
 		gui.State.Contexts.SubCommits,
 		gui.State.Contexts.Suggestions,
 		gui.State.Contexts.CommandLog,
@@ -126,7 +138,9 @@ func (gui *Gui) contextTree() ContextTree {
 		SubCommits:     gui.subCommitsListContext(),
 		Branches:       gui.branchesListContext(),
 		Tags:           gui.tagsListContext(),
+		Worktrees:      gui.worktreesListContext(),
 		Stash:          gui.stashListContext(),
+		LostAndFound:   gui.lostAndFoundListContext(),
 		Normal: &BasicContext{
 			OnFocus: func() error {
 				return nil // TODO: should we do something here? We should allow for scrolling the panel
@@ -162,6 +176,13 @@ func (gui *Gui) contextTree() ContextTree {
 			Key:             MAIN_MERGING_CONTEXT_KEY,
 			OnGetOptionsMap: gui.getMergingOptions,
 		},
+		Blame: &BasicContext{
+			OnFocus:         gui.refreshBlamePanel,
+			Kind:            MAIN_CONTEXT,
+			ViewName:        "main",
+			Key:             MAIN_BLAME_CONTEXT_KEY,
+			OnGetOptionsMap: gui.getBlameOptions,
+		},
 		Credentials: &BasicContext{
 			OnFocus:  gui.handleCredentialsViewFocused,
 			Kind:     PERSISTENT_POPUP,
@@ -237,6 +258,10 @@ func (tree ContextTree) initialViewTabContextMap() map[string][]tabContext {
 				tab:      "Tags",
 				contexts: []Context{tree.Tags},
 			},
+			{
+				tab:      "Worktrees",
+				contexts: []Context{tree.Worktrees},
+			},
 		},
 		"commits": {
 			{
@@ -262,5 +287,15 @@ func (tree ContextTree) initialViewTabContextMap() map[string][]tabContext {
 				},
 			},
 		},
+		"stash": {
+			{
+				tab:      "Stash",
+				contexts: []Context{tree.Stash},
+			},
+			{
+				tab:      "Lost & found",
+				contexts: []Context{tree.LostAndFound},
+			},
+		},
 	}
 }
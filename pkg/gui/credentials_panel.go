@@ -4,25 +4,26 @@ import (
 	"strings"
 
 	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
 type credentials chan string
 
-// promptUserForCredential wait for a username, password or passphrase input from the credentials popup
-func (gui *Gui) promptUserForCredential(passOrUname string) string {
+// promptUserForCredential waits for the answer to a credential prompt from git
+// or ssh, such as a username, password, passphrase or one-time code. An empty
+// answer means the user cancelled.
+func (gui *Gui) promptUserForCredential(prompt string) string {
+	if gui.runningScript() {
+		return promptForCredentialInTerminal(prompt)
+	}
+
 	gui.credentials = make(chan string)
 	gui.g.Update(func(g *gocui.Gui) error {
 		credentialsView := gui.Views.Credentials
-		switch passOrUname {
-		case "username":
-			credentialsView.Title = gui.Tr.CredentialsUsername
-			credentialsView.Mask = 0
-		case "password":
-			credentialsView.Title = gui.Tr.CredentialsPassword
-			credentialsView.Mask = '*'
-		default:
-			credentialsView.Title = gui.Tr.CredentialsPassphrase
+		credentialsView.Title = credentialPromptTitle(prompt, gui.Tr.CredentialsPassword)
+		credentialsView.Mask = 0
+		if isSecretCredentialPrompt(prompt) {
 			credentialsView.Mask = '*'
 		}
 
@@ -35,8 +36,27 @@ func (gui *Gui) promptUserForCredential(passOrUname string) string {
 	})
 
 	// wait for username/passwords/passphrase input
-	userInput := <-gui.credentials
-	return userInput + "\n"
+	return <-gui.credentials
+}
+
+// credentialPromptTitle returns the question from a prompt like ssh's host
+// key confirmation which has some explanation on the lines before it
+func credentialPromptTitle(prompt string, defaultTitle string) string {
+	lines := strings.Split(strings.TrimSpace(prompt), "\n")
+	title := strings.TrimSpace(lines[len(lines)-1])
+	if title == "" {
+		return defaultTitle
+	}
+	return title
+}
+
+// isSecretCredentialPrompt tells us whether to hide what's typed in answer to
+// a prompt. Given prompts can be in any language we hide it unless we know
+// it's asking for a username or a yes/no answer.
+func isSecretCredentialPrompt(prompt string) bool {
+	trimmedPrompt := strings.TrimSpace(prompt)
+	return !strings.HasPrefix(strings.ToLower(trimmedPrompt), "username") &&
+		!strings.HasSuffix(trimmedPrompt, "?")
 }
 
 func (gui *Gui) handleSubmitCredential() error {
@@ -71,17 +91,18 @@ func (gui *Gui) handleCredentialsViewFocused() error {
 	return nil
 }
 
-// handleCredentialsPopup handles the views after executing a command that might ask for credentials
+// handleCredentialsPopup shows the error, if any, from a command that might have
+// asked for credentials
 func (gui *Gui) handleCredentialsPopup(cmdErr error) {
-	if cmdErr != nil {
-		errMessage := cmdErr.Error()
-		if strings.Contains(errMessage, "Invalid username, password or passphrase") {
-			errMessage = gui.Tr.PassUnameWrong
-		}
-		_ = gui.returnFromContext()
-		// we are not logging this error because it may contain a password or a passphrase
-		_ = gui.createErrorPanel(errMessage)
-	} else {
-		_ = gui.closeConfirmationPrompt(false)
+	// if the user cancelled the command they don't need to be told it failed
+	if cmdErr == nil || cmdErr == oscommands.ErrCommandCancelled {
+		return
+	}
+
+	errMessage := cmdErr.Error()
+	if strings.Contains(errMessage, "Invalid username, password or passphrase") {
+		errMessage = gui.Tr.PassUnameWrong
 	}
+	// we are not logging this error because it may contain a password or a passphrase
+	_ = gui.createErrorPanel(errMessage)
 }
@@ -13,10 +13,13 @@ func (gui *Gui) exitDiffMode() error {
 }
 
 func (gui *Gui) renderDiff() error {
-	cmd := gui.OSCommand.ExecutableFromString(
-		fmt.Sprintf("git diff --submodule --no-ext-diff --color %s", gui.diffStr()),
-	)
-	task := NewRunPtyTask(cmd)
+	task := gui.diffTask(func(plain bool) string {
+		colorArg := "--color"
+		if plain {
+			colorArg = "--color=never"
+		}
+		return fmt.Sprintf("git diff --submodule --no-ext-diff %s %s", colorArg, gui.diffStr())
+	})
 
 	return gui.refreshMainViews(refreshMainOpts{
 		main: &viewUpdateOpts{
@@ -11,6 +11,7 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
 	"github.com/jesseduffield/lazygit/pkg/config"
 	"github.com/jesseduffield/lazygit/pkg/gui/filetree"
+	"github.com/jesseduffield/lazygit/pkg/theme"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
@@ -71,22 +72,30 @@ func (gui *Gui) selectFile(alreadySelected bool) error {
 		return gui.refreshMergePanelWithLock()
 	}
 
-	cmdStr := gui.GitCommand.WorktreeFileDiffCmdStr(node, false, !node.GetHasUnstagedChanges() && node.GetHasStagedChanges(), gui.State.IgnoreWhitespaceInDiffView)
-	cmd := gui.OSCommand.ExecutableFromString(cmdStr)
+	isLfs := node.File != nil && node.File.IsLfs
+	task := gui.fileDiffTask(isLfs, func(plain bool) string {
+		return gui.GitCommand.WorktreeFileDiffCmdStr(node, plain, !node.GetHasUnstagedChanges() && node.GetHasStagedChanges(), gui.State.IgnoreWhitespaceInDiffView)
+	})
+	// an untracked file's diff is just its content, which is easier to read
+	// with syntax highlighting
+	if node.File != nil && !node.File.Tracked && !node.File.HasStagedChanges && theme.SyntaxHighlighting && !gui.State.SideBySideDiff && !isLfs {
+		task = NewRenderDiffTask(gui.GitCommand.WorktreeFileDiffCmdStr(node, true, false, gui.State.IgnoreWhitespaceInDiffView), false)
+	}
 
 	refreshOpts := refreshMainOpts{main: &viewUpdateOpts{
 		title: gui.Tr.UnstagedChanges,
-		task:  NewRunPtyTask(cmd),
+		task:  task,
 	}}
 
 	if node.GetHasUnstagedChanges() {
 		if node.GetHasStagedChanges() {
-			cmdStr := gui.GitCommand.WorktreeFileDiffCmdStr(node, false, true, gui.State.IgnoreWhitespaceInDiffView)
-			cmd := gui.OSCommand.ExecutableFromString(cmdStr)
+			task := gui.fileDiffTask(isLfs, func(plain bool) string {
+				return gui.GitCommand.WorktreeFileDiffCmdStr(node, plain, true, gui.State.IgnoreWhitespaceInDiffView)
+			})
 
 			refreshOpts.secondary = &viewUpdateOpts{
 				title: gui.Tr.StagedChanges,
-				task:  NewRunPtyTask(cmd),
+				task:  task,
 			}
 		}
 	} else {
@@ -390,6 +399,7 @@ func (gui *Gui) handleCommitPress() error {
 		return gui.promptToStageAllAndRetry(gui.handleCommitPress)
 	}
 
+	prefix := ""
 	commitPrefixConfig := gui.commitPrefixConfigForRepo()
 	if commitPrefixConfig != nil {
 		prefixPattern := commitPrefixConfig.Pattern
@@ -398,8 +408,27 @@ func (gui *Gui) handleCommitPress() error {
 		if err != nil {
 			return gui.createErrorPanel(fmt.Sprintf("%s: %s", gui.Tr.LcCommitPrefixPatternError, err.Error()))
 		}
-		prefix := rgx.ReplaceAllString(gui.getCheckedOutBranch().Name, prefixReplace)
-		gui.renderString(gui.Views.CommitMessage, prefix)
+		prefix = rgx.ReplaceAllString(gui.getCheckedOutBranch().Name, prefixReplace)
+	}
+
+	template, err := gui.GitCommand.GetCommitTemplate()
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+	// when splitting a commit, its original message makes a better template
+	if splitCommit := gui.splittingCommit(); splitCommit != nil {
+		template = splitCommit.message
+	}
+	// we don't want to clobber a message that's still in progress from the last
+	// time the panel was opened, unless we've got a prefix to add
+	if template != "" && prefix == "" && gui.trimmedContent(gui.Views.CommitMessage) != "" {
+		template = ""
+	}
+
+	if prefix != "" || template != "" {
+		if err := gui.renderStringSync(gui.Views.CommitMessage, prefix+template); err != nil {
+			return err
+		}
 		if err := gui.Views.CommitMessage.SetCursor(len(prefix), 0); err != nil {
 			return err
 		}
@@ -450,9 +479,10 @@ func (gui *Gui) handleAmendCommitPress() error {
 		title:  strings.Title(gui.Tr.AmendLastCommit),
 		prompt: gui.Tr.SureToAmend,
 		handleConfirm: func() error {
+			gui.recordForcePushLease()
 			cmdStr := gui.GitCommand.AmendHeadCmdStr()
 			gui.OnRunCommand(oscommands.NewCmdLogEntry(cmdStr, gui.Tr.Spans.AmendCommit, true))
-			return gui.withGpgHandling(cmdStr, gui.Tr.AmendingStatus, nil)
+			return gui.withGpgHandling(cmdStr, gui.GitCommand.UsingGpg(), gui.Tr.AmendingStatus, nil)
 		},
 	})
 }
@@ -524,6 +554,11 @@ func (gui *Gui) refreshStateFiles() error {
 
 	files := gui.GitCommand.GetStatusFiles(commands.GetStatusFileOptions{})
 
+	sparseCheckoutCone, err := gui.GitCommand.GetSparseCheckoutCone()
+	if err != nil {
+		gui.Log.Error(err)
+	}
+
 	// for when you stage the old file of a rename and the new file is in a collapsed dir
 	state.FileManager.RWMutex.Lock()
 	for _, file := range files {
@@ -532,6 +567,7 @@ func (gui *Gui) refreshStateFiles() error {
 		}
 	}
 
+	state.FileManager.SetSparseCheckoutCone(sparseCheckoutCone)
 	state.FileManager.SetFiles(files)
 	state.FileManager.RWMutex.Unlock()
 
@@ -655,25 +691,18 @@ type PullFilesOptions struct {
 }
 
 func (gui *Gui) pullFiles(opts PullFilesOptions) error {
-	if err := gui.createLoaderPanel(gui.Tr.PullWait); err != nil {
-		return err
-	}
-
 	mode := &gui.Config.GetUserConfig().Git.Pull.Mode
 	*mode = gui.GitCommand.GetPullMode(*mode)
 
-	// TODO: this doesn't look like a good idea. Why the goroutine?
-	go utils.Safe(func() { _ = gui.pullWithMode(*mode, opts) })
-
-	return nil
+	return gui.WithRemoteOperationStatus(gui.Tr.PullWait, gui.GitCommand.WithSpan(opts.span), func(gitCommand *commands.GitCommand) error {
+		return gui.pullWithMode(gitCommand, *mode, opts)
+	})
 }
 
-func (gui *Gui) pullWithMode(mode string, opts PullFilesOptions) error {
+func (gui *Gui) pullWithMode(gitCommand *commands.GitCommand, mode string, opts PullFilesOptions) error {
 	gui.Mutexes.FetchMutex.Lock()
 	defer gui.Mutexes.FetchMutex.Unlock()
 
-	gitCommand := gui.GitCommand.WithSpan(opts.span)
-
 	err := gitCommand.Fetch(
 		commands.FetchOptions{
 			PromptUserForCredential: gui.promptUserForCredential,
@@ -702,31 +731,37 @@ func (gui *Gui) pullWithMode(mode string, opts PullFilesOptions) error {
 }
 
 func (gui *Gui) pushWithForceFlag(force bool, upstream string, args string) error {
-	if err := gui.createLoaderPanel(gui.Tr.PushWait); err != nil {
-		return err
-	}
-	go utils.Safe(func() {
-		branchName := gui.getCheckedOutBranch().Name
-		err := gui.GitCommand.WithSpan(gui.Tr.Spans.Push).Push(branchName, force, upstream, args, gui.promptUserForCredential)
+	return gui.WithRemoteOperationStatus(gui.Tr.PushWait, gui.GitCommand.WithSpan(gui.Tr.Spans.Push), func(gitCommand *commands.GitCommand) error {
+		branch := gui.getCheckedOutBranch()
+		branchName := branch.Name
+		var lease *commands.ForceWithLease
+		if force {
+			lease = gui.getForcePushLease(gitCommand, branch)
+		}
+		err := gitCommand.Push(branchName, force, lease, upstream, args, gui.promptUserForCredential)
+		if err == nil {
+			// the remote branch is now wherever we've put it
+			gui.setForcePushLease(branchName, nil)
+		}
+		if lease != nil && isStaleForcePushLeaseError(err) {
+			return gui.handleStaleForcePushLease(lease)
+		}
 		if err != nil && !force && strings.Contains(err.Error(), "Updates were rejected") {
 			forcePushDisabled := gui.Config.GetUserConfig().Git.DisableForcePushing
 			if forcePushDisabled {
-				_ = gui.createErrorPanel(gui.Tr.UpdatesRejectedAndForcePushDisabled)
-				return
+				return gui.createErrorPanel(gui.Tr.UpdatesRejectedAndForcePushDisabled)
 			}
-			_ = gui.ask(askOpts{
+			return gui.ask(askOpts{
 				title:  gui.Tr.ForcePush,
 				prompt: gui.Tr.ForcePushPrompt,
 				handleConfirm: func() error {
 					return gui.pushWithForceFlag(true, upstream, args)
 				},
 			})
-			return
 		}
 		gui.handleCredentialsPopup(err)
-		_ = gui.refreshSidePanels(refreshOptions{mode: ASYNC})
+		return gui.refreshSidePanels(refreshOptions{mode: ASYNC})
 	})
-	return nil
 }
 
 func (gui *Gui) pushFiles() error {
@@ -741,9 +776,19 @@ func (gui *Gui) pushFiles() error {
 		return nil
 	}
 
+	if currentBranch.HasPushRemote() {
+		// we're pushing somewhere other than where we pull from, so it's the
+		// push remote's copy of the branch that tells us if we need to force push
+		args := fmt.Sprintf("%s %s", currentBranch.PushRemote, currentBranch.Name)
+		if currentBranch.HasCommitsToPullFromPushRemote() {
+			return gui.requestToForcePush(args)
+		}
+		return gui.pushWithForceFlag(false, "", args)
+	}
+
 	if currentBranch.IsTrackingRemote() {
 		if currentBranch.HasCommitsToPull() {
-			return gui.requestToForcePush()
+			return gui.requestToForcePush("")
 		} else {
 			return gui.pushWithForceFlag(false, "", "")
 		}
@@ -772,7 +817,7 @@ func (gui *Gui) pushFiles() error {
 	}
 }
 
-func (gui *Gui) requestToForcePush() error {
+func (gui *Gui) requestToForcePush(args string) error {
 	forcePushDisabled := gui.Config.GetUserConfig().Git.DisableForcePushing
 	if forcePushDisabled {
 		return gui.createErrorPanel(gui.Tr.ForcePushDisabled)
@@ -782,7 +827,7 @@ func (gui *Gui) requestToForcePush() error {
 		title:  gui.Tr.ForcePush,
 		prompt: gui.Tr.ForcePushPrompt,
 		handleConfirm: func() error {
-			return gui.pushWithForceFlag(true, "", "")
+			return gui.pushWithForceFlag(true, "", args)
 		},
 	})
 }
@@ -859,9 +904,44 @@ func (gui *Gui) handleCreateStashMenu() error {
 		},
 	}
 
+	if node := gui.getSelectedFileNode(); node != nil {
+		menuItems = append(menuItems, &menuItem{
+			displayString: utils.ResolvePlaceholderString(
+				gui.Tr.LcStashSelectedPath,
+				map[string]string{
+					"path": node.GetPath(),
+				},
+			),
+			onPress: func() error {
+				return gui.handleStashFileNode(node)
+			},
+		})
+	}
+
+	if gui.currentContext().GetKey() == MAIN_STAGING_CONTEXT_KEY {
+		menuItems = append(menuItems, &menuItem{
+			displayString: gui.Tr.LcStashSelectedLines,
+			onPress:       gui.handleStashSelectedLines,
+		})
+	}
+
 	return gui.createMenu(gui.Tr.LcStashOptions, menuItems, createMenuOptions{showCancel: true})
 }
 
+func (gui *Gui) handleStashFileNode(node *filetree.FileNode) error {
+	paths := []string{node.GetPath()}
+	if node.File != nil {
+		// for a renamed file we need both the old and new name
+		paths = node.File.Names()
+	}
+
+	includeUntracked := node.AnyFile(func(file *models.File) bool { return !file.Tracked })
+
+	return gui.promptForStashMessage(func(message string) error {
+		return gui.GitCommand.WithSpan(gui.Tr.Spans.StashSelectedPath).StashSaveFiles(message, paths, includeUntracked)
+	}, nil)
+}
+
 func (gui *Gui) handleStashChanges() error {
 	return gui.handleStashSave(gui.GitCommand.StashSave)
 }
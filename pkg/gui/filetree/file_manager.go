@@ -14,6 +14,9 @@ type FileManager struct {
 	showTree       bool
 	log            *logrus.Entry
 	collapsedPaths CollapsedPaths
+	// nil unless the repo is a sparse checkout, in which case we show which
+	// directories are in the cone
+	sparseCheckoutCone *models.SparseCheckoutCone
 	sync.RWMutex
 }
 
@@ -72,6 +75,10 @@ func (m *FileManager) SetFiles(files []*models.File) {
 	m.SetTree()
 }
 
+func (m *FileManager) SetSparseCheckoutCone(cone *models.SparseCheckoutCone) {
+	m.sparseCheckoutCone = cone
+}
+
 func (m *FileManager) SetTree() {
 	if m.showTree {
 		m.tree = BuildTreeFromFiles(m.files)
@@ -96,6 +103,10 @@ func (m *FileManager) Render(diffName string, submoduleConfigs []*models.Submodu
 
 	return renderAux(m.tree, m.collapsedPaths, "", -1, func(n INode, depth int) string {
 		castN := n.(*FileNode)
-		return presentation.GetFileLine(castN.GetHasUnstagedChanges(), castN.GetHasStagedChanges(), castN.NameAtDepth(depth), diffName, submoduleConfigs, castN.File)
+		line := presentation.GetFileLine(castN.GetHasUnstagedChanges(), castN.GetHasStagedChanges(), castN.NameAtDepth(depth), diffName, submoduleConfigs, castN.File)
+		if castN.File == nil && m.sparseCheckoutCone != nil {
+			line += presentation.GetSparseCheckoutSuffix(m.sparseCheckoutCone.Status(castN.GetPath()))
+		}
+		return line
 	})
 }
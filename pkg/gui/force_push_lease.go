@@ -0,0 +1,148 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// When the user starts rewriting a branch that's been pushed (by rebasing or
+// amending), we note where the branch was on the remote. When they come to
+// force push, we tell git to only overwrite the remote branch if it's still
+// there. Plain --force-with-lease isn't enough because it compares against the
+// remote-tracking ref, and our background fetch may have moved that onto
+// commits the user has never seen.
+// We keep the first lease until the branch is pushed, because commits that
+// arrive on the remote after that haven't been taken in, unless the user has
+// since rebased onto them.
+
+// recordForcePushLease is to be called before rewriting the checked-out branch
+func (gui *Gui) recordForcePushLease() {
+	branch := gui.currentBranch()
+	// mid-rebase we're detached, but we'll have recorded the lease when the
+	// rebase started
+	if branch == nil || !branch.IsRealBranch() {
+		return
+	}
+
+	gui.Mutexes.ForcePushLeasesMutex.Lock()
+	defer gui.Mutexes.ForcePushLeasesMutex.Unlock()
+
+	if _, ok := gui.State.ForcePushLeases[branch.Name]; ok {
+		return
+	}
+
+	lease, err := gui.GitCommand.GetForceWithLease(branch)
+	if err != nil {
+		gui.Log.Error(err)
+		return
+	}
+	if lease != nil {
+		gui.State.ForcePushLeases[branch.Name] = lease
+	}
+}
+
+// getForcePushLease returns nil if we haven't seen the branch rewritten, in
+// which case git falls back to comparing against the remote-tracking ref
+func (gui *Gui) getForcePushLease(gitCommand *commands.GitCommand, branch *models.Branch) *commands.ForceWithLease {
+	gui.Mutexes.ForcePushLeasesMutex.Lock()
+	lease := gui.State.ForcePushLeases[branch.Name]
+	gui.Mutexes.ForcePushLeasesMutex.Unlock()
+
+	if lease == nil {
+		return nil
+	}
+
+	// if the user has since rebased onto the remote branch, they've taken in
+	// whatever was pushed to it so we can let them overwrite it
+	current, err := gitCommand.GetForceWithLease(branch)
+	if err != nil {
+		gui.Log.Error(err)
+		return lease
+	}
+	if current != nil && current.ExpectedSha != lease.ExpectedSha && gitCommand.IsAncestor(current.ExpectedSha, "HEAD") {
+		return current
+	}
+
+	return lease
+}
+
+func (gui *Gui) setForcePushLease(branchName string, lease *commands.ForceWithLease) {
+	gui.Mutexes.ForcePushLeasesMutex.Lock()
+	defer gui.Mutexes.ForcePushLeasesMutex.Unlock()
+
+	if lease == nil {
+		delete(gui.State.ForcePushLeases, branchName)
+		return
+	}
+	gui.State.ForcePushLeases[branchName] = lease
+}
+
+// isStaleForcePushLeaseError tells us whether git refused to force push because
+// the remote branch isn't where we said it would be
+func isStaleForcePushLeaseError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "stale info")
+}
+
+func (gui *Gui) handleStaleForcePushLease(lease *commands.ForceWithLease) error {
+	return gui.ask(askOpts{
+		title: gui.Tr.RemoteBranchChanged,
+		prompt: utils.ResolvePlaceholderString(
+			gui.Tr.RemoteBranchChangedPrompt,
+			map[string]string{
+				"branchName":  strings.TrimPrefix(lease.RefName, "refs/heads/"),
+				"expectedSha": utils.SafeTruncate(lease.ExpectedSha, 8),
+			},
+		),
+		handleConfirm: gui.viewIncomingCommits,
+	})
+}
+
+// viewIncomingCommits fetches the checked-out branch's copy on the remote and
+// shows its commits. Now that the user has seen them, their next force push
+// will be allowed to overwrite them.
+func (gui *Gui) viewIncomingCommits() error {
+	branch := gui.getCheckedOutBranch()
+	if branch == nil {
+		return nil
+	}
+
+	remoteName := branch.PushRemote
+	if remoteName == "" {
+		remoteName = gui.GitCommand.GetConfigValue(fmt.Sprintf("branch.%s.remote", branch.Name))
+	}
+
+	return gui.WithRemoteOperationStatus(gui.Tr.FetchingRemoteStatus, gui.GitCommand, func(gitCommand *commands.GitCommand) error {
+		gui.Mutexes.FetchMutex.Lock()
+		err := gitCommand.FetchRemote(remoteName, gui.promptUserForCredential)
+		gui.Mutexes.FetchMutex.Unlock()
+		if err != nil {
+			return err
+		}
+
+		lease, err := gitCommand.GetForceWithLease(branch)
+		if err != nil {
+			return err
+		}
+		if lease == nil {
+			// the branch has been deleted from the remote
+			gui.setForcePushLease(branch.Name, nil)
+			return gui.refreshSidePanels(refreshOptions{mode: ASYNC})
+		}
+		gui.setForcePushLease(branch.Name, lease)
+
+		if err := gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{BRANCHES, REMOTES}}); err != nil {
+			return err
+		}
+
+		gui.g.Update(func(*gocui.Gui) error {
+			return gui.switchToSubCommitsContext(lease.RemoteTrackingRef)
+		})
+
+		return nil
+	})
+}
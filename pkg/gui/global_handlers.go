@@ -153,6 +153,8 @@ func (gui *Gui) scrollDownConfirmationPanel() error {
 }
 
 func (gui *Gui) handleRefresh() error {
+	gui.forgetLostCommits()
+
 	return gui.refreshSidePanels(refreshOptions{mode: ASYNC})
 }
 
@@ -166,9 +168,9 @@ func (gui *Gui) handleMouseDownMain() error {
 		// set filename, set primary/secondary selected, set line number, then switch context
 		// I'll need to know it was changed though.
 		// Could I pass something along to the context change?
-		return gui.enterFile(false, gui.Views.Main.SelectedLineIdx())
+		return gui.enterFile(false, gui.clickedDiffLineIdx(gui.Views.Main))
 	case gui.State.Contexts.CommitFiles:
-		return gui.enterCommitFile(gui.Views.Main.SelectedLineIdx())
+		return gui.enterCommitFile(gui.clickedDiffLineIdx(gui.Views.Main))
 	}
 
 	return nil
@@ -181,13 +183,24 @@ func (gui *Gui) handleMouseDownSecondary() error {
 
 	switch gui.g.CurrentView() {
 	case gui.Views.Files:
-		return gui.enterFile(true, gui.Views.Secondary.SelectedLineIdx())
+		return gui.enterFile(true, gui.clickedDiffLineIdx(gui.Views.Secondary))
 	}
 
 	return nil
 }
 
-func (gui *Gui) fetch(canPromptForCredentials bool, span string) (err error) {
+// clickedDiffLineIdx returns the index of the line of the diff that was clicked
+// on in the given view. A side-by-side diff's rows don't match up with the
+// diff's lines so in that case we return -1 to not select any line in particular.
+func (gui *Gui) clickedDiffLineIdx(view *gocui.View) int {
+	if gui.State.SideBySideDiff {
+		return -1
+	}
+
+	return view.SelectedLineIdx()
+}
+
+func (gui *Gui) fetch(gitCommand *commands.GitCommand, canPromptForCredentials bool) (err error) {
 	gui.Mutexes.FetchMutex.Lock()
 	defer gui.Mutexes.FetchMutex.Unlock()
 
@@ -196,7 +209,7 @@ func (gui *Gui) fetch(canPromptForCredentials bool, span string) (err error) {
 		fetchOpts.PromptUserForCredential = gui.promptUserForCredential
 	}
 
-	err = gui.GitCommand.WithSpan(span).Fetch(fetchOpts)
+	err = gitCommand.Fetch(fetchOpts)
 
 	if canPromptForCredentials && err != nil && strings.Contains(err.Error(), "exit status 128") {
 		_ = gui.createErrorPanel(gui.Tr.PassUnameWrong)
@@ -4,9 +4,10 @@ package gui
 // WithWaitingStatus we get stuck there and can't return to lazygit. We could
 // fix this bug, or just stop running subprocesses from within there, given that
 // we don't need to see a loading status if we're in a subprocess.
-func (gui *Gui) withGpgHandling(cmdStr string, waitingStatus string, onSuccess func() error) error {
-	useSubprocess := gui.GitCommand.UsingGpg()
-	if useSubprocess {
+// usingGpg tells us whether the command will need gpg, e.g. via
+// GitCommand.UsingGpg for commits or GitCommand.UsingGpgForTag for tags.
+func (gui *Gui) withGpgHandling(cmdStr string, usingGpg bool, waitingStatus string, onSuccess func() error) error {
+	if usingGpg {
 		// Need to remember why we use the shell for the subprocess but not in the other case
 		// Maybe there's no good reason
 		success, err := gui.runSubprocessWithSuspense(gui.OSCommand.ShellCommandFromString(cmdStr))
@@ -20,6 +20,7 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/gui/filetree"
 	"github.com/jesseduffield/lazygit/pkg/gui/lbl"
 	"github.com/jesseduffield/lazygit/pkg/gui/mergeconflicts"
+	"github.com/jesseduffield/lazygit/pkg/gui/modes/bisecting"
 	"github.com/jesseduffield/lazygit/pkg/gui/modes/cherrypicking"
 	"github.com/jesseduffield/lazygit/pkg/gui/modes/diffing"
 	"github.com/jesseduffield/lazygit/pkg/gui/modes/filtering"
@@ -122,6 +123,13 @@ type Gui struct {
 
 	// the extras window contains things like the command log
 	ShowExtrasWindow bool
+
+	// set when we're running a script rather than taking input from the user
+	script *scriptState
+
+	// closed (and replaced) whenever the main view changes size, so that
+	// anything laid out to fit the view can be re-rendered
+	mainViewResized chan struct{}
 }
 
 type listPanelState struct {
@@ -142,6 +150,8 @@ func (h *listPanelState) GetSelectedLineIdx() int {
 type LblPanelState struct {
 	*lbl.State
 	SecondaryFocused bool // this is for if we show the left or right panel
+	// set while we're staging the individual words of a block of changed lines
+	WordDiff *lbl.WordDiffState
 }
 
 type MergingPanelState struct {
@@ -173,19 +183,27 @@ type tagsPanelState struct {
 	listPanelState
 }
 
+type worktreesPanelState struct {
+	listPanelState
+}
+
 type commitPanelState struct {
 	listPanelState
 
-	LimitCommits bool
+	pagination paginationState
 }
 
 type reflogCommitPanelState struct {
 	listPanelState
+
+	pagination paginationState
 }
 
 type subCommitPanelState struct {
 	listPanelState
 
+	pagination paginationState
+
 	// e.g. name of branch whose commits we're looking at
 	refName string
 }
@@ -194,6 +212,10 @@ type stashPanelState struct {
 	listPanelState
 }
 
+type lostAndFoundPanelState struct {
+	listPanelState
+}
+
 type menuPanelState struct {
 	listPanelState
 	OnPress func() error
@@ -216,22 +238,48 @@ type suggestionsPanelState struct {
 	listPanelState
 }
 
+type blamePanelState struct {
+	listPanelState
+
+	path string
+	// the commit we're blaming the file at, which is empty for the working tree
+	ref   string
+	lines []*models.BlameLine
+}
+
+// the commit message panel is also used to get messages for things other than
+// commits, in which case onConfirm is called with the message
+type commitMessagePanelState struct {
+	onConfirm func(message string) error
+	// shown instead of calling onConfirm when the message is empty
+	emptyMessageErr string
+	// the commit message the user was writing before the panel was reused, and
+	// where they were in it, which we put back afterwards
+	draft        string
+	draftCursorX int
+	draftCursorY int
+}
+
 type panelStates struct {
 	Files          *filePanelState
 	Branches       *branchPanelState
 	Remotes        *remotePanelState
 	RemoteBranches *remoteBranchesState
 	Tags           *tagsPanelState
+	Worktrees      *worktreesPanelState
 	Commits        *commitPanelState
 	ReflogCommits  *reflogCommitPanelState
 	SubCommits     *subCommitPanelState
 	Stash          *stashPanelState
+	LostAndFound   *lostAndFoundPanelState
 	Menu           *menuPanelState
 	LineByLine     *LblPanelState
 	Merging        *MergingPanelState
 	CommitFiles    *commitFilesPanelState
 	Submodules     *submodulePanelState
 	Suggestions    *suggestionsPanelState
+	CommitMessage  *commitMessagePanelState
+	Blame          *blamePanelState
 }
 
 type Views struct {
@@ -275,6 +323,7 @@ type Modes struct {
 	Filtering     filtering.Filtering
 	CherryPicking cherrypicking.CherryPicking
 	Diffing       diffing.Diffing
+	Bisecting     bisecting.Bisecting
 }
 
 type guiMutexes struct {
@@ -282,8 +331,11 @@ type guiMutexes struct {
 	RefreshingStatusMutex sync.Mutex
 	FetchMutex            sync.Mutex
 	BranchCommitsMutex    sync.Mutex
+	ReflogCommitsMutex    sync.Mutex
 	LineByLinePanelMutex  sync.Mutex
 	SubprocessMutex       sync.Mutex
+	ForcePushLeasesMutex  sync.Mutex
+	MainViewResizedMutex  sync.Mutex
 }
 
 type guiState struct {
@@ -295,6 +347,10 @@ type guiState struct {
 	Branches          []*models.Branch
 	Commits           []*models.Commit
 	StashEntries      []*models.StashEntry
+	// LostCommits is nil until the lost & found tab is opened, and again after
+	// the repo changes, because finding them means going through every object
+	// in the repo
+	LostCommits []*models.Commit
 	// Suggestions will sometimes appear when typing into a prompt
 	Suggestions []*types.Suggestion
 	// FilteredReflogCommits are the ones that appear in the reflog panel.
@@ -308,6 +364,7 @@ type guiState struct {
 	Remotes           []*models.Remote
 	RemoteBranches    []*models.RemoteBranch
 	Tags              []*models.Tag
+	Worktrees         []*models.Worktree
 	MenuItems         []*menuItem
 	Updating          bool
 	Panels            *panelStates
@@ -342,6 +399,16 @@ type guiState struct {
 
 	// flag as to whether or not the diff view should ignore whitespace
 	IgnoreWhitespaceInDiffView bool
+
+	// flag as to whether we show diffs side by side rather than unified
+	SideBySideDiff bool
+
+	// set while we're splitting a commit into several commits
+	SplitCommit *splitCommitState
+
+	// keyed by branch name, where each branch we've rewritten since last pushing
+	// it was on the remote before we started. See force_push_lease.go
+	ForcePushLeases map[string]*commands.ForceWithLease
 }
 
 // reuseState determines if we pull the repo state from our repo state map or
@@ -394,13 +461,17 @@ func (gui *Gui) resetState(filterPath string, reuseState bool) {
 			Remotes:        &remotePanelState{listPanelState{SelectedLineIdx: 0}},
 			RemoteBranches: &remoteBranchesState{listPanelState{SelectedLineIdx: -1}},
 			Tags:           &tagsPanelState{listPanelState{SelectedLineIdx: -1}},
-			Commits:        &commitPanelState{listPanelState: listPanelState{SelectedLineIdx: -1}, LimitCommits: true},
-			ReflogCommits:  &reflogCommitPanelState{listPanelState{SelectedLineIdx: 0}},
-			SubCommits:     &subCommitPanelState{listPanelState: listPanelState{SelectedLineIdx: 0}, refName: ""},
+			Worktrees:      &worktreesPanelState{listPanelState{SelectedLineIdx: -1}},
+			Commits:        &commitPanelState{listPanelState: listPanelState{SelectedLineIdx: -1}, pagination: newPaginationState()},
+			ReflogCommits:  &reflogCommitPanelState{listPanelState: listPanelState{SelectedLineIdx: 0}, pagination: newPaginationState()},
+			SubCommits:     &subCommitPanelState{listPanelState: listPanelState{SelectedLineIdx: 0}, pagination: newPaginationState(), refName: ""},
 			CommitFiles:    &commitFilesPanelState{listPanelState: listPanelState{SelectedLineIdx: -1}, refName: ""},
 			Stash:          &stashPanelState{listPanelState{SelectedLineIdx: -1}},
+			LostAndFound:   &lostAndFoundPanelState{listPanelState{SelectedLineIdx: -1}},
 			Menu:           &menuPanelState{listPanelState: listPanelState{SelectedLineIdx: 0}, OnPress: nil},
 			Suggestions:    &suggestionsPanelState{listPanelState: listPanelState{SelectedLineIdx: 0}},
+			CommitMessage:  &commitMessagePanelState{},
+			Blame:          &blamePanelState{listPanelState: listPanelState{SelectedLineIdx: 0}},
 			Merging: &MergingPanelState{
 				State:         mergeconflicts.NewState(),
 				UserScrolling: false,
@@ -411,13 +482,15 @@ func (gui *Gui) resetState(filterPath string, reuseState bool) {
 			Filtering:     filtering.New(filterPath),
 			CherryPicking: cherrypicking.New(),
 			Diffing:       diffing.New(),
+			Bisecting:     bisecting.New(),
 		},
 		ViewContextMap:    contexts.initialViewContextMap(),
 		ViewTabContextMap: contexts.initialViewTabContextMap(),
 		ScreenMode:        screenMode,
 		// TODO: put contexts in the context manager
-		ContextManager: NewContextManager(initialContext),
-		Contexts:       contexts,
+		ContextManager:  NewContextManager(initialContext),
+		Contexts:        contexts,
+		ForcePushLeases: map[string]*commands.ForceWithLease{},
 	}
 
 	gui.RepoStateMap[Repo(currentDir)] = gui.State
@@ -440,6 +513,7 @@ func NewGui(log *logrus.Entry, gitCommand *commands.GitCommand, oSCommand *oscom
 		RepoStateMap:         map[Repo]*guiState{},
 		CmdLog:               []string{},
 		ShowExtrasWindow:     config.GetUserConfig().Gui.ShowCommandLog,
+		mainViewResized:      make(chan struct{}),
 	}
 
 	gui.resetState(filterPath, false)
@@ -700,7 +774,7 @@ func (gui *Gui) startBackgroundFetch() {
 	if !isNew {
 		time.After(time.Duration(userConfig.Refresher.FetchInterval) * time.Second)
 	}
-	err := gui.fetch(false, "")
+	err := gui.fetch(gui.GitCommand, false)
 	if err != nil && strings.Contains(err.Error(), "exit status 128") && isNew {
 		_ = gui.ask(askOpts{
 			title:  gui.Tr.NoAutomaticGitFetchTitle,
@@ -708,7 +782,7 @@ func (gui *Gui) startBackgroundFetch() {
 		})
 	} else {
 		gui.goEvery(time.Second*time.Duration(userConfig.Refresher.FetchInterval), gui.stopChan, func() error {
-			err := gui.fetch(false, "")
+			err := gui.fetch(gui.GitCommand, false)
 			return err
 		})
 	}
@@ -283,6 +283,12 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Description: gui.Tr.ViewMergeRebaseOptions,
 			OpensMenu:   true,
 		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey(config.Universal.ToggleSideBySideDiff),
+			Handler:     gui.handleToggleSideBySideDiff,
+			Description: gui.Tr.LcToggleSideBySideDiff,
+		},
 		{
 			ViewName:    "",
 			Key:         gui.getKey(config.Universal.CreatePatchOptionsMenu),
@@ -330,15 +336,28 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 		{
 			ViewName:    "",
 			Key:         gui.getKey(config.Universal.Undo),
-			Handler:     gui.reflogUndo,
+			Handler:     gui.handleUndo,
 			Description: gui.Tr.LcUndoReflog,
 		},
 		{
 			ViewName:    "",
 			Key:         gui.getKey(config.Universal.Redo),
-			Handler:     gui.reflogRedo,
+			Handler:     gui.handleRedo,
 			Description: gui.Tr.LcRedoReflog,
 		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey(config.Universal.ViewOperationLog),
+			Handler:     gui.handleCreateOperationLogMenu,
+			Description: gui.Tr.LcViewOperationLog,
+			OpensMenu:   true,
+		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey(config.Universal.CancelRemoteOperation),
+			Handler:     gui.handleCancelRemoteOperation,
+			Description: gui.Tr.LcCancelRemoteOperation,
+		},
 		{
 			ViewName:    "status",
 			Key:         gui.getKey(config.Universal.Edit),
@@ -438,6 +457,13 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleFileOpen,
 			Description: gui.Tr.LcOpenFile,
 		},
+		{
+			ViewName:    "files",
+			Contexts:    []string{string(FILES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.OpenInBrowser),
+			Handler:     gui.handleBrowseFile,
+			Description: gui.Tr.LcOpenInBrowser,
+		},
 		{
 			ViewName:    "files",
 			Contexts:    []string{string(FILES_CONTEXT_KEY)},
@@ -531,6 +557,29 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleOpenMergeTool,
 			Description: gui.Tr.LcOpenMergeTool,
 		},
+		{
+			ViewName:    "files",
+			Contexts:    []string{string(FILES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Files.ViewLfsOptions),
+			Handler:     gui.handleCreateLfsOptionsMenu,
+			Description: gui.Tr.LcViewLfsOptions,
+			OpensMenu:   true,
+		},
+		{
+			ViewName:    "files",
+			Contexts:    []string{string(FILES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Files.ViewSparseCheckoutOptions),
+			Handler:     gui.handleCreateSparseCheckoutMenu,
+			Description: gui.Tr.LcViewSparseCheckoutOptions,
+			OpensMenu:   true,
+		},
+		{
+			ViewName:    "files",
+			Contexts:    []string{string(FILES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.Blame),
+			Handler:     gui.handleBlameFile,
+			Description: gui.Tr.LcBlameFile,
+		},
 		{
 			ViewName:    "branches",
 			Contexts:    []string{string(LOCAL_BRANCHES_CONTEXT_KEY)},
@@ -646,6 +695,28 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleSwitchToSubCommits,
 			Description: gui.Tr.LcViewCommits,
 		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(LOCAL_BRANCHES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Branches.CreateWorktree),
+			Handler:     gui.handleCreateWorktreeFromBranch,
+			Description: gui.Tr.LcCreateWorktreeFromBranch,
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(LOCAL_BRANCHES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Branches.SetPushRemote),
+			Handler:     gui.handleCreateSetPushRemoteMenu,
+			Description: gui.Tr.LcSetPushRemote,
+			OpensMenu:   true,
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(LOCAL_BRANCHES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.OpenInBrowser),
+			Handler:     gui.handleBrowseBranch,
+			Description: gui.Tr.LcOpenInBrowser,
+		},
 		{
 			ViewName:    "branches",
 			Contexts:    []string{string(TAGS_CONTEXT_KEY)},
@@ -674,6 +745,14 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleCreateTag,
 			Description: gui.Tr.LcCreateTag,
 		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(TAGS_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Branches.CreateAnnotatedTag),
+			Handler:     gui.handleCreateAnnotatedTagMenu,
+			Description: gui.Tr.LcCreateAnnotatedTag,
+			OpensMenu:   true,
+		},
 		{
 			ViewName:    "branches",
 			Contexts:    []string{string(TAGS_CONTEXT_KEY)},
@@ -689,6 +768,13 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleSwitchToSubCommits,
 			Description: gui.Tr.LcViewCommits,
 		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(TAGS_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.OpenInBrowser),
+			Handler:     gui.handleBrowseTag,
+			Description: gui.Tr.LcOpenInBrowser,
+		},
 		{
 			ViewName:    "branches",
 			Contexts:    []string{string(REMOTE_BRANCHES_CONTEXT_KEY)},
@@ -718,6 +804,13 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleFetchRemote,
 			Description: gui.Tr.LcFetchRemote,
 		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(REMOTES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Branches.FetchAllRemotes),
+			Handler:     gui.handleFetchAllRemotes,
+			Description: gui.Tr.LcFetchAllRemotes,
+		},
 		{
 			ViewName:    "commits",
 			Contexts:    []string{string(BRANCH_COMMITS_CONTEXT_KEY)},
@@ -795,6 +888,13 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleCommitEdit,
 			Description: gui.Tr.LcEditCommit,
 		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{string(BRANCH_COMMITS_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Commits.SplitCommit),
+			Handler:     gui.handleSplitCommit,
+			Description: gui.Tr.LcSplitCommit,
+		},
 		{
 			ViewName:    "commits",
 			Contexts:    []string{string(BRANCH_COMMITS_CONTEXT_KEY)},
@@ -873,6 +973,14 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleTagCommit,
 			Description: gui.Tr.LcTagCommit,
 		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{string(BRANCH_COMMITS_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Commits.AnnotatedTagCommit),
+			Handler:     gui.handleAnnotatedTagCommit,
+			Description: gui.Tr.LcAnnotatedTagCommit,
+			OpensMenu:   true,
+		},
 		{
 			ViewName:    "commits",
 			Contexts:    []string{string(BRANCH_COMMITS_CONTEXT_KEY)},
@@ -887,6 +995,21 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleCopySelectedCommitMessageToClipboard,
 			Description: gui.Tr.LcCopyCommitMessageToClipboard,
 		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{string(BRANCH_COMMITS_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Commits.ViewBisectOptions),
+			Handler:     gui.handleOpenBisectMenu,
+			Description: gui.Tr.LcViewBisectOptions,
+			OpensMenu:   true,
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{string(BRANCH_COMMITS_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.OpenInBrowser),
+			Handler:     gui.handleBrowseCommit,
+			Description: gui.Tr.LcOpenInBrowser,
+		},
 		{
 			ViewName:    "commits",
 			Contexts:    []string{string(REFLOG_COMMITS_CONTEXT_KEY)},
@@ -996,34 +1119,88 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 		},
 		{
 			ViewName:    "stash",
+			Contexts:    []string{string(STASH_CONTEXT_KEY)},
 			Key:         gui.getKey(config.Universal.GoInto),
 			Handler:     gui.handleViewStashFiles,
 			Description: gui.Tr.LcViewStashFiles,
 		},
 		{
 			ViewName:    "stash",
+			Contexts:    []string{string(STASH_CONTEXT_KEY)},
 			Key:         gui.getKey(config.Universal.Select),
 			Handler:     gui.handleStashApply,
 			Description: gui.Tr.LcApply,
 		},
 		{
 			ViewName:    "stash",
+			Contexts:    []string{string(STASH_CONTEXT_KEY)},
 			Key:         gui.getKey(config.Stash.PopStash),
 			Handler:     gui.handleStashPop,
 			Description: gui.Tr.LcPop,
 		},
 		{
 			ViewName:    "stash",
+			Contexts:    []string{string(STASH_CONTEXT_KEY)},
 			Key:         gui.getKey(config.Universal.Remove),
 			Handler:     gui.handleStashDrop,
 			Description: gui.Tr.LcDrop,
 		},
 		{
 			ViewName:    "stash",
+			Contexts:    []string{string(STASH_CONTEXT_KEY)},
 			Key:         gui.getKey(config.Universal.New),
 			Handler:     gui.handleNewBranchOffCurrentItem,
 			Description: gui.Tr.LcNewBranch,
 		},
+		{
+			ViewName:    "stash",
+			Contexts:    []string{string(LOST_AND_FOUND_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.GoInto),
+			Handler:     gui.handleViewLostCommitFiles,
+			Description: gui.Tr.LcViewCommitFiles,
+		},
+		{
+			ViewName:    "stash",
+			Contexts:    []string{string(LOST_AND_FOUND_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.Select),
+			Handler:     gui.handleRestoreLostStash,
+			Description: gui.Tr.LcRestoreAsStashEntry,
+		},
+		{
+			ViewName:    "stash",
+			Contexts:    []string{string(LOST_AND_FOUND_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.New),
+			Handler:     gui.handleNewBranchOffCurrentItem,
+			Description: gui.Tr.LcNewBranch,
+		},
+		{
+			ViewName:    "stash",
+			Contexts:    []string{string(LOST_AND_FOUND_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Commits.CherryPickCopy),
+			Handler:     gui.handleCopyCommit,
+			Description: gui.Tr.LcCherryPickCopy,
+		},
+		{
+			ViewName:    "stash",
+			Contexts:    []string{string(LOST_AND_FOUND_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Commits.CherryPickCopyRange),
+			Handler:     gui.handleCopyCommitRange,
+			Description: gui.Tr.LcCherryPickCopyRange,
+		},
+		{
+			ViewName:    "stash",
+			Contexts:    []string{string(LOST_AND_FOUND_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Commits.ResetCherryPick),
+			Handler:     gui.exitCherryPickingMode,
+			Description: gui.Tr.LcResetCherryPick,
+		},
+		{
+			ViewName:    "stash",
+			Contexts:    []string{string(LOST_AND_FOUND_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.CopyToClipboard),
+			Handler:     gui.handleCopySelectedSideContextItemToClipboard,
+			Description: gui.Tr.LcCopyCommitShaToClipboard,
+		},
 		{
 			ViewName: "commitMessage",
 			Key:      gui.getKey(config.Universal.SubmitEditorText),
@@ -1036,6 +1213,14 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Modifier: gocui.ModNone,
 			Handler:  gui.handleCommitClose,
 		},
+		{
+			ViewName:    "commitMessage",
+			Key:         gui.getKey(config.CommitMessage.AddTrailer),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleCommitMessageAddTrailer,
+			Description: gui.Tr.LcAddTrailer,
+			OpensMenu:   true,
+		},
 		{
 			ViewName: "credentials",
 			Key:      gui.getKey(config.Universal.Confirm),
@@ -1084,12 +1269,24 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleOpenOldCommitFile,
 			Description: gui.Tr.LcOpenFile,
 		},
+		{
+			ViewName:    "commitFiles",
+			Key:         gui.getKey(config.Universal.OpenInBrowser),
+			Handler:     gui.handleBrowseCommitFile,
+			Description: gui.Tr.LcOpenInBrowser,
+		},
 		{
 			ViewName:    "commitFiles",
 			Key:         gui.getKey(config.Universal.Edit),
 			Handler:     gui.handleEditCommitFile,
 			Description: gui.Tr.LcEditFile,
 		},
+		{
+			ViewName:    "commitFiles",
+			Key:         gui.getKey(config.Universal.Blame),
+			Handler:     gui.handleBlameCommitFile,
+			Description: gui.Tr.LcBlameFile,
+		},
 		{
 			ViewName:    "commitFiles",
 			Key:         gui.getKey(config.Universal.Select),
@@ -1189,9 +1386,16 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			ViewName:    "main",
 			Contexts:    []string{string(MAIN_STAGING_CONTEXT_KEY)},
 			Key:         gui.getKey(config.Universal.Return),
-			Handler:     gui.handleStagingEscape,
+			Handler:     gui.handleStagingReturn,
 			Description: gui.Tr.ReturnToFilesPanel,
 		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{string(MAIN_STAGING_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Main.ToggleWordDiff),
+			Handler:     gui.handleToggleWordDiff,
+			Description: gui.Tr.LcToggleWordDiff,
+		},
 		{
 			ViewName:    "main",
 			Contexts:    []string{string(MAIN_STAGING_CONTEXT_KEY)},
@@ -1213,6 +1417,14 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleTogglePanel,
 			Description: gui.Tr.TogglePanel,
 		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{string(MAIN_STAGING_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Files.ViewStashOptions),
+			Handler:     gui.handleCreateStashMenu,
+			Description: gui.Tr.LcViewStashOptions,
+			OpensMenu:   true,
+		},
 		{
 			ViewName:    "main",
 			Contexts:    []string{string(MAIN_PATCH_BUILDING_CONTEXT_KEY)},
@@ -1227,6 +1439,13 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleOpenFileAtLine,
 			Description: gui.Tr.LcOpenFile,
 		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{string(MAIN_PATCH_BUILDING_CONTEXT_KEY), string(MAIN_STAGING_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.OpenInBrowser),
+			Handler:     gui.handleBrowseSelectedLine,
+			Description: gui.Tr.LcOpenInBrowser,
+		},
 		{
 			ViewName:    "main",
 			Contexts:    []string{string(MAIN_PATCH_BUILDING_CONTEXT_KEY), string(MAIN_STAGING_CONTEXT_KEY)},
@@ -1461,6 +1680,20 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handlePickBothHunks,
 			Description: gui.Tr.PickBothHunks,
 		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{string(MAIN_MERGING_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Main.PickBaseHunk),
+			Handler:     gui.handlePickBaseHunk,
+			Description: gui.Tr.PickBaseHunk,
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{string(MAIN_MERGING_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Main.PickAllHunks),
+			Handler:     gui.handlePickAllHunks,
+			Description: gui.Tr.PickAllHunks,
+		},
 		{
 			ViewName:    "main",
 			Contexts:    []string{string(MAIN_MERGING_CONTEXT_KEY)},
@@ -1538,6 +1771,55 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handlePopFileSnapshot,
 			Description: gui.Tr.LcUndo,
 		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{string(MAIN_BLAME_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.Return),
+			Handler:     gui.handleEscapeBlame,
+			Description: gui.Tr.LcReturn,
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{string(MAIN_BLAME_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.PrevItem),
+			Handler:     gui.handleBlamePrevLine,
+			Description: gui.Tr.PrevLine,
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{string(MAIN_BLAME_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.NextItem),
+			Handler:     gui.handleBlameNextLine,
+			Description: gui.Tr.NextLine,
+		},
+		{
+			ViewName: "main",
+			Contexts: []string{string(MAIN_BLAME_CONTEXT_KEY)},
+			Key:      gui.getKey(config.Universal.PrevItemAlt),
+			Modifier: gocui.ModNone,
+			Handler:  gui.handleBlamePrevLine,
+		},
+		{
+			ViewName: "main",
+			Contexts: []string{string(MAIN_BLAME_CONTEXT_KEY)},
+			Key:      gui.getKey(config.Universal.NextItemAlt),
+			Modifier: gocui.ModNone,
+			Handler:  gui.handleBlameNextLine,
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{string(MAIN_BLAME_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.GoInto),
+			Handler:     gui.handleBlameViewCommit,
+			Description: gui.Tr.LcViewCommitOfLine,
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{string(MAIN_BLAME_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Main.BlameParent),
+			Handler:     gui.handleBlameParent,
+			Description: gui.Tr.LcBlameParent,
+		},
 		{
 			ViewName: "branches",
 			Contexts: []string{string(REMOTES_CONTEXT_KEY)},
@@ -1727,6 +2009,34 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Description: gui.Tr.LcViewBulkSubmoduleOptions,
 			OpensMenu:   true,
 		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(WORKTREES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.Select),
+			Handler:     gui.withSelectedWorktree(gui.handleSwitchToWorktree),
+			Description: gui.Tr.LcSwitchToWorktree,
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(WORKTREES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.New),
+			Handler:     gui.handleCreateWorktree,
+			Description: gui.Tr.LcCreateWorktree,
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(WORKTREES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.Remove),
+			Handler:     gui.withSelectedWorktree(gui.handleRemoveWorktree),
+			Description: gui.Tr.LcRemoveWorktree,
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(WORKTREES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Worktrees.Prune),
+			Handler:     gui.handlePruneWorktrees,
+			Description: gui.Tr.LcPruneWorktrees,
+		},
 		{
 			ViewName:    "files",
 			Contexts:    []string{string(FILES_CONTEXT_KEY)},
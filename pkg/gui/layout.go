@@ -285,6 +285,7 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 	if mainViewWidth != gui.State.PrevMainWidth || mainViewHeight != gui.State.PrevMainHeight {
 		gui.State.PrevMainWidth = mainViewWidth
 		gui.State.PrevMainHeight = mainViewHeight
+		gui.notifyMainViewResized()
 		if err := gui.onResize(); err != nil {
 			return err
 		}
@@ -377,6 +378,12 @@ func (gui *Gui) onInitialViewsCreation() error {
 		return err
 	}
 
+	if gui.runningScript() {
+		// there's nobody to show popups to, and a script shouldn't update lazygit
+		gui.waitForIntro.Done()
+		return nil
+	}
+
 	if !gui.Config.GetUserConfig().DisableStartupPopups {
 		popupTasks := []func(chan struct{}) error{}
 		storedPopupVersion := gui.Config.GetAppState().StartupPopupVersion
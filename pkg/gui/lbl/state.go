@@ -11,6 +11,8 @@ type State struct {
 	diff              string
 	patchParser       *patch.PatchParser
 	selectMode        selectMode
+	// only worked out if we render the patch side by side
+	sideBySideRows []*patch.SideBySideRow
 }
 
 // these represent what select mode we're in
@@ -195,3 +197,34 @@ func (s *State) CalculateOrigin(currentOrigin int, bufferHeight int) int {
 
 	return calculateOrigin(currentOrigin, bufferHeight, firstLineIdx, lastLineIdx, s.GetSelectedLineIdx(), s.selectMode)
 }
+
+func (s *State) getSideBySideRows() []*patch.SideBySideRow {
+	if s.sideBySideRows == nil {
+		s.sideBySideRows = s.patchParser.SideBySideRows()
+	}
+
+	return s.sideBySideRows
+}
+
+// RenderSideBySide is like RenderForLineIndices but puts the old and new
+// versions of each hunk side by side, meaning that rows of the view don't match
+// up with lines of the patch. Use SideBySideRowForLine and LineForSideBySideRow
+// to convert between the two.
+func (s *State) RenderSideBySide(width int, includedLineIndices []int) string {
+	firstLineIdx, lastLineIdx := s.SelectedRange()
+	return s.patchParser.RenderSideBySide(s.getSideBySideRows(), width, firstLineIdx, lastLineIdx, includedLineIndices)
+}
+
+func (s *State) SideBySideRowForLine(lineIdx int) int {
+	return patch.SideBySideRowForLine(s.getSideBySideRows(), lineIdx)
+}
+
+func (s *State) LineForSideBySideRow(rowIdx int) int {
+	return patch.SideBySideLineForRow(s.getSideBySideRows(), rowIdx)
+}
+
+func (s *State) CalculateSideBySideOrigin(currentOrigin int, bufferHeight int) int {
+	firstLineIdx, lastLineIdx := s.SelectedRange()
+
+	return calculateOrigin(currentOrigin, bufferHeight, s.SideBySideRowForLine(firstLineIdx), s.SideBySideRowForLine(lastLineIdx), s.SideBySideRowForLine(s.selectedLineIdx), s.selectMode)
+}
@@ -0,0 +1,112 @@
+package lbl
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands/patch"
+)
+
+// WordDiffState is the state of the staging panel while it shows the word diff
+// of a block of changed lines, where each line of the view is a segment of the
+// word diff rather than a line of the file
+type WordDiffState struct {
+	wordDiff      *patch.WordDiff
+	selectedIdx   int
+	rangeStartIdx int
+	selectMode    selectMode
+}
+
+// NewWordDiffState returns nil if the given line of the diff isn't part of a
+// block of changed lines
+func NewWordDiffState(diff string, lineIdx int) *WordDiffState {
+	wordDiff := patch.NewWordDiff(diff, lineIdx)
+	if wordDiff == nil || len(wordDiff.ChangedSegments) == 0 {
+		return nil
+	}
+
+	return &WordDiffState{
+		wordDiff:    wordDiff,
+		selectedIdx: wordDiff.ChangedSegments[0],
+		selectMode:  LINE,
+	}
+}
+
+func (s *WordDiffState) GetSelectedIdx() int {
+	return s.selectedIdx
+}
+
+func (s *WordDiffState) ToggleSelectRange() {
+	if s.selectMode == RANGE {
+		s.selectMode = LINE
+	} else {
+		s.selectMode = RANGE
+		s.rangeStartIdx = s.selectedIdx
+	}
+}
+
+func (s *WordDiffState) SelectingRange() bool {
+	return s.selectMode == RANGE
+}
+
+func (s *WordDiffState) SetLineSelectMode() {
+	s.selectMode = LINE
+}
+
+func (s *WordDiffState) SelectSegment(newSelectedIdx int) {
+	if newSelectedIdx < 0 {
+		newSelectedIdx = 0
+	} else if newSelectedIdx > len(s.wordDiff.Segments)-1 {
+		newSelectedIdx = len(s.wordDiff.Segments) - 1
+	}
+
+	s.selectedIdx = newSelectedIdx
+}
+
+func (s *WordDiffState) SelectNewSegmentForRange(newSelectedIdx int) {
+	s.rangeStartIdx = newSelectedIdx
+
+	s.selectMode = RANGE
+
+	s.SelectSegment(newSelectedIdx)
+}
+
+func (s *WordDiffState) CycleSelection(forward bool) {
+	change := 1
+	if !forward {
+		change = -1
+	}
+
+	s.SelectSegment(s.selectedIdx + change)
+}
+
+func (s *WordDiffState) SelectedRange() (int, int) {
+	if s.selectMode == RANGE {
+		if s.rangeStartIdx > s.selectedIdx {
+			return s.selectedIdx, s.rangeStartIdx
+		}
+		return s.rangeStartIdx, s.selectedIdx
+	}
+
+	return s.selectedIdx, s.selectedIdx
+}
+
+// ModifiedPatch returns the patch for staging (or, if reverse is true,
+// unstaging) the selected segments
+func (s *WordDiffState) ModifiedPatch(filename string, reverse bool) string {
+	firstIdx, lastIdx := s.SelectedRange()
+	segmentIndices := []int{}
+	for i := firstIdx; i <= lastIdx; i++ {
+		segmentIndices = append(segmentIndices, i)
+	}
+
+	return s.wordDiff.ModifiedPatchForSegments(filename, segmentIndices, reverse)
+}
+
+func (s *WordDiffState) Render() string {
+	firstIdx, lastIdx := s.SelectedRange()
+	return s.wordDiff.Render(firstIdx, lastIdx)
+}
+
+func (s *WordDiffState) CalculateOrigin(currentOrigin int, bufferHeight int) int {
+	firstIdx, lastIdx := s.SelectedRange()
+
+	return calculateOrigin(currentOrigin, bufferHeight, firstIdx, lastIdx, s.selectedIdx, s.selectMode)
+}
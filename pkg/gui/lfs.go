@@ -0,0 +1,164 @@
+package gui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// fileDiffTask is like diffTask but for the diff of a single file. A file
+// stored with Git LFS only has a pointer in the repo, so we render its diff
+// ourselves in order to summarise the change to the pointer.
+func (gui *Gui) fileDiffTask(isLfs bool, getCmdStr func(plain bool) string) updateTask {
+	if isLfs {
+		return NewRenderDiffTask(getCmdStr(true), gui.State.SideBySideDiff)
+	}
+
+	return gui.diffTask(getCmdStr)
+}
+
+// lfsDiffSummary returns a one-line summary if the diff is of an LFS pointer
+// file
+func (gui *Gui) lfsDiffSummary(diff string) (string, bool) {
+	oldPointer, newPointer, ok := commands.ParseLfsPointerDiff(diff)
+	if !ok {
+		return "", false
+	}
+
+	switch {
+	case oldPointer == nil:
+		return utils.ResolvePlaceholderString(gui.Tr.LfsObjectAdded, map[string]string{
+			"new": formatLfsPointer(newPointer),
+		}), true
+	case newPointer == nil:
+		return utils.ResolvePlaceholderString(gui.Tr.LfsObjectDeleted, map[string]string{
+			"old": formatLfsPointer(oldPointer),
+		}), true
+	default:
+		return utils.ResolvePlaceholderString(gui.Tr.LfsObjectChanged, map[string]string{
+			"old": formatLfsPointer(oldPointer),
+			"new": formatLfsPointer(newPointer),
+		}), true
+	}
+}
+
+// formatLfsPointer returns something like 'sha256:4d7a2146b0ab/1.5 MB'
+func formatLfsPointer(pointer *commands.LfsPointer) string {
+	oid := pointer.Oid
+	if split := strings.SplitN(oid, ":", 2); len(split) == 2 {
+		oid = split[0] + ":" + utils.SafeTruncate(split[1], 12)
+	}
+
+	return oid + "/" + formatByteSize(pointer.Size)
+}
+
+func formatByteSize(sizeStr string) string {
+	size, err := strconv.ParseFloat(sizeStr, 64)
+	if err != nil {
+		return sizeStr
+	}
+
+	units := []string{"B", "KB", "MB", "GB"}
+	unitIdx := 0
+	for size >= 1024 && unitIdx < len(units)-1 {
+		size /= 1024
+		unitIdx++
+	}
+
+	if unitIdx == 0 {
+		return fmt.Sprintf("%d %s", int(size), units[unitIdx])
+	}
+	return fmt.Sprintf("%.1f %s", size, units[unitIdx])
+}
+
+func (gui *Gui) handleCreateLfsOptionsMenu() error {
+	node := gui.getSelectedFileNode()
+
+	return gui.WithWaitingStatus(gui.Tr.LcLoadingLfsLocks, func() error {
+		locks, err := gui.GitCommand.GetLfsLocks()
+		if err != nil {
+			return err
+		}
+
+		menuItems := []*menuItem{}
+
+		if node != nil && node.File != nil && node.File.IsLfs {
+			path := node.GetPath()
+			var lock *commands.LfsLock
+			for _, l := range locks {
+				if l.Path == path {
+					lock = l
+				}
+			}
+
+			if lock == nil {
+				menuItems = append(menuItems, &menuItem{
+					displayStrings: []string{gui.Tr.LcLfsLockFile, path},
+					onPress: func() error {
+						return gui.lfsLockAction(gui.Tr.LcLockingLfsFile, func() error {
+							return gui.GitCommand.WithSpan(gui.Tr.Spans.LfsLock).LfsLock(path)
+						})
+					},
+				})
+			} else {
+				menuItems = append(menuItems, &menuItem{
+					displayStrings: []string{gui.Tr.LcLfsUnlockFile, path},
+					onPress: func() error {
+						return gui.lfsLockAction(gui.Tr.LcUnlockingLfsFile, func() error {
+							return gui.GitCommand.WithSpan(gui.Tr.Spans.LfsUnlock).LfsUnlock(path, false)
+						})
+					},
+				}, &menuItem{
+					displayStrings: []string{gui.Tr.LcLfsForceUnlockFile, path},
+					onPress: func() error {
+						return gui.ask(askOpts{
+							title:  gui.Tr.LfsForceUnlockTitle,
+							prompt: utils.ResolvePlaceholderString(gui.Tr.LfsForceUnlockPrompt, map[string]string{"owner": lock.Owner}),
+							handleConfirm: func() error {
+								return gui.lfsLockAction(gui.Tr.LcUnlockingLfsFile, func() error {
+									return gui.GitCommand.WithSpan(gui.Tr.Spans.LfsUnlock).LfsUnlock(path, true)
+								})
+							},
+						})
+					},
+				})
+			}
+		}
+
+		// listing every lock and who owns it
+		for _, lock := range locks {
+			menuItems = append(menuItems, &menuItem{
+				displayStrings: []string{
+					lock.Path,
+					utils.ResolvePlaceholderString(gui.Tr.LfsLockedBy, map[string]string{"owner": lock.Owner}),
+				},
+				onPress: func() error {
+					return nil
+				},
+			})
+		}
+
+		if len(menuItems) == 0 {
+			gui.raiseToast(gui.Tr.NoLfsLocks)
+			return nil
+		}
+
+		gui.g.Update(func(*gocui.Gui) error {
+			return gui.createMenu(gui.Tr.LfsOptionsTitle, menuItems, createMenuOptions{showCancel: true})
+		})
+		return nil
+	})
+}
+
+func (gui *Gui) lfsLockAction(status string, f func() error) error {
+	return gui.WithWaitingStatus(status, func() error {
+		err := f()
+		gui.handleCredentialsPopup(err)
+
+		return nil
+	})
+}
@@ -31,9 +31,17 @@ func (gui *Gui) refreshLineByLinePanel(diff string, secondaryDiff string, second
 		return true, nil
 	}
 
+	var wordDiffState *lbl.WordDiffState
+	// if we were staging words we stay that way, as long as the selected line
+	// is still a changed line
+	if gui.State.Panels.LineByLine != nil && gui.State.Panels.LineByLine.WordDiff != nil {
+		wordDiffState = lbl.NewWordDiffState(diff, state.GetSelectedLineIdx())
+	}
+
 	gui.State.Panels.LineByLine = &LblPanelState{
 		State:            state,
 		SecondaryFocused: secondaryFocused,
+		WordDiff:         wordDiffState,
 	}
 
 	if err := gui.refreshMainViewForLineByLine(gui.State.Panels.LineByLine); err != nil {
@@ -56,7 +64,11 @@ func (gui *Gui) refreshLineByLinePanel(diff string, secondaryDiff string, second
 
 func (gui *Gui) handleSelectPrevLine() error {
 	return gui.withLBLActiveCheck(func(state *LblPanelState) error {
-		state.CycleSelection(false)
+		if state.WordDiff != nil {
+			state.WordDiff.CycleSelection(false)
+		} else {
+			state.CycleSelection(false)
+		}
 
 		return gui.refreshAndFocusLblPanel(state)
 	})
@@ -64,7 +76,11 @@ func (gui *Gui) handleSelectPrevLine() error {
 
 func (gui *Gui) handleSelectNextLine() error {
 	return gui.withLBLActiveCheck(func(state *LblPanelState) error {
-		state.CycleSelection(true)
+		if state.WordDiff != nil {
+			state.WordDiff.CycleSelection(true)
+		} else {
+			state.CycleSelection(true)
+		}
 
 		return gui.refreshAndFocusLblPanel(state)
 	})
@@ -72,6 +88,7 @@ func (gui *Gui) handleSelectNextLine() error {
 
 func (gui *Gui) handleSelectPrevHunk() error {
 	return gui.withLBLActiveCheck(func(state *LblPanelState) error {
+		state.WordDiff = nil
 		state.CycleHunk(false)
 
 		return gui.refreshAndFocusLblPanel(state)
@@ -80,6 +97,7 @@ func (gui *Gui) handleSelectPrevHunk() error {
 
 func (gui *Gui) handleSelectNextHunk() error {
 	return gui.withLBLActiveCheck(func(state *LblPanelState) error {
+		state.WordDiff = nil
 		state.CycleHunk(true)
 
 		return gui.refreshAndFocusLblPanel(state)
@@ -100,7 +118,11 @@ func (gui *Gui) handleLBLMouseDown() error {
 			return nil
 		}
 
-		state.SelectNewLineForRange(gui.Views.Main.SelectedLineIdx())
+		if state.WordDiff != nil {
+			state.WordDiff.SelectNewSegmentForRange(gui.Views.Main.SelectedLineIdx())
+		} else {
+			state.SelectNewLineForRange(gui.lineIdxForMainViewRow(state, gui.Views.Main.SelectedLineIdx()))
+		}
 
 		return gui.refreshAndFocusLblPanel(state)
 	})
@@ -112,12 +134,32 @@ func (gui *Gui) handleMouseDrag() error {
 			return nil
 		}
 
-		state.SelectLine(gui.Views.Main.SelectedLineIdx())
+		if state.WordDiff != nil {
+			state.WordDiff.SelectSegment(gui.Views.Main.SelectedLineIdx())
+		} else {
+			state.SelectLine(gui.lineIdxForMainViewRow(state, gui.Views.Main.SelectedLineIdx()))
+		}
 
 		return gui.refreshAndFocusLblPanel(state)
 	})
 }
 
+// showingSideBySideLineByLine tells us whether the patch is rendered side by
+// side, which we only support when building a patch
+func (gui *Gui) showingSideBySideLineByLine() bool {
+	return gui.State.SideBySideDiff && gui.State.MainContext == MAIN_PATCH_BUILDING_CONTEXT_KEY
+}
+
+// lineIdxForMainViewRow returns the index of the patch line shown in the given
+// row of the main view
+func (gui *Gui) lineIdxForMainViewRow(state *LblPanelState, rowIdx int) int {
+	if gui.showingSideBySideLineByLine() {
+		return state.LineForSideBySideRow(rowIdx)
+	}
+
+	return rowIdx
+}
+
 func (gui *Gui) getSelectedCommitFileName() string {
 	idx := gui.State.Panels.CommitFiles.SelectedLineIdx
 
@@ -136,7 +178,15 @@ func (gui *Gui) refreshMainViewForLineByLine(state *LblPanelState) error {
 			return err
 		}
 	}
-	colorDiff := state.RenderForLineIndices(includedLineIndices)
+	var colorDiff string
+	if state.WordDiff != nil {
+		colorDiff = state.WordDiff.Render()
+	} else if gui.showingSideBySideLineByLine() {
+		width, _ := gui.Views.Main.Size()
+		colorDiff = state.RenderSideBySide(width, includedLineIndices)
+	} else {
+		colorDiff = state.RenderForLineIndices(includedLineIndices)
+	}
 
 	gui.Views.Main.Highlight = true
 	gui.Views.Main.Wrap = false
@@ -155,9 +205,18 @@ func (gui *Gui) focusSelection(state *LblPanelState) error {
 	bufferHeight := viewHeight - 1
 	_, origin := stagingView.Origin()
 
-	selectedLineIdx := state.GetSelectedLineIdx()
-
-	newOrigin := state.CalculateOrigin(origin, bufferHeight)
+	var selectedLineIdx int
+	var newOrigin int
+	if state.WordDiff != nil {
+		selectedLineIdx = state.WordDiff.GetSelectedIdx()
+		newOrigin = state.WordDiff.CalculateOrigin(origin, bufferHeight)
+	} else if gui.showingSideBySideLineByLine() {
+		selectedLineIdx = state.SideBySideRowForLine(state.GetSelectedLineIdx())
+		newOrigin = state.CalculateSideBySideOrigin(origin, bufferHeight)
+	} else {
+		selectedLineIdx = state.GetSelectedLineIdx()
+		newOrigin = state.CalculateOrigin(origin, bufferHeight)
+	}
 
 	gui.g.Update(func(*gocui.Gui) error {
 		if err := stagingView.SetOrigin(0, newOrigin); err != nil {
@@ -172,7 +231,11 @@ func (gui *Gui) focusSelection(state *LblPanelState) error {
 
 func (gui *Gui) handleToggleSelectRange() error {
 	return gui.withLBLActiveCheck(func(state *LblPanelState) error {
-		state.ToggleSelectRange()
+		if state.WordDiff != nil {
+			state.WordDiff.ToggleSelectRange()
+		} else {
+			state.ToggleSelectRange()
+		}
 
 		return gui.refreshMainViewForLineByLine(state)
 	})
@@ -180,6 +243,7 @@ func (gui *Gui) handleToggleSelectRange() error {
 
 func (gui *Gui) handleToggleSelectHunk() error {
 	return gui.withLBLActiveCheck(func(state *LblPanelState) error {
+		state.WordDiff = nil
 		state.ToggleSelectHunk()
 
 		return gui.refreshAndFocusLblPanel(state)
@@ -220,6 +284,7 @@ func (gui *Gui) handleOpenFileAtLine() error {
 
 func (gui *Gui) handleLineByLineNextPage() error {
 	return gui.withLBLActiveCheck(func(state *LblPanelState) error {
+		state.WordDiff = nil
 		state.SetLineSelectMode()
 		state.AdjustSelectedLineIdx(gui.pageDelta(gui.Views.Main))
 
@@ -229,6 +294,7 @@ func (gui *Gui) handleLineByLineNextPage() error {
 
 func (gui *Gui) handleLineByLinePrevPage() error {
 	return gui.withLBLActiveCheck(func(state *LblPanelState) error {
+		state.WordDiff = nil
 		state.SetLineSelectMode()
 		state.AdjustSelectedLineIdx(-gui.pageDelta(gui.Views.Main))
 
@@ -238,6 +304,7 @@ func (gui *Gui) handleLineByLinePrevPage() error {
 
 func (gui *Gui) handleLineByLineGotoBottom() error {
 	return gui.withLBLActiveCheck(func(state *LblPanelState) error {
+		state.WordDiff = nil
 		state.SelectBottom()
 
 		return gui.refreshAndFocusLblPanel(state)
@@ -246,6 +313,7 @@ func (gui *Gui) handleLineByLineGotoBottom() error {
 
 func (gui *Gui) handleLineByLineGotoTop() error {
 	return gui.withLBLActiveCheck(func(state *LblPanelState) error {
+		state.WordDiff = nil
 		state.SelectTop()
 
 		return gui.refreshAndFocusLblPanel(state)
@@ -254,6 +322,7 @@ func (gui *Gui) handleLineByLineGotoTop() error {
 
 func (gui *Gui) handlelineByLineNavigateTo(selectedLineIdx int) error {
 	return gui.withLBLActiveCheck(func(state *LblPanelState) error {
+		state.WordDiff = nil
 		state.SetLineSelectMode()
 		state.SelectLine(selectedLineIdx)
 
@@ -272,3 +341,33 @@ func (gui *Gui) withLBLActiveCheck(f func(*LblPanelState) error) error {
 
 	return f(state)
 }
+
+// handleStashSelectedLines stashes the selected lines of the file being
+// staged, leaving the rest of its changes in the working tree
+func (gui *Gui) handleStashSelectedLines() error {
+	return gui.withLBLActiveCheck(func(state *LblPanelState) error {
+		file := gui.getSelectedFile()
+		if file == nil {
+			return nil
+		}
+
+		if state.SecondaryFocused {
+			return gui.createErrorPanel(gui.Tr.StashLinesOnlyUnstaged)
+		}
+
+		if !file.Tracked || file.HasStagedChanges {
+			return gui.createErrorPanel(gui.Tr.StashLinesRequiresNoStagedChanges)
+		}
+
+		// we hold onto the selection here because the panel's state may well be
+		// replaced by the time the user has typed the stash message
+		firstLineIdx, lastLineIdx := state.SelectedRange()
+		diff := state.GetDiff()
+
+		return gui.promptForStashMessage(func(message string) error {
+			return gui.GitCommand.WithSpan(gui.Tr.Spans.StashSelectedLines).StashSaveLines(message, file.Name, diff, firstLineIdx, lastLineIdx)
+		}, func() error {
+			return gui.handleRefreshStagingPanel(false, -1)
+		})
+	})
+}
@@ -69,7 +69,7 @@ func (gui *Gui) branchesListContext() *ListContext {
 		Gui:                        gui,
 		ResetMainViewOriginOnFocus: true,
 		GetDisplayStrings: func() [][]string {
-			return presentation.GetBranchListDisplayStrings(gui.State.Branches, gui.State.ScreenMode != SCREEN_NORMAL, gui.State.Modes.Diffing.Ref)
+			return presentation.GetBranchListDisplayStrings(gui.State.Branches, gui.State.Worktrees, gui.State.ScreenMode != SCREEN_NORMAL, gui.State.Modes.Diffing.Ref)
 		},
 		SelectedItem: func() (ListItem, bool) {
 			item := gui.getSelectedBranch()
@@ -139,7 +139,7 @@ func (gui *Gui) tagsListContext() *ListContext {
 		Gui:                        gui,
 		ResetMainViewOriginOnFocus: true,
 		GetDisplayStrings: func() [][]string {
-			return presentation.GetTagListDisplayStrings(gui.State.Tags, gui.State.Modes.Diffing.Ref)
+			return presentation.GetTagListDisplayStrings(gui.State.Tags, gui.State.ScreenMode != SCREEN_NORMAL, gui.State.Modes.Diffing.Ref)
 		},
 		SelectedItem: func() (ListItem, bool) {
 			item := gui.getSelectedTag()
@@ -164,12 +164,16 @@ func (gui *Gui) branchCommitsListContext() *ListContext {
 		Gui:                        gui,
 		ResetMainViewOriginOnFocus: true,
 		GetDisplayStrings: func() [][]string {
-			return presentation.GetCommitListDisplayStrings(
-				gui.State.Commits,
-				gui.State.ScreenMode != SCREEN_NORMAL,
-				gui.cherryPickedCommitShaMap(),
-				gui.State.Modes.Diffing.Ref,
-				parseEmoji,
+			return gui.withLoadingMoreRow(
+				presentation.GetCommitListDisplayStrings(
+					gui.State.Commits,
+					gui.State.ScreenMode != SCREEN_NORMAL,
+					gui.cherryPickedCommitShaMap(),
+					gui.State.Modes.Diffing.Ref,
+					parseEmoji,
+					gui.State.Modes.Bisecting.GetInfo(),
+				),
+				&gui.State.Panels.Commits.pagination,
 			)
 		},
 		SelectedItem: func() (ListItem, bool) {
@@ -194,12 +198,15 @@ func (gui *Gui) reflogCommitsListContext() *ListContext {
 		Gui:                        gui,
 		ResetMainViewOriginOnFocus: true,
 		GetDisplayStrings: func() [][]string {
-			return presentation.GetReflogCommitListDisplayStrings(
-				gui.State.FilteredReflogCommits,
-				gui.State.ScreenMode != SCREEN_NORMAL,
-				gui.cherryPickedCommitShaMap(),
-				gui.State.Modes.Diffing.Ref,
-				parseEmoji,
+			return gui.withLoadingMoreRow(
+				presentation.GetReflogCommitListDisplayStrings(
+					gui.State.FilteredReflogCommits,
+					gui.State.ScreenMode != SCREEN_NORMAL,
+					gui.cherryPickedCommitShaMap(),
+					gui.State.Modes.Diffing.Ref,
+					parseEmoji,
+				),
+				&gui.State.Panels.ReflogCommits.pagination,
 			)
 		},
 		SelectedItem: func() (ListItem, bool) {
@@ -224,12 +231,16 @@ func (gui *Gui) subCommitsListContext() *ListContext {
 		Gui:                        gui,
 		ResetMainViewOriginOnFocus: true,
 		GetDisplayStrings: func() [][]string {
-			return presentation.GetCommitListDisplayStrings(
-				gui.State.SubCommits,
-				gui.State.ScreenMode != SCREEN_NORMAL,
-				gui.cherryPickedCommitShaMap(),
-				gui.State.Modes.Diffing.Ref,
-				parseEmoji,
+			return gui.withLoadingMoreRow(
+				presentation.GetCommitListDisplayStrings(
+					gui.State.SubCommits,
+					gui.State.ScreenMode != SCREEN_NORMAL,
+					gui.cherryPickedCommitShaMap(),
+					gui.State.Modes.Diffing.Ref,
+					parseEmoji,
+					gui.State.Modes.Bisecting.GetInfo(),
+				),
+				&gui.State.Panels.SubCommits.pagination,
 			)
 		},
 		SelectedItem: func() (ListItem, bool) {
@@ -262,6 +273,36 @@ func (gui *Gui) stashListContext() *ListContext {
 	}
 }
 
+func (gui *Gui) lostAndFoundListContext() *ListContext {
+	parseEmoji := gui.Config.GetUserConfig().Git.ParseEmoji
+	return &ListContext{
+		BasicContext: &BasicContext{
+			ViewName:   "stash",
+			WindowName: "stash",
+			Key:        LOST_AND_FOUND_CONTEXT_KEY,
+			Kind:       SIDE_CONTEXT,
+		},
+		GetItemsLength:             func() int { return len(gui.State.LostCommits) },
+		GetPanelState:              func() IListPanelState { return gui.State.Panels.LostAndFound },
+		OnFocus:                    gui.handleLostCommitSelect,
+		Gui:                        gui,
+		ResetMainViewOriginOnFocus: true,
+		GetDisplayStrings: func() [][]string {
+			return presentation.GetLostCommitListDisplayStrings(
+				gui.State.LostCommits,
+				gui.State.ScreenMode != SCREEN_NORMAL,
+				gui.cherryPickedCommitShaMap(),
+				gui.State.Modes.Diffing.Ref,
+				parseEmoji,
+			)
+		},
+		SelectedItem: func() (ListItem, bool) {
+			item := gui.getSelectedLostCommit()
+			return item, item != nil
+		},
+	}
+}
+
 func (gui *Gui) commitFilesListContext() *ListContext {
 	return &ListContext{
 		BasicContext: &BasicContext{
@@ -318,6 +359,30 @@ func (gui *Gui) submodulesListContext() *ListContext {
 	}
 }
 
+func (gui *Gui) worktreesListContext() *ListContext {
+	return &ListContext{
+		BasicContext: &BasicContext{
+			ViewName:   "branches",
+			WindowName: "branches",
+			Key:        WORKTREES_CONTEXT_KEY,
+			Kind:       SIDE_CONTEXT,
+		},
+		GetItemsLength:             func() int { return len(gui.State.Worktrees) },
+		GetPanelState:              func() IListPanelState { return gui.State.Panels.Worktrees },
+		OnFocus:                    gui.handleWorktreeSelect,
+		OnClickSelectedItem:        gui.withSelectedWorktree(gui.handleSwitchToWorktree),
+		Gui:                        gui,
+		ResetMainViewOriginOnFocus: true,
+		GetDisplayStrings: func() [][]string {
+			return presentation.GetWorktreeListDisplayStrings(gui.State.Worktrees, gui.State.ScreenMode != SCREEN_NORMAL)
+		},
+		SelectedItem: func() (ListItem, bool) {
+			item := gui.getSelectedWorktree()
+			return item, item != nil
+		},
+	}
+}
+
 func (gui *Gui) suggestionsListContext() *ListContext {
 	return &ListContext{
 		BasicContext: &BasicContext{
@@ -345,11 +410,13 @@ func (gui *Gui) getListContexts() []*ListContext {
 		gui.State.Contexts.Remotes,
 		gui.State.Contexts.RemoteBranches,
 		gui.State.Contexts.Tags,
+		gui.State.Contexts.Worktrees,
 		gui.State.Contexts.BranchCommits,
 		gui.State.Contexts.BranchCommits,
 		gui.State.Contexts.ReflogCommits,
 		gui.State.Contexts.SubCommits,
 		gui.State.Contexts.Stash,
+		gui.State.Contexts.LostAndFound,
 		gui.State.Contexts.CommitFiles,
 		gui.State.Contexts.Submodules,
 		gui.State.Contexts.Suggestions,
@@ -0,0 +1,103 @@
+package gui
+
+import (
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+)
+
+// list panel functions
+
+func (gui *Gui) getSelectedLostCommit() *models.Commit {
+	selectedLine := gui.State.Panels.LostAndFound.SelectedLineIdx
+	lostCommits := gui.State.LostCommits
+	if selectedLine == -1 || len(lostCommits) == 0 {
+		return nil
+	}
+
+	return lostCommits[selectedLine]
+}
+
+func (gui *Gui) handleLostCommitSelect() error {
+	if gui.State.LostCommits == nil {
+		// we only go looking for lost commits when the tab is opened because it
+		// can take a while in a big repo
+		return gui.WithWaitingStatus(gui.Tr.LcFindingLostCommits, gui.refreshLostCommits)
+	}
+
+	commit := gui.getSelectedLostCommit()
+	var task updateTask
+	if commit == nil {
+		task = NewRenderStringTask(gui.Tr.NoLostCommits)
+	} else {
+		task = gui.diffTask(func(plain bool) string {
+			return gui.GitCommand.ShowLostCommitCmdStr(commit, plain)
+		})
+	}
+
+	return gui.refreshMainViews(refreshMainOpts{
+		main: &viewUpdateOpts{
+			title: "Lost Commit",
+			task:  task,
+		},
+	})
+}
+
+// forgetLostCommits is called when the repo has changed in a way that could
+// have lost commits or found them again. We only look for them straight away
+// if the tab is open, otherwise we wait until it's next opened.
+func (gui *Gui) forgetLostCommits() {
+	gui.g.Update(func(*gocui.Gui) error {
+		gui.State.LostCommits = nil
+
+		if ContextKey(gui.Views.Stash.Context) != LOST_AND_FOUND_CONTEXT_KEY {
+			return nil
+		}
+
+		return gui.WithWaitingStatus(gui.Tr.LcFindingLostCommits, gui.refreshLostCommits)
+	})
+}
+
+func (gui *Gui) refreshLostCommits() error {
+	commits, err := gui.GitCommand.GetLostCommits()
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+
+	gui.State.LostCommits = commits
+
+	return gui.postRefreshUpdate(gui.State.Contexts.LostAndFound)
+}
+
+// specific functions
+
+func (gui *Gui) handleRestoreLostStash() error {
+	commit := gui.getSelectedLostCommit()
+	if commit == nil {
+		return nil
+	}
+
+	if !commit.IsStash() {
+		return gui.createErrorPanel(gui.Tr.NotAStashCommit)
+	}
+
+	if err := gui.GitCommand.WithSpan(gui.Tr.Spans.RestoreStash).StashStore(commit.Sha, commit.Name); err != nil {
+		return gui.surfaceError(err)
+	}
+
+	gui.State.Panels.Stash.SelectedLineIdx = 0
+
+	if err := gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{STASH}}); err != nil {
+		return err
+	}
+
+	return gui.pushContext(gui.State.Contexts.Stash)
+}
+
+func (gui *Gui) handleViewLostCommitFiles() error {
+	commit := gui.getSelectedLostCommit()
+	if commit == nil {
+		return nil
+	}
+
+	return gui.switchToCommitFilesContext(commit.Sha, false, gui.State.Contexts.LostAndFound, "stash")
+}
@@ -32,6 +32,7 @@ const (
 	RUN_FUNCTION
 	RUN_COMMAND
 	RUN_PTY
+	RENDER_DIFF
 )
 
 type updateTask interface {
@@ -97,6 +98,19 @@ func NewRunPtyTask(cmd *exec.Cmd) *runPtyTask {
 // 	return &runPtyTask{cmd: cmd, prefix: prefix}
 // }
 
+type renderDiffTask struct {
+	cmdStr     string
+	sideBySide bool
+}
+
+func (t *renderDiffTask) GetKind() TaskKind {
+	return RENDER_DIFF
+}
+
+func NewRenderDiffTask(cmdStr string, sideBySide bool) *renderDiffTask {
+	return &renderDiffTask{cmdStr: cmdStr, sideBySide: sideBySide}
+}
+
 type runFunctionTask struct {
 	f func(chan struct{}) error
 }
@@ -131,6 +145,10 @@ func (gui *Gui) runTaskForView(view *gocui.View, task updateTask) error {
 	case RUN_PTY:
 		specificTask := task.(*runPtyTask)
 		return gui.newPtyTask(view, specificTask.cmd, specificTask.prefix)
+
+	case RENDER_DIFF:
+		specificTask := task.(*renderDiffTask)
+		return gui.newRenderDiffTask(view, specificTask.cmdStr, specificTask.sideBySide)
 	}
 
 	return nil
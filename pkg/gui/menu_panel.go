@@ -51,6 +51,10 @@ type createMenuOptions struct {
 }
 
 func (gui *Gui) createMenu(title string, items []*menuItem, createMenuOptions createMenuOptions) error {
+	if gui.runningScript() {
+		return gui.failScriptStep(gui.cantAnswerFromScriptError(title))
+	}
+
 	if createMenuOptions.showCancel {
 		// this is mutative but I'm okay with that for now
 		items = append(items, &menuItem{
@@ -96,10 +96,26 @@ func (gui *Gui) handlePickHunk() error {
 }
 
 func (gui *Gui) handlePickBothHunks() error {
+	return gui.pickHunks(mergeconflicts.BOTH)
+}
+
+func (gui *Gui) handlePickBaseHunk() error {
+	return gui.pickHunks(mergeconflicts.BASE)
+}
+
+func (gui *Gui) handlePickAllHunks() error {
+	return gui.pickHunks(mergeconflicts.ALL)
+}
+
+func (gui *Gui) pickHunks(selection mergeconflicts.Selection) error {
 	return gui.withMergeConflictLock(func() error {
 		gui.takeOverMergeConflictScrolling()
 
-		ok, err := gui.resolveConflict(mergeconflicts.BOTH)
+		if selection == mergeconflicts.BASE && !gui.State.Panels.Merging.HasAncestor() {
+			return gui.createErrorPanel(gui.Tr.NoBaseSectionInConflict)
+		}
+
+		ok, err := gui.resolveConflict(selection)
 		if err != nil {
 			return err
 		}
@@ -139,6 +155,10 @@ func (gui *Gui) resolveConflict(selection mergeconflicts.Selection) (bool, error
 		logStr = "Picking bottom hunk"
 	case mergeconflicts.BOTH:
 		logStr = "Picking both hunks"
+	case mergeconflicts.BASE:
+		logStr = "Picking base hunk"
+	case mergeconflicts.ALL:
+		logStr = "Picking all hunks"
 	}
 	gui.OnRunCommand(oscommands.NewCmdLogEntry(logStr, "Resolve merge conflict", false))
 	return true, ioutil.WriteFile(gitFile.Name, []byte(output), 0644)
@@ -167,7 +187,7 @@ func (gui *Gui) refreshMergePanel() error {
 	}
 
 	hasFocus := gui.currentViewName() == "main"
-	content := mergeconflicts.ColoredConflictFile(cat, panelState.State, hasFocus)
+	content := mergeconflicts.ColoredConflictFile(cat, gui.getSelectedFile().Name, panelState.State, hasFocus)
 
 	if err := gui.scrollToConflict(); err != nil {
 		return err
@@ -232,6 +252,8 @@ func (gui *Gui) getMergingOptions() map[string]string {
 		fmt.Sprintf("%s %s", gui.getKeyDisplay(keybindingConfig.Universal.PrevBlock), gui.getKeyDisplay(keybindingConfig.Universal.NextBlock)): gui.Tr.LcNavigateConflicts,
 		gui.getKeyDisplay(keybindingConfig.Universal.Select):   gui.Tr.LcPickHunk,
 		gui.getKeyDisplay(keybindingConfig.Main.PickBothHunks): gui.Tr.LcPickBothHunks,
+		gui.getKeyDisplay(keybindingConfig.Main.PickBaseHunk):  gui.Tr.LcPickBaseHunk,
+		gui.getKeyDisplay(keybindingConfig.Main.PickAllHunks):  gui.Tr.LcPickAllHunks,
 		gui.getKeyDisplay(keybindingConfig.Universal.Undo):     gui.Tr.LcUndo,
 	}
 }
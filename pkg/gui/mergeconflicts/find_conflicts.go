@@ -6,12 +6,14 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
-// LineType tells us whether a given line is a start/middle/end marker of a conflict,
-// or if it's not a marker at all
+// LineType tells us whether a given line is a start/ancestor/middle/end marker of
+// a conflict, or if it's not a marker at all. The ancestor marker only appears
+// when merge.conflictStyle is set to diff3.
 type LineType int
 
 const (
 	START LineType = iota
+	ANCESTOR
 	MIDDLE
 	END
 	NOT_A_MARKER
@@ -28,7 +30,11 @@ func findConflicts(content string) []*mergeConflict {
 	for i, line := range utils.SplitLines(content) {
 		switch determineLineType(line) {
 		case START:
-			newConflict = &mergeConflict{start: i}
+			newConflict = &mergeConflict{start: i, ancestor: -1}
+		case ANCESTOR:
+			if newConflict != nil {
+				newConflict.ancestor = i
+			}
 		case MIDDLE:
 			newConflict.middle = i
 		case END:
@@ -50,6 +56,8 @@ func determineLineType(line string) LineType {
 	switch {
 	case strings.HasPrefix(trimmedLine, "<<<<<<< "):
 		return START
+	case trimmedLine == "|||||||" || strings.HasPrefix(trimmedLine, "||||||| "):
+		return ANCESTOR
 	case trimmedLine == "=======":
 		return MIDDLE
 	case strings.HasPrefix(trimmedLine, ">>>>>>> "):
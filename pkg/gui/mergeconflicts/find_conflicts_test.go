@@ -41,6 +41,26 @@ func TestDetermineLineType(t *testing.T) {
 			line:     "<<<<<<< ours:my_branch",
 			expected: START,
 		},
+		{
+			line:     "|||||||",
+			expected: ANCESTOR,
+		},
+		{
+			line:     "||||||| merged common ancestors",
+			expected: ANCESTOR,
+		},
+		{
+			line:     "||||||| 2f6e3a7:my_branch",
+			expected: ANCESTOR,
+		},
+		{
+			line:     "++||||||| base",
+			expected: ANCESTOR,
+		},
+		{
+			line:     "||||||||",
+			expected: NOT_A_MARKER,
+		},
 		{
 			line:     "=======",
 			expected: MIDDLE,
@@ -4,29 +4,55 @@ import (
 	"bytes"
 
 	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/gui/syntax"
 	"github.com/jesseduffield/lazygit/pkg/theme"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
-func ColoredConflictFile(content string, state *State, hasFocus bool) string {
+// ColoredConflictFile renders the content of the file at the given path with
+// its conflicts coloured. Code outside of the conflict markers and on either
+// side of a conflict is syntax highlighted if possible.
+func ColoredConflictFile(content string, path string, state *State, hasFocus bool) string {
 	if len(state.conflicts) == 0 {
 		return content
 	}
+	highlighter := syntax.NewHighlighter(path)
 	conflict, remainingConflicts := shiftConflict(state.conflicts)
 	var outputBuffer bytes.Buffer
 	for i, line := range utils.SplitLines(content) {
 		textStyle := theme.DefaultTextColor
-		if i == conflict.start || i == conflict.middle || i == conflict.end {
+		isCode := true
+		if conflict.isMarkerLine(i) {
 			textStyle = style.FgRed
+			isCode = false
+		} else if conflict.hasAncestor() && conflict.ancestor < i && i < conflict.middle {
+			// the base section is there for reference so we don't want it competing
+			// for attention with the two sides of the conflict
+			textStyle = style.FgBlackLighter
+			isCode = false
 		}
 
-		if hasFocus && state.conflictIndex < len(state.conflicts) && *state.conflicts[state.conflictIndex] == *conflict && shouldHighlightLine(i, conflict, state.conflictTop) {
-			textStyle = textStyle.MergeStyle(theme.SelectedRangeBgColor).SetBold()
+		selected := hasFocus && state.conflictIndex < len(state.conflicts) && *state.conflicts[state.conflictIndex] == *conflict && shouldHighlightLine(i, conflict, state.conflictTop)
+		decorate := func(textStyle style.TextStyle) style.TextStyle {
+			if selected {
+				return textStyle.MergeStyle(theme.SelectedRangeBgColor).SetBold()
+			}
+			return textStyle
 		}
+
 		if i == conflict.end && len(remainingConflicts) > 0 {
 			conflict, remainingConflicts = shiftConflict(remainingConflicts)
 		}
-		outputBuffer.WriteString(textStyle.Sprint(line) + "\n")
+
+		if highlighter == nil || !isCode {
+			outputBuffer.WriteString(decorate(textStyle).Sprint(line) + "\n")
+			continue
+		}
+
+		for _, token := range highlighter.Tokenize(line) {
+			outputBuffer.WriteString(decorate(token.Style(textStyle)).Sprint(token.Text))
+		}
+		outputBuffer.WriteString("\n")
 	}
 	return outputBuffer.String()
 }
@@ -36,5 +62,5 @@ func shiftConflict(conflicts []*mergeConflict) (*mergeConflict, []*mergeConflict
 }
 
 func shouldHighlightLine(index int, conflict *mergeConflict, top bool) bool {
-	return (index >= conflict.start && index <= conflict.middle && top) || (index >= conflict.middle && index <= conflict.end && !top)
+	return (index >= conflict.start && index <= conflict.topEnd() && top) || (index >= conflict.middle && index <= conflict.end && !top)
 }
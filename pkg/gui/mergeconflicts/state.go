@@ -13,14 +13,40 @@ const (
 	TOP Selection = iota
 	BOTTOM
 	BOTH
+	// BASE and ALL are only meaningful for conflicts with an ancestor section
+	BASE
+	ALL
 )
 
 // mergeConflict : A git conflict with a start middle and end corresponding to line
-// numbers in the file where the conflict markers appear
+// numbers in the file where the conflict markers appear. With diff3-style
+// conflicts there is also an ancestor marker between the start and the middle,
+// otherwise ancestor is -1.
 type mergeConflict struct {
-	start  int
-	middle int
-	end    int
+	start    int
+	ancestor int
+	middle   int
+	end      int
+}
+
+func (c *mergeConflict) hasAncestor() bool {
+	return c.ancestor >= 0
+}
+
+func (c *mergeConflict) isMarkerLine(i int) bool {
+	return i == c.start ||
+		(c.hasAncestor() && i == c.ancestor) ||
+		i == c.middle ||
+		i == c.end
+}
+
+// topEnd is the line on which our side of the conflict ends
+func (c *mergeConflict) topEnd() int {
+	if c.hasAncestor() {
+		return c.ancestor
+	}
+
+	return c.middle
 }
 
 type State struct {
@@ -115,6 +141,13 @@ func (s *State) Reset() {
 	s.EditHistory = stack.New()
 }
 
+// HasAncestor tells us whether the current conflict has a base section i.e.
+// whether it was written with merge.conflictStyle=diff3
+func (s *State) HasAncestor() bool {
+	conflict := s.currentConflict()
+	return conflict != nil && conflict.hasAncestor()
+}
+
 func (s *State) GetConflictMiddle() int {
 	return s.currentConflict().middle
 }
@@ -140,14 +173,22 @@ func (s *State) ContentAfterConflictResolve(path string, selection Selection) (b
 }
 
 func isIndexToDelete(i int, conflict *mergeConflict, selection Selection) bool {
-	isMarkerLine :=
-		i == conflict.middle ||
-			i == conflict.start ||
-			i == conflict.end
+	if conflict.isMarkerLine(i) {
+		return true
+	}
+
+	isTopContent := conflict.start < i && i < conflict.topEnd()
+	isBaseContent := conflict.hasAncestor() && conflict.ancestor < i && i < conflict.middle
+	isBottomContent := conflict.middle < i && i < conflict.end
+
+	keepTop := selection == TOP || selection == BOTH || selection == ALL
+	keepBase := selection == BASE || selection == ALL
+	keepBottom := selection == BOTTOM || selection == BOTH || selection == ALL
 
 	isUnwantedContent :=
-		(selection == BOTTOM && conflict.start < i && i < conflict.middle) ||
-			(selection == TOP && conflict.middle < i && i < conflict.end)
+		(isTopContent && !keepTop) ||
+			(isBaseContent && !keepBase) ||
+			(isBottomContent && !keepBottom)
 
-	return isMarkerLine || isUnwantedContent
+	return isUnwantedContent
 }
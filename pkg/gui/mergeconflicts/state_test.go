@@ -1,6 +1,7 @@
 package mergeconflicts
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -61,34 +62,73 @@ baz
 `,
 			expected: []*mergeConflict{
 				{
-					start:  0,
-					middle: 2,
-					end:    4,
+					start:    0,
+					ancestor: -1,
+					middle:   2,
+					end:      4,
 				},
 				{
-					start:  6,
-					middle: 9,
-					end:    11,
+					start:    6,
+					ancestor: -1,
+					middle:   9,
+					end:      11,
 				},
 				{
-					start:  13,
-					middle: 15,
-					end:    17,
+					start:    13,
+					ancestor: -1,
+					middle:   15,
+					end:      17,
 				},
 				{
-					start:  19,
-					middle: 21,
-					end:    23,
+					start:    19,
+					ancestor: -1,
+					middle:   21,
+					end:      23,
 				},
 				{
-					start:  25,
-					middle: 27,
-					end:    29,
+					start:    25,
+					ancestor: -1,
+					middle:   27,
+					end:      29,
 				},
 				{
-					start:  31,
-					middle: 34,
-					end:    36,
+					start:    31,
+					ancestor: -1,
+					middle:   34,
+					end:      36,
+				},
+			},
+		},
+		{
+			name: "diff3 conflicts",
+			content: `<<<<<<< HEAD
+foo
+||||||| merged common ancestors
+base
+=======
+bar
+>>>>>>> branch
+
+++<<<<<<< ours
+foo
+++||||||| base
+++=======
+bar
+baz
+++>>>>>>> theirs
+`,
+			expected: []*mergeConflict{
+				{
+					start:    0,
+					ancestor: 2,
+					middle:   4,
+					end:      6,
+				},
+				{
+					start:    8,
+					ancestor: 10,
+					middle:   11,
+					end:      14,
 				},
 			},
 		},
@@ -101,3 +141,93 @@ baz
 		})
 	}
 }
+
+func TestIsIndexToDelete(t *testing.T) {
+	type scenario struct {
+		name      string
+		content   string
+		selection Selection
+		expected  string
+	}
+
+	diff3Content := `before
+<<<<<<< HEAD
+ours
+||||||| merged common ancestors
+base
+=======
+theirs
+>>>>>>> branch
+after`
+
+	scenarios := []scenario{
+		{
+			name: "pick top of two-way conflict",
+			content: `before
+<<<<<<< HEAD
+ours
+=======
+theirs
+>>>>>>> branch
+after`,
+			selection: TOP,
+			expected:  "before\nours\nafter",
+		},
+		{
+			name: "pick both of two-way conflict",
+			content: `<<<<<<< HEAD
+ours
+=======
+theirs
+>>>>>>> branch`,
+			selection: BOTH,
+			expected:  "ours\ntheirs",
+		},
+		{
+			name:      "pick top of diff3 conflict",
+			content:   diff3Content,
+			selection: TOP,
+			expected:  "before\nours\nafter",
+		},
+		{
+			name:      "pick bottom of diff3 conflict",
+			content:   diff3Content,
+			selection: BOTTOM,
+			expected:  "before\ntheirs\nafter",
+		},
+		{
+			name:      "pick base of diff3 conflict",
+			content:   diff3Content,
+			selection: BASE,
+			expected:  "before\nbase\nafter",
+		},
+		{
+			name:      "pick both sides of diff3 conflict",
+			content:   diff3Content,
+			selection: BOTH,
+			expected:  "before\nours\ntheirs\nafter",
+		},
+		{
+			name:      "pick all three of diff3 conflict",
+			content:   diff3Content,
+			selection: ALL,
+			expected:  "before\nours\nbase\ntheirs\nafter",
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.name, func(t *testing.T) {
+			conflict := findConflicts(s.content)[0]
+
+			kept := []string{}
+			for i, line := range strings.Split(s.content, "\n") {
+				if !isIndexToDelete(i, conflict, s.selection) {
+					kept = append(kept, line)
+				}
+			}
+
+			assert.EqualValues(t, s.expected, strings.Join(kept, "\n"))
+		})
+	}
+}
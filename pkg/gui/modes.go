@@ -58,5 +58,10 @@ func (gui *Gui) modeStatuses() []modeStatus {
 			},
 			reset: gui.exitCherryPickingMode,
 		},
+		{
+			isActive:    gui.State.Modes.Bisecting.Active,
+			description: gui.bisectStatusDescription,
+			reset:       gui.resetBisect,
+		},
 	}
 }
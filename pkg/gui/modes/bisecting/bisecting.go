@@ -0,0 +1,26 @@
+package bisecting
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+)
+
+// the bisect itself lives in git, we just hold onto what we last read of it
+type Bisecting struct {
+	info *models.BisectInfo
+}
+
+func New() Bisecting {
+	return Bisecting{info: models.NewBisectInfo()}
+}
+
+func (m *Bisecting) Active() bool {
+	return m.info.Started()
+}
+
+func (m *Bisecting) SetInfo(info *models.BisectInfo) {
+	m.info = info
+}
+
+func (m *Bisecting) GetInfo() *models.BisectInfo {
+	return m.info
+}
@@ -0,0 +1,72 @@
+package gui
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+func (gui *Gui) operationDescription(op *models.Operation) string {
+	var template string
+	switch op.Kind {
+	case models.OPERATION_CREATE_BRANCH:
+		template = gui.Tr.CreateBranchOperation
+	case models.OPERATION_DELETE_BRANCH:
+		template = gui.Tr.DeleteBranchOperation
+	case models.OPERATION_RENAME_BRANCH:
+		template = gui.Tr.RenameBranchOperation
+	case models.OPERATION_DELETE_TAG:
+		template = gui.Tr.DeleteTagOperation
+	case models.OPERATION_DROP_STASH:
+		template = gui.Tr.DropStashOperation
+	case models.OPERATION_DELETE_REMOTE_BRANCH:
+		template = gui.Tr.DeleteRemoteBranchOperation
+	default:
+		// operations taken from the reflog are described by their reflog entry
+		return op.Summary
+	}
+
+	return utils.ResolvePlaceholderString(template, map[string]string{
+		"name":   op.Name,
+		"from":   op.From,
+		"to":     op.To,
+		"remote": op.Remote,
+	})
+}
+
+// handleCreateOperationLogMenu lists the operations in the journal, newest
+// first. Operations which have been undone come first because they're newer
+// than whatever we'd undo next.
+func (gui *Gui) handleCreateOperationLogMenu() error {
+	done, undone, err := gui.GitCommand.GetOperations()
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+
+	if len(done) == 0 && len(undone) == 0 {
+		return gui.createErrorPanel(gui.Tr.NoOperations)
+	}
+
+	menuItems := make([]*menuItem, 0, len(done)+len(undone))
+	for _, op := range undone {
+		menuItems = append(menuItems, &menuItem{
+			displayStrings: []string{
+				style.FgBlue.Sprint(utils.UnixToTimeAgo(op.UnixTimestamp)),
+				gui.operationDescription(op),
+				style.FgYellow.Sprint(gui.Tr.LcUndone),
+			},
+			onPress: func() error { return nil },
+		})
+	}
+	for _, op := range done {
+		menuItems = append(menuItems, &menuItem{
+			displayStrings: []string{
+				style.FgBlue.Sprint(utils.UnixToTimeAgo(op.UnixTimestamp)),
+				gui.operationDescription(op),
+			},
+			onPress: func() error { return nil },
+		})
+	}
+
+	return gui.createMenu(gui.Tr.OperationLogTitle, menuItems, createMenuOptions{showCancel: true})
+}
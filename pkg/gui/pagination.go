@@ -0,0 +1,88 @@
+package gui
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// when the selection gets this close to the end of what we've loaded, we load
+// the next page
+const loadMoreThreshold = 20
+
+// paginationState keeps track of how much of a long list of commits we've
+// loaded so that we can load the next page when the user scrolls towards the
+// bottom instead of loading everything up front.
+type paginationState struct {
+	// limit is how many items we load when refreshing the list, which grows as
+	// pages are loaded so that refreshing doesn't lose them. Zero means no limit.
+	limit int
+	// hasMore is true when there may be more items after those we've loaded
+	hasMore bool
+	// loadingMore is true while we're loading the next page
+	loadingMore bool
+}
+
+func newPaginationState() paginationState {
+	return paginationState{limit: commands.COMMITS_PAGE_SIZE}
+}
+
+// reset goes back to only loading the first page
+func (p *paginationState) reset() {
+	p.limit = commands.COMMITS_PAGE_SIZE
+}
+
+// loadAll is for when we need every item e.g. to search through them
+func (p *paginationState) loadAll() {
+	p.limit = 0
+	p.hasMore = false
+}
+
+// afterRefresh records whether there may be more items than the given number
+// loaded when refreshing the list
+func (p *paginationState) afterRefresh(loaded int) {
+	p.hasMore = p.limit > 0 && loaded >= p.limit
+}
+
+// afterLoadingMore records the result of loading the next page
+func (p *paginationState) afterLoadingMore(loaded int) {
+	if p.limit > 0 {
+		p.limit += loaded
+	}
+	p.hasMore = loaded >= commands.COMMITS_PAGE_SIZE
+	p.loadingMore = false
+}
+
+func (p *paginationState) shouldLoadMore(selectedLineIdx int, itemsLength int) bool {
+	return p.hasMore && !p.loadingMore && selectedLineIdx >= itemsLength-loadMoreThreshold
+}
+
+// loadMoreIfNecessary loads the next page in the background once the
+// selection is near the bottom of the list
+func (gui *Gui) loadMoreIfNecessary(pagination *paginationState, selectedLineIdx int, itemsLength int, loadMore func() error) {
+	if !pagination.shouldLoadMore(selectedLineIdx, itemsLength) {
+		return
+	}
+
+	pagination.loadingMore = true
+	go utils.Safe(func() {
+		if err := loadMore(); err != nil {
+			pagination.loadingMore = false
+			_ = gui.surfaceError(err)
+		}
+	})
+}
+
+// withLoadingMoreRow adds a row to the end of a list to show there's more to
+// come. The text goes in the last column so that it doesn't change the width
+// of the other columns.
+func (gui *Gui) withLoadingMoreRow(displayStrings [][]string, pagination *paginationState) [][]string {
+	if !pagination.hasMore || len(displayStrings) == 0 {
+		return displayStrings
+	}
+
+	row := make([]string, len(displayStrings[0]))
+	row[len(row)-1] = style.FgYellow.Sprint(gui.Tr.LoadingMoreCommits)
+
+	return append(displayStrings, row)
+}
@@ -0,0 +1,53 @@
+package presentation
+
+import (
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/gui/syntax"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// RenderBlame renders each line of a file next to the short sha, author and
+// age of the commit that last changed it
+func RenderBlame(blameLines []*models.BlameLine, path string, selectedLineIdx int) string {
+	highlighter := syntax.NewHighlighter(path)
+
+	displayStrings := make([][]string, len(blameLines))
+	for i, blameLine := range blameLines {
+		decorate := func(textStyle style.TextStyle) style.TextStyle {
+			if i == selectedLineIdx {
+				return textStyle.MergeStyle(theme.SelectedRangeBgColor)
+			}
+			return textStyle
+		}
+
+		shaColor := style.FgBlue
+		if !blameLine.IsCommitted() {
+			shaColor = style.FgRed
+		}
+
+		displayStrings[i] = []string{
+			decorate(shaColor).Sprint(blameLine.ShortSha()),
+			decorate(style.FgYellow).Sprint(utils.TruncateWithEllipsis(blameLine.Author, 17)),
+			decorate(style.FgGreen).Sprint(utils.UnixToTimeAgo(blameLine.AuthorTime)),
+			renderBlameContent(blameLine.Content, highlighter, decorate),
+		}
+	}
+
+	return utils.RenderDisplayStrings(displayStrings)
+}
+
+func renderBlameContent(content string, highlighter *syntax.Highlighter, decorate func(style.TextStyle) style.TextStyle) string {
+	if highlighter == nil {
+		return decorate(theme.DefaultTextColor).Sprint(content)
+	}
+
+	var builder strings.Builder
+	for _, token := range highlighter.Tokenize(content) {
+		builder.WriteString(decorate(token.Style(theme.DefaultTextColor)).Sprint(token.Text))
+	}
+	return builder.String()
+}
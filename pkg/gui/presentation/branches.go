@@ -9,19 +9,19 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/theme"
 )
 
-func GetBranchListDisplayStrings(branches []*models.Branch, fullDescription bool, diffName string) [][]string {
+func GetBranchListDisplayStrings(branches []*models.Branch, worktrees []*models.Worktree, fullDescription bool, diffName string) [][]string {
 	lines := make([][]string, len(branches))
 
 	for i := range branches {
 		diffed := branches[i].Name == diffName
-		lines[i] = getBranchDisplayStrings(branches[i], fullDescription, diffed)
+		lines[i] = getBranchDisplayStrings(branches[i], worktreeForBranch(branches[i], worktrees), fullDescription, diffed)
 	}
 
 	return lines
 }
 
 // getBranchDisplayStrings returns the display string of branch
-func getBranchDisplayStrings(b *models.Branch, fullDescription bool, diffed bool) []string {
+func getBranchDisplayStrings(b *models.Branch, worktree *models.Worktree, fullDescription bool, diffed bool) []string {
 	displayName := b.Name
 	if b.DisplayName != "" {
 		displayName = b.DisplayName
@@ -35,6 +35,13 @@ func getBranchDisplayStrings(b *models.Branch, fullDescription bool, diffed bool
 	if b.IsTrackingRemote() {
 		coloredName = fmt.Sprintf("%s %s", coloredName, ColoredBranchStatus(b))
 	}
+	if b.HasPushRemote() {
+		coloredName = fmt.Sprintf("%s %s", coloredName, ColoredPushRemoteStatus(b))
+	}
+	if worktree != nil {
+		// the branch is checked out in another worktree so git won't let us check it out here
+		coloredName = fmt.Sprintf("%s %s", coloredName, style.FgMagenta.Sprintf("(worktree %s)", worktree.Name()))
+	}
 
 	recencyColor := style.FgCyan
 	if b.Recency == "  *" {
@@ -78,3 +85,16 @@ func ColoredBranchStatus(branch *models.Branch) string {
 func BranchStatus(branch *models.Branch) string {
 	return fmt.Sprintf("↑%s↓%s", branch.Pushables, branch.Pullables)
 }
+
+// ColoredPushRemoteStatus is like ColoredBranchStatus but against the branch
+// on the push remote, e.g. 'origin ↑1↓0'
+func ColoredPushRemoteStatus(branch *models.Branch) string {
+	colour := style.FgYellow
+	if branch.MatchesPushRemote() {
+		colour = style.FgGreen
+	} else if branch.PushRemotePushables == "?" {
+		colour = style.FgRed
+	}
+
+	return colour.Sprintf("%s ↑%s↓%s", branch.PushRemote, branch.PushRemotePushables, branch.PushRemotePullables)
+}
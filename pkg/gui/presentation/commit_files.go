@@ -26,7 +26,12 @@ func GetCommitFileLine(name string, diffName string, commitFile *models.CommitFi
 		return colour.Sprint(name)
 	}
 
-	return getColorForChangeStatus(commitFile.ChangeStatus).Sprint(commitFile.ChangeStatus) + " " + colour.Sprint(name)
+	output := getColorForChangeStatus(commitFile.ChangeStatus).Sprint(commitFile.ChangeStatus) + " " + colour.Sprint(name)
+	if commitFile.IsLfs {
+		output += theme.DefaultTextColor.Sprint(" (LFS)")
+	}
+
+	return output
 }
 
 func getColorForChangeStatus(changeStatus string) style.TextStyle {
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/presentation/graph"
 	"github.com/jesseduffield/lazygit/pkg/gui/style"
 	"github.com/jesseduffield/lazygit/pkg/theme"
 	"github.com/jesseduffield/lazygit/pkg/utils"
@@ -12,25 +13,40 @@ import (
 
 var cherryPickedCommitTextStyle = style.FgCyan.MergeStyle(style.BgBlue)
 
-func GetCommitListDisplayStrings(commits []*models.Commit, fullDescription bool, cherryPickedCommitShaMap map[string]bool, diffName string, parseEmoji bool) [][]string {
+func GetCommitListDisplayStrings(commits []*models.Commit, fullDescription bool, cherryPickedCommitShaMap map[string]bool, diffName string, parseEmoji bool, bisectInfo *models.BisectInfo) [][]string {
 	lines := make([][]string, len(commits))
 
-	var displayFunc func(*models.Commit, map[string]bool, bool, bool) []string
+	var displayFunc func(*models.Commit, string, map[string]bool, bool, bool, *models.BisectInfo) []string
 	if fullDescription {
 		displayFunc = getFullDescriptionDisplayStringsForCommit
 	} else {
 		displayFunc = getDisplayStringsForCommit
 	}
 
+	graphLines := getGraphLines(commits)
+
 	for i := range commits {
 		diffed := commits[i].Sha == diffName
-		lines[i] = displayFunc(commits[i], cherryPickedCommitShaMap, diffed, parseEmoji)
+		lines[i] = displayFunc(commits[i], graphLines[i], cherryPickedCommitShaMap, diffed, parseEmoji, bisectInfo)
 	}
 
 	return lines
 }
 
-func getFullDescriptionDisplayStringsForCommit(c *models.Commit, cherryPickedCommitShaMap map[string]bool, diffed, parseEmoji bool) []string {
+// getGraphLines renders the commit graph, leaving out any commits at the top
+// which are yet to be applied in an interactive rebase because they don't have
+// parents yet
+func getGraphLines(commits []*models.Commit) []string {
+	startIdx := 0
+	for startIdx < len(commits) && commits[startIdx].Status == "rebasing" {
+		startIdx++
+	}
+
+	lines := make([]string, startIdx, len(commits))
+	return append(lines, graph.RenderCommitGraph(commits[startIdx:])...)
+}
+
+func getFullDescriptionDisplayStringsForCommit(c *models.Commit, graphLine string, cherryPickedCommitShaMap map[string]bool, diffed, parseEmoji bool, bisectInfo *models.BisectInfo) []string {
 	shaColor := theme.DefaultTextColor
 	switch c.Status {
 	case "unpushed":
@@ -73,11 +89,11 @@ func getFullDescriptionDisplayStringsForCommit(c *models.Commit, cherryPickedCom
 		shaColor.Sprint(c.ShortSha()),
 		secondColumnString,
 		style.FgYellow.Sprint(truncatedAuthor),
-		tagString + theme.DefaultTextColor.Sprint(name),
+		graphLine + getBisectString(c, bisectInfo) + tagString + theme.DefaultTextColor.Sprint(name),
 	}
 }
 
-func getDisplayStringsForCommit(c *models.Commit, cherryPickedCommitShaMap map[string]bool, diffed, parseEmoji bool) []string {
+func getDisplayStringsForCommit(c *models.Commit, graphLine string, cherryPickedCommitShaMap map[string]bool, diffed, parseEmoji bool, bisectInfo *models.BisectInfo) []string {
 	shaColor := theme.DefaultTextColor
 	switch c.Status {
 	case "unpushed":
@@ -116,7 +132,38 @@ func getDisplayStringsForCommit(c *models.Commit, cherryPickedCommitShaMap map[s
 
 	return []string{
 		shaColor.Sprint(c.ShortSha()),
-		actionString + tagString + theme.DefaultTextColor.Sprint(name),
+		actionString + graphLine + getBisectString(c, bisectInfo) + tagString + theme.DefaultTextColor.Sprint(name),
+	}
+}
+
+// getBisectString returns a marker for commits we've marked while bisecting,
+// as well as for the commit git has checked out for us to test
+func getBisectString(c *models.Commit, bisectInfo *models.BisectInfo) string {
+	if bisectInfo == nil || !bisectInfo.Started() {
+		return ""
+	}
+
+	status := bisectInfo.Status(c.Sha)
+	switch {
+	case c.Sha == bisectInfo.FirstNewSha:
+		return style.FgRed.SetBold().Sprintf("<-- first %s commit", bisectInfo.NewTerm) + " "
+	case status != models.BisectStatusNone:
+		return bisectStatusColor(status).Sprint(bisectInfo.Term(status)) + " "
+	case c.Sha == bisectInfo.Current:
+		return style.FgMagenta.SetBold().Sprint("<-- current") + " "
+	default:
+		return ""
+	}
+}
+
+func bisectStatusColor(status models.BisectStatus) style.TextStyle {
+	switch status {
+	case models.BisectStatusNew:
+		return style.FgRed
+	case models.BisectStatusOld:
+		return style.FgGreen
+	default:
+		return style.FgYellow
 	}
 }
 
@@ -49,5 +49,21 @@ func GetFileLine(hasUnstagedChanges bool, hasStagedChanges bool, name string, di
 		output += theme.DefaultTextColor.Sprint(" (submodule)")
 	}
 
+	if file != nil && file.IsLfs {
+		output += theme.DefaultTextColor.Sprint(" (LFS)")
+	}
+
 	return output
 }
+
+// GetSparseCheckoutSuffix is shown after a directory in a sparse checkout
+func GetSparseCheckoutSuffix(status models.ConeStatus) string {
+	switch status {
+	case models.ConeStatusInside:
+		return style.FgGreen.Sprint(" (in cone)")
+	case models.ConeStatusPartlyInside:
+		return style.FgYellow.Sprint(" (partly in cone)")
+	default:
+		return theme.DefaultTextColor.Sprint(" (outside cone)")
+	}
+}
@@ -0,0 +1,127 @@
+package graph
+
+import (
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+)
+
+const (
+	commitSymbol = "◯"
+	mergeSymbol  = "⏣"
+)
+
+type cellType int
+
+const (
+	CONNECTION cellType = iota
+	COMMIT
+	MERGE
+)
+
+// a Cell is two characters wide: the first is where a lane runs vertically and
+// where commits sit, the second is only ever used for horizontal connections
+// between lanes
+type Cell struct {
+	up, down, left, right bool
+	cellType              cellType
+	style                 style.TextStyle
+	// if a pipe heads off to the right from this cell it may be in a different
+	// color to the lane running through the cell
+	rightStyle *style.TextStyle
+}
+
+func (cell *Cell) render(writer *strings.Builder) {
+	first, second := getBoxDrawingChars(cell.up, cell.down, cell.left, cell.right)
+
+	switch cell.cellType {
+	case COMMIT:
+		first = commitSymbol
+	case MERGE:
+		first = mergeSymbol
+	}
+
+	rightStyle := cell.style
+	if cell.rightStyle != nil {
+		rightStyle = *cell.rightStyle
+	}
+
+	writer.WriteString(cell.style.Sprint(first))
+	// not styling blank space so that tests can easily compare plain strings
+	if second == " " {
+		writer.WriteString(second)
+	} else {
+		writer.WriteString(rightStyle.Sprint(second))
+	}
+}
+
+func (cell *Cell) setUp(style style.TextStyle) *Cell {
+	cell.up = true
+	cell.style = style
+	return cell
+}
+
+func (cell *Cell) setDown(style style.TextStyle) *Cell {
+	cell.down = true
+	cell.style = style
+	return cell
+}
+
+func (cell *Cell) setLeft(style style.TextStyle) *Cell {
+	cell.left = true
+	if !cell.up && !cell.down {
+		// vertical lines take precedence
+		cell.style = style
+	}
+	return cell
+}
+
+func (cell *Cell) setRight(style style.TextStyle, override bool) *Cell {
+	cell.right = true
+	if cell.rightStyle == nil || override {
+		cell.rightStyle = &style
+	}
+	return cell
+}
+
+func (cell *Cell) setType(cellType cellType) *Cell {
+	cell.cellType = cellType
+	return cell
+}
+
+func getBoxDrawingChars(up, down, left, right bool) (string, string) {
+	switch {
+	case up && down && left && right:
+		return "│", "─"
+	case up && down && left && !right:
+		return "│", " "
+	case up && down && !left && right:
+		return "│", "─"
+	case up && down && !left && !right:
+		return "│", " "
+	case up && !down && left && right:
+		return "┴", "─"
+	case up && !down && left && !right:
+		return "╯", " "
+	case up && !down && !left && right:
+		return "╰", "─"
+	case up && !down && !left && !right:
+		return "╵", " "
+	case !up && down && left && right:
+		return "┬", "─"
+	case !up && down && left && !right:
+		return "╮", " "
+	case !up && down && !left && right:
+		return "╭", "─"
+	case !up && down && !left && !right:
+		return "╷", " "
+	case !up && !down && left && right:
+		return "─", "─"
+	case !up && !down && left && !right:
+		return "─", " "
+	case !up && !down && !left && right:
+		return "╶", "─"
+	default:
+		return " ", " "
+	}
+}
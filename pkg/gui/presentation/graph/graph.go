@@ -0,0 +1,333 @@
+package graph
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+type PipeKind uint8
+
+const (
+	TERMINATES PipeKind = iota
+	STARTS
+	CONTINUES
+)
+
+// a Pipe runs from a commit to one of its parents. A line of the graph is
+// drawn from the pipes that start on, end on, or pass through that line.
+type Pipe struct {
+	fromPos int
+	toPos   int
+	fromSha string
+	toSha   string
+	kind    PipeKind
+	style   style.TextStyle
+}
+
+func (p *Pipe) left() int {
+	return utils.Min(p.fromPos, p.toPos)
+}
+
+func (p *Pipe) right() int {
+	if p.fromPos > p.toPos {
+		return p.fromPos
+	}
+	return p.toPos
+}
+
+// pipeSet holds everything we need to render a commit's line of the graph
+type pipeSet struct {
+	pipes       []*Pipe
+	commitPos   int
+	commitStyle style.TextStyle
+	isMerge     bool
+}
+
+// each lane gets its own color so that it's easy to follow a branch down the graph
+var laneStyles = []style.TextStyle{
+	style.FgCyan,
+	style.FgGreen,
+	style.FgYellow,
+	style.FgBlue,
+	style.FgMagenta,
+	style.FgRed,
+}
+
+func laneStyle(pos int) style.TextStyle {
+	return laneStyles[pos%len(laneStyles)]
+}
+
+// RenderCommitGraph returns a line of the graph for each commit, worked out
+// from the commits' parents. Commits are expected to be in the order git log
+// gives them to us, i.e. children before parents.
+func RenderCommitGraph(commits []*models.Commit) []string {
+	pipeSets := getPipeSets(commits)
+
+	lines := make([]string, len(pipeSets))
+	for i, pipeSet := range pipeSets {
+		lines[i] = renderPipeSet(pipeSet)
+	}
+
+	return lines
+}
+
+func getPipeSets(commits []*models.Commit) []pipeSet {
+	if len(commits) == 0 {
+		return nil
+	}
+
+	// We only draw pipes to parents that appear further down the list. Otherwise
+	// a commit whose parent isn't loaded (e.g. because we've hit the commit limit,
+	// or because we're looking at the reflog) would leave a lane running all the
+	// way to the bottom.
+	lastIndices := make(map[string]int, len(commits))
+	for i, commit := range commits {
+		lastIndices[shaKey(commit.Sha)] = i
+	}
+
+	pipeSets := make([]pipeSet, len(commits))
+	var pipes []*Pipe
+	for i, commit := range commits {
+		parents := make([]string, 0, len(commit.Parents))
+		for _, parent := range commit.Parents {
+			key := shaKey(parent)
+			if lastIndex, ok := lastIndices[key]; ok && lastIndex > i {
+				parents = append(parents, key)
+			}
+		}
+
+		pipeSets[i] = getNextPipeSet(pipes, commit, parents)
+		pipes = pipeSets[i].pipes
+	}
+
+	return pipeSets
+}
+
+func getNextPipeSet(prevPipes []*Pipe, commit *models.Commit, parents []string) pipeSet {
+	sha := shaKey(commit.Sha)
+
+	// a pipe that terminated on the previous line has no bearing on this one
+	currentPipes := make([]*Pipe, 0, len(prevPipes))
+	for _, pipe := range prevPipes {
+		if pipe.kind != TERMINATES {
+			currentPipes = append(currentPipes, pipe)
+		}
+	}
+
+	// our commit goes under the leftmost pipe pointing to it, and continues that
+	// pipe's color. If no pipe points to it, it has no children in the list so we
+	// give it the first free spot.
+	pos := -1
+	var commitStyle style.TextStyle
+	occupiedSpots := make(map[int]bool, len(currentPipes))
+	for _, pipe := range currentPipes {
+		if pos == -1 && pipe.toSha == sha {
+			pos = pipe.toPos
+			commitStyle = pipe.style
+		}
+		occupiedSpots[pipe.toPos] = true
+	}
+	if pos == -1 {
+		pos = 0
+		for occupiedSpots[pos] {
+			pos++
+		}
+		commitStyle = laneStyle(pos)
+	}
+
+	// a taken spot is one that a pipe ends on
+	takenSpots := map[int]bool{pos: true}
+	// a traversed spot is one that a pipe starts on, ends on, or passes through
+	traversedSpots := map[int]bool{pos: true}
+
+	traverse := func(from, to int) {
+		left, right := from, to
+		if left > right {
+			left, right = right, left
+		}
+		for i := left; i <= right; i++ {
+			traversedSpots[i] = true
+		}
+		takenSpots[to] = true
+	}
+
+	// new pipes can't end on a spot where a pipe that's not ending at our commit currently sits
+	continuingPipeSpots := make(map[int]bool, len(currentPipes))
+	for _, pipe := range currentPipes {
+		if pipe.toSha != sha {
+			continuingPipeSpots[pipe.toPos] = true
+		}
+	}
+
+	newPipes := make([]*Pipe, 0, len(currentPipes)+len(parents))
+
+	if len(parents) > 0 {
+		newPipes = append(newPipes, &Pipe{
+			fromPos: pos,
+			toPos:   pos,
+			fromSha: sha,
+			toSha:   parents[0],
+			kind:    STARTS,
+			style:   commitStyle,
+		})
+	}
+
+	for _, pipe := range currentPipes {
+		if pipe.toSha == sha {
+			newPipes = append(newPipes, &Pipe{
+				fromPos: pipe.toPos,
+				toPos:   pos,
+				fromSha: pipe.fromSha,
+				toSha:   pipe.toSha,
+				kind:    TERMINATES,
+				style:   pipe.style,
+			})
+			traverse(pipe.toPos, pos)
+		} else if pipe.toPos < pos {
+			// pipes to our left keep going, shuffling left if there's room
+			availablePos := 0
+			for traversedSpots[availablePos] {
+				availablePos++
+			}
+			newPipes = append(newPipes, &Pipe{
+				fromPos: pipe.toPos,
+				toPos:   availablePos,
+				fromSha: pipe.fromSha,
+				toSha:   pipe.toSha,
+				kind:    CONTINUES,
+				style:   pipe.style,
+			})
+			traverse(pipe.toPos, availablePos)
+		}
+	}
+
+	for _, parent := range parents[utils.Min(1, len(parents)):] {
+		availablePos := 0
+		for takenSpots[availablePos] || continuingPipeSpots[availablePos] {
+			availablePos++
+		}
+		newPipes = append(newPipes, &Pipe{
+			fromPos: pos,
+			toPos:   availablePos,
+			fromSha: sha,
+			toSha:   parent,
+			kind:    STARTS,
+			style:   laneStyle(availablePos),
+		})
+		takenSpots[availablePos] = true
+	}
+
+	for _, pipe := range currentPipes {
+		if pipe.toSha != sha && pipe.toPos > pos {
+			// pipes to our right keep going, moving left to fill in any gap
+			last := pipe.toPos
+			for i := pipe.toPos; i > pos; i-- {
+				if takenSpots[i] || traversedSpots[i] {
+					break
+				}
+				last = i
+			}
+			newPipes = append(newPipes, &Pipe{
+				fromPos: pipe.toPos,
+				toPos:   last,
+				fromSha: pipe.fromSha,
+				toSha:   pipe.toSha,
+				kind:    CONTINUES,
+				style:   pipe.style,
+			})
+			traverse(pipe.toPos, last)
+		}
+	}
+
+	sort.SliceStable(newPipes, func(i, j int) bool {
+		if newPipes[i].toPos == newPipes[j].toPos {
+			return newPipes[i].kind < newPipes[j].kind
+		}
+		return newPipes[i].toPos < newPipes[j].toPos
+	})
+
+	return pipeSet{
+		pipes:       newPipes,
+		commitPos:   pos,
+		commitStyle: commitStyle,
+		isMerge:     commit.IsMerge(),
+	}
+}
+
+func renderPipeSet(pipeSet pipeSet) string {
+	maxPos := pipeSet.commitPos
+	for _, pipe := range pipeSet.pipes {
+		if pipe.right() > maxPos {
+			maxPos = pipe.right()
+		}
+	}
+
+	cells := make([]*Cell, maxPos+1)
+	for i := range cells {
+		cells[i] = &Cell{cellType: CONNECTION, style: theme.DefaultTextColor}
+	}
+
+	renderPipe := func(pipe *Pipe, overrideRightStyle bool) {
+		left := pipe.left()
+		right := pipe.right()
+
+		if left != right {
+			for i := left + 1; i < right; i++ {
+				cells[i].setLeft(pipe.style).setRight(pipe.style, overrideRightStyle)
+			}
+			cells[left].setRight(pipe.style, overrideRightStyle)
+			cells[right].setLeft(pipe.style)
+		}
+
+		if pipe.kind == STARTS || pipe.kind == CONTINUES {
+			cells[pipe.toPos].setDown(pipe.style)
+		}
+		if pipe.kind == TERMINATES || pipe.kind == CONTINUES {
+			cells[pipe.fromPos].setUp(pipe.style)
+		}
+	}
+
+	// pipes starting at our commit are rendered first so that where they head off
+	// to the right, they take precedence over pipes coming in from the right.
+	for _, pipe := range pipeSet.pipes {
+		if pipe.kind == STARTS {
+			renderPipe(pipe, true)
+		}
+	}
+
+	for _, pipe := range pipeSet.pipes {
+		// a pipe coming straight down into our commit doesn't need drawing: the
+		// commit symbol covers it
+		if pipe.kind == TERMINATES && pipe.fromPos == pipeSet.commitPos && pipe.toPos == pipeSet.commitPos {
+			continue
+		}
+		if pipe.kind != STARTS {
+			renderPipe(pipe, false)
+		}
+	}
+
+	commitCellType := COMMIT
+	if pipeSet.isMerge {
+		commitCellType = MERGE
+	}
+	commitCell := cells[pipeSet.commitPos].setType(commitCellType)
+	commitCell.style = pipeSet.commitStyle
+
+	writer := &strings.Builder{}
+	for _, cell := range cells {
+		cell.render(writer)
+	}
+
+	return writer.String()
+}
+
+// shaKey lets us compare a commit's full sha with the abbreviated shas we get
+// for its parents
+func shaKey(sha string) string {
+	return utils.SafeTruncate(sha, 20)
+}
@@ -0,0 +1,174 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRenderCommitGraph is a function.
+func TestRenderCommitGraph(t *testing.T) {
+	type scenario struct {
+		testName       string
+		commits        string
+		expectedOutput string
+	}
+
+	// commits are given as 'sha | parent1 parent2', and the expected output has
+	// each commit's sha alongside its line of the graph
+	scenarios := []scenario{
+		{
+			"Linear history",
+			`
+1 | 2
+2 | 3
+3 | 4
+4 |`,
+			`
+1 ◯
+2 ◯
+3 ◯
+4 ◯`,
+		},
+		{
+			"Merged feature branch",
+			`
+1 | 2 5
+2 | 3
+5 | 6
+6 | 3
+3 |`,
+			`
+1 ⏣─╮
+2 ◯ │
+5 │ ◯
+6 │ ◯
+3 ◯─╯`,
+		},
+		{
+			"Two feature branches merged in",
+			`
+1 | 2 3
+2 | 4 5
+3 | 6
+4 | 6
+5 | 6
+6 |`,
+			`
+1 ⏣─╮
+2 ⏣─│─╮
+3 │ ◯ │
+4 ◯ │ │
+5 │ │ ◯
+6 ◯─┴─╯`,
+		},
+		{
+			"Forked branches converging on the same parent",
+			`
+a | b
+c | b
+b | d
+d |`,
+			`
+a ◯
+c │ ◯
+b ◯─╯
+d ◯`,
+		},
+		{
+			"Lane shuffles left once there's room",
+			`
+1 | 2 4
+2 | 3 5
+4 | 5
+3 | 6
+5 | 6
+6 |`,
+			`
+1 ⏣─╮
+2 ⏣─│─╮
+4 │ ◯ │
+3 ◯ │ │
+5 │ ◯─╯
+6 ◯─╯`,
+		},
+		{
+			"Parents that aren't loaded don't get a lane",
+			`
+1 | 2 9
+2 | 8
+3 | 4
+4 | 7`,
+			`
+1 ⏣
+2 ◯
+3 ◯
+4 ◯`,
+		},
+		{
+			"Abbreviated parent shas match full commit shas",
+			`
+aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa | bbbbbbbbbbbbbbbbbbbb cccccccccccccccccccc
+bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb | cccccccccccccccccccc
+cccccccccccccccccccccccccccccccccccccccc |`,
+			`
+aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa ⏣─╮
+bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb ◯ │
+cccccccccccccccccccccccccccccccccccccccc ◯─╯`,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			commits := parseCommits(s.commits)
+
+			lines := RenderCommitGraph(commits)
+			output := make([]string, len(lines))
+			for i, line := range lines {
+				output[i] = strings.TrimRight(commits[i].Sha+" "+utils.Decolorise(line), " ")
+			}
+
+			assert.Equal(t, strings.TrimPrefix(s.expectedOutput, "\n"), strings.Join(output, "\n"))
+		})
+	}
+}
+
+func TestRenderCommitGraphEmpty(t *testing.T) {
+	assert.Empty(t, RenderCommitGraph(nil))
+}
+
+func BenchmarkRenderCommitGraph(b *testing.B) {
+	// a long-running main branch with a short-lived feature branch merged in every few commits
+	commits := []*models.Commit{}
+	for i := 0; i < 1000; i++ {
+		parents := []string{fmt.Sprintf("%d", i+1)}
+		if i%5 == 0 {
+			parents = append(parents, fmt.Sprintf("f%d", i))
+		}
+		commits = append(commits, &models.Commit{Sha: fmt.Sprintf("%d", i), Parents: parents})
+		if i%5 == 0 {
+			commits = append(commits, &models.Commit{Sha: fmt.Sprintf("f%d", i), Parents: []string{fmt.Sprintf("%d", i+3)}})
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		RenderCommitGraph(commits)
+	}
+}
+
+func parseCommits(str string) []*models.Commit {
+	commits := []*models.Commit{}
+	for _, line := range utils.SplitLines(strings.TrimPrefix(str, "\n")) {
+		parts := strings.Split(line, "|")
+		commits = append(commits, &models.Commit{
+			Sha:     strings.TrimSpace(parts[0]),
+			Parents: strings.Fields(parts[1]),
+		})
+	}
+	return commits
+}
@@ -0,0 +1,55 @@
+package presentation
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/kyokomi/emoji/v2"
+)
+
+func GetLostCommitListDisplayStrings(commits []*models.Commit, fullDescription bool, cherryPickedCommitShaMap map[string]bool, diffName string, parseEmoji bool) [][]string {
+	lines := make([][]string, len(commits))
+
+	for i := range commits {
+		diffed := commits[i].Sha == diffName
+		lines[i] = getDisplayStringsForLostCommit(commits[i], fullDescription, cherryPickedCommitShaMap, diffed, parseEmoji)
+	}
+
+	return lines
+}
+
+func getDisplayStringsForLostCommit(c *models.Commit, fullDescription bool, cherryPickedCommitShaMap map[string]bool, diffed, parseEmoji bool) []string {
+	colorAttr := theme.DefaultTextColor
+	if diffed {
+		colorAttr = theme.DiffTerminalColor
+	}
+
+	name := c.Name
+	if parseEmoji {
+		name = emoji.Sprint(name)
+	}
+
+	kind := "commit"
+	kindColor := style.FgYellow
+	if c.IsStash() {
+		kind = "stash"
+		kindColor = style.FgCyan
+	}
+
+	if !fullDescription {
+		return []string{
+			coloredReflogSha(c, cherryPickedCommitShaMap),
+			kindColor.Sprint(kind),
+			colorAttr.Sprint(name),
+		}
+	}
+
+	return []string{
+		coloredReflogSha(c, cherryPickedCommitShaMap),
+		kindColor.Sprint(kind),
+		style.FgMagenta.Sprint(utils.UnixToDate(c.UnixTimestamp)),
+		style.FgGreen.Sprint(utils.TruncateWithEllipsis(c.Author, 17)),
+		colorAttr.Sprint(name),
+	}
+}
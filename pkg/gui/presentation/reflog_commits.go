@@ -11,16 +11,18 @@ import (
 func GetReflogCommitListDisplayStrings(commits []*models.Commit, fullDescription bool, cherryPickedCommitShaMap map[string]bool, diffName string, parseEmoji bool) [][]string {
 	lines := make([][]string, len(commits))
 
-	var displayFunc func(*models.Commit, map[string]bool, bool, bool) []string
+	var displayFunc func(*models.Commit, string, map[string]bool, bool, bool) []string
 	if fullDescription {
 		displayFunc = getFullDescriptionDisplayStringsForReflogCommit
 	} else {
 		displayFunc = getDisplayStringsForReflogCommit
 	}
 
+	graphLines := getGraphLines(commits)
+
 	for i := range commits {
 		diffed := commits[i].Sha == diffName
-		lines[i] = displayFunc(commits[i], cherryPickedCommitShaMap, diffed, parseEmoji)
+		lines[i] = displayFunc(commits[i], graphLines[i], cherryPickedCommitShaMap, diffed, parseEmoji)
 	}
 
 	return lines
@@ -35,7 +37,7 @@ func coloredReflogSha(c *models.Commit, cherryPickedCommitShaMap map[string]bool
 	return shaColor.Sprint(c.ShortSha())
 }
 
-func getFullDescriptionDisplayStringsForReflogCommit(c *models.Commit, cherryPickedCommitShaMap map[string]bool, diffed, parseEmoji bool) []string {
+func getFullDescriptionDisplayStringsForReflogCommit(c *models.Commit, graphLine string, cherryPickedCommitShaMap map[string]bool, diffed, parseEmoji bool) []string {
 	colorAttr := theme.DefaultTextColor
 	if diffed {
 		colorAttr = theme.DiffTerminalColor
@@ -49,11 +51,11 @@ func getFullDescriptionDisplayStringsForReflogCommit(c *models.Commit, cherryPic
 	return []string{
 		coloredReflogSha(c, cherryPickedCommitShaMap),
 		style.FgMagenta.Sprint(utils.UnixToDate(c.UnixTimestamp)),
-		colorAttr.Sprint(name),
+		graphLine + colorAttr.Sprint(name),
 	}
 }
 
-func getDisplayStringsForReflogCommit(c *models.Commit, cherryPickedCommitShaMap map[string]bool, diffed, parseEmoji bool) []string {
+func getDisplayStringsForReflogCommit(c *models.Commit, graphLine string, cherryPickedCommitShaMap map[string]bool, diffed, parseEmoji bool) []string {
 	name := c.Name
 	if parseEmoji {
 		name = emoji.Sprint(name)
@@ -61,6 +63,6 @@ func getDisplayStringsForReflogCommit(c *models.Commit, cherryPickedCommitShaMap
 
 	return []string{
 		coloredReflogSha(c, cherryPickedCommitShaMap),
-		theme.DefaultTextColor.Sprint(name),
+		graphLine + theme.DefaultTextColor.Sprint(name),
 	}
 }
@@ -1,26 +1,54 @@
 package presentation
 
 import (
+	"strings"
+
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
 	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
-func GetTagListDisplayStrings(tags []*models.Tag, diffName string) [][]string {
+func GetTagListDisplayStrings(tags []*models.Tag, fullDescription bool, diffName string) [][]string {
 	lines := make([][]string, len(tags))
 
 	for i := range tags {
 		diffed := tags[i].Name == diffName
-		lines[i] = getTagDisplayStrings(tags[i], diffed)
+		lines[i] = getTagDisplayStrings(tags[i], fullDescription, diffed)
 	}
 
 	return lines
 }
 
-// getTagDisplayStrings returns the display string of branch
-func getTagDisplayStrings(t *models.Tag, diffed bool) []string {
+// getTagDisplayStrings returns the display string of a tag
+func getTagDisplayStrings(t *models.Tag, fullDescription bool, diffed bool) []string {
 	textStyle := theme.DefaultTextColor
 	if diffed {
 		textStyle = theme.DiffTerminalColor
 	}
-	return []string{textStyle.Sprint(t.Name)}
+
+	// lightweight tags don't have a message of their own
+	subject := ""
+	if t.Annotated {
+		subject = style.FgYellow.Sprint(strings.SplitN(t.Message, "\n", 2)[0])
+	}
+
+	if !fullDescription {
+		return []string{textStyle.Sprint(t.Name), subject}
+	}
+
+	kind := "lightweight"
+	if t.Signed {
+		kind = "signed"
+	} else if t.Annotated {
+		kind = "annotated"
+	}
+
+	return []string{
+		textStyle.Sprint(t.Name),
+		style.FgMagenta.Sprint(kind),
+		style.FgBlue.Sprint(utils.UnixToDate(t.UnixTimestamp)),
+		style.FgGreen.Sprint(t.Tagger),
+		subject,
+	}
 }
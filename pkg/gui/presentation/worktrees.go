@@ -0,0 +1,67 @@
+package presentation
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+func GetWorktreeListDisplayStrings(worktrees []*models.Worktree, fullDescription bool) [][]string {
+	lines := make([][]string, len(worktrees))
+
+	for i := range worktrees {
+		lines[i] = getWorktreeDisplayStrings(worktrees[i], fullDescription)
+	}
+
+	return lines
+}
+
+// getWorktreeDisplayStrings returns the display string of a worktree
+func getWorktreeDisplayStrings(w *models.Worktree, fullDescription bool) []string {
+	marker := " "
+	nameTextStyle := theme.DefaultTextColor
+	if w.Current {
+		marker = style.FgGreen.Sprint("*")
+		nameTextStyle = style.FgGreen
+	}
+
+	name := w.Name()
+	if w.Main {
+		name += " (main)"
+	}
+
+	var ref string
+	switch {
+	case w.Bare:
+		ref = style.FgMagenta.Sprint("(bare)")
+	case w.IsDetached():
+		ref = style.FgYellow.Sprintf("(detached %s)", utils.SafeTruncate(w.Head, 8))
+	default:
+		ref = GetBranchTextStyle(w.Branch).Sprint(w.Branch)
+	}
+
+	if w.Prunable {
+		ref += " " + style.FgRed.Sprint("(prunable)")
+	} else if w.Locked {
+		ref += " " + style.FgRed.Sprint("(locked)")
+	}
+
+	res := []string{marker, nameTextStyle.Sprint(name), ref}
+	if fullDescription {
+		return append(res, style.FgCyan.Sprint(w.Path))
+	}
+	return res
+}
+
+// worktreeForBranch returns the worktree (other than the one we're in) that has
+// the given branch checked out
+func worktreeForBranch(branch *models.Branch, worktrees []*models.Worktree) *models.Worktree {
+	for _, worktree := range worktrees {
+		if !worktree.Current && worktree.Branch != "" && worktree.Branch == branch.Name {
+			return worktree
+		}
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package gui
 
 import (
+	"github.com/jesseduffield/lazygit/pkg/commands"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 )
 
@@ -17,16 +18,17 @@ func (gui *Gui) getSelectedReflogCommit() *models.Commit {
 }
 
 func (gui *Gui) handleReflogCommitSelect() error {
+	state := gui.State.Panels.ReflogCommits
+	gui.loadMoreIfNecessary(&state.pagination, state.SelectedLineIdx, len(gui.State.FilteredReflogCommits), gui.loadMoreReflogCommits)
+
 	commit := gui.getSelectedReflogCommit()
 	var task updateTask
 	if commit == nil {
 		task = NewRenderStringTask("No reflog history")
 	} else {
-		cmd := gui.OSCommand.ExecutableFromString(
-			gui.GitCommand.ShowCmdStr(commit.Sha, gui.State.Modes.Filtering.GetPath()),
-		)
-
-		task = NewRunPtyTask(cmd)
+		task = gui.diffTask(func(plain bool) string {
+			return gui.GitCommand.ShowCmdStr(commit.Sha, gui.State.Modes.Filtering.GetPath(), plain)
+		})
 	}
 
 	return gui.refreshMainViews(refreshMainOpts{
@@ -45,16 +47,23 @@ func (gui *Gui) handleReflogCommitSelect() error {
 // FilteredReflogCommits are rendered in the reflogs panel, and ReflogCommits
 // are used by the branches panel to obtain recency values for sorting.
 func (gui *Gui) refreshReflogCommits() error {
+	gui.Mutexes.ReflogCommitsMutex.Lock()
+	defer gui.Mutexes.ReflogCommitsMutex.Unlock()
+
 	// pulling state into its own variable incase it gets swapped out for another state
 	// and we get an out of bounds exception
 	state := gui.State
+	pagination := &state.Panels.ReflogCommits.pagination
 	var lastReflogCommit *models.Commit
 	if len(state.ReflogCommits) > 0 {
 		lastReflogCommit = state.ReflogCommits[0]
 	}
 
 	refresh := func(stateCommits *[]*models.Commit, filterPath string) error {
-		commits, onlyObtainedNewReflogCommits, err := gui.GitCommand.GetReflogCommits(lastReflogCommit, filterPath)
+		commits, onlyObtainedNewReflogCommits, err := gui.GitCommand.GetReflogCommits(
+			lastReflogCommit,
+			commands.GetReflogCommitsOptions{FilterPath: filterPath, Limit: pagination.limit},
+		)
 		if err != nil {
 			return gui.surfaceError(err)
 		}
@@ -71,6 +80,11 @@ func (gui *Gui) refreshReflogCommits() error {
 		return err
 	}
 
+	// HEAD has moved, so commits may have been lost along the way
+	if lastReflogCommit != nil && (len(state.ReflogCommits) == 0 || state.ReflogCommits[0] != lastReflogCommit) {
+		gui.forgetLostCommits()
+	}
+
 	if gui.State.Modes.Filtering.Active() {
 		if err := refresh(&state.FilteredReflogCommits, state.Modes.Filtering.GetPath()); err != nil {
 			return err
@@ -78,6 +92,45 @@ func (gui *Gui) refreshReflogCommits() error {
 	} else {
 		state.FilteredReflogCommits = state.ReflogCommits
 	}
+	pagination.afterRefresh(len(state.FilteredReflogCommits))
+
+	return gui.postRefreshUpdate(gui.State.Contexts.ReflogCommits)
+}
+
+// loadMoreReflogCommits loads the next page of the reflog onto the end of the
+// list
+func (gui *Gui) loadMoreReflogCommits() error {
+	gui.Mutexes.ReflogCommitsMutex.Lock()
+
+	state := gui.State
+	filterPath := state.Modes.Filtering.GetPath()
+	commits, _, err := gui.GitCommand.GetReflogCommits(
+		nil,
+		commands.GetReflogCommitsOptions{
+			FilterPath: filterPath,
+			Limit:      commands.COMMITS_PAGE_SIZE,
+			Skip:       len(state.FilteredReflogCommits),
+		},
+	)
+	if err != nil {
+		gui.Mutexes.ReflogCommitsMutex.Unlock()
+		return err
+	}
+
+	if filterPath != "" {
+		state.FilteredReflogCommits = append(state.FilteredReflogCommits, commits...)
+	} else {
+		state.ReflogCommits = append(state.ReflogCommits, commits...)
+		state.FilteredReflogCommits = state.ReflogCommits
+	}
+	state.Panels.ReflogCommits.pagination.afterLoadingMore(len(commits))
+
+	gui.Mutexes.ReflogCommitsMutex.Unlock()
+
+	if filterPath == "" {
+		// older reflog entries can tell us when more of our branches were last checked out
+		gui.refreshBranches()
+	}
 
 	return gui.postRefreshUpdate(gui.State.Contexts.ReflogCommits)
 }
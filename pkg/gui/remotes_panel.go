@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/jesseduffield/lazygit/pkg/commands"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/gui/style"
 	"github.com/jesseduffield/lazygit/pkg/utils"
@@ -173,13 +174,29 @@ func (gui *Gui) handleFetchRemote() error {
 		return nil
 	}
 
-	return gui.WithWaitingStatus(gui.Tr.FetchingRemoteStatus, func() error {
+	return gui.fetchRemote(remote.Name)
+}
+
+func (gui *Gui) fetchRemote(remoteName string) error {
+	return gui.WithRemoteOperationStatus(gui.Tr.FetchingRemoteStatus, gui.GitCommand, func(gitCommand *commands.GitCommand) error {
 		gui.Mutexes.FetchMutex.Lock()
 		defer gui.Mutexes.FetchMutex.Unlock()
 
-		err := gui.GitCommand.FetchRemote(remote.Name, gui.promptUserForCredential)
+		err := gitCommand.FetchRemote(remoteName, gui.promptUserForCredential)
 		gui.handleCredentialsPopup(err)
 
 		return gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{BRANCHES, REMOTES}})
 	})
 }
+
+func (gui *Gui) handleFetchAllRemotes() error {
+	return gui.WithRemoteOperationStatus(gui.Tr.FetchingAllRemotesStatus, gui.GitCommand.WithSpan(gui.Tr.Spans.FetchAllRemotes), func(gitCommand *commands.GitCommand) error {
+		gui.Mutexes.FetchMutex.Lock()
+		defer gui.Mutexes.FetchMutex.Unlock()
+
+		err := gitCommand.FetchAllRemotes(gui.promptUserForCredential)
+		gui.handleCredentialsPopup(err)
+
+		return gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{BRANCHES, REMOTES, COMMITS}})
+	})
+}
@@ -12,10 +12,14 @@ func (gui *Gui) resetToRef(ref string, strength string, span string, options osc
 		return gui.surfaceError(err)
 	}
 
+	return gui.afterResetToRef()
+}
+
+func (gui *Gui) afterResetToRef() error {
 	gui.State.Panels.Commits.SelectedLineIdx = 0
 	gui.State.Panels.ReflogCommits.SelectedLineIdx = 0
 	// loading a heap of commits is slow so we limit them whenever doing a reset
-	gui.State.Panels.Commits.LimitCommits = true
+	gui.State.Panels.Commits.pagination.reset()
 
 	if err := gui.pushContext(gui.State.Contexts.BranchCommits); err != nil {
 		return err
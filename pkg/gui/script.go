@@ -0,0 +1,280 @@
+package gui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/script"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"golang.org/x/term"
+)
+
+// When running a script, the gui isn't drawn and each step is carried out by
+// the same handler that the step's keybinding would call. Nobody's there to
+// answer the gui's popups, so the step answers them instead: it says yes to
+// the confirmations it expects (e.g. 'are you sure you want to merge?') and
+// types its arguments into any prompts. Any other popup fails the step, as
+// does any error the gui would have shown. Everything that would normally
+// happen in the background, like refreshing the panels, happens before the
+// step finishes so that the next step sees the result.
+
+type scriptState struct {
+	// how many more confirmations the current step will say yes to
+	confirmations int
+	// what the current step types into the prompts it expects, in order
+	answers []string
+	// the first error the gui tried to show during the current step
+	err error
+}
+
+func (gui *Gui) runningScript() bool {
+	return gui.script != nil
+}
+
+// RunScript runs the given steps through the gui's handlers, stopping at the
+// first one that fails
+func (gui *Gui) RunScript(steps []script.Step, out io.Writer) error {
+	if err := script.Validate(steps); err != nil {
+		return err
+	}
+
+	gui.script = &scriptState{}
+	gui.stopChan = make(chan struct{})
+	defer close(gui.stopChan)
+
+	g, err := gocui.NewGui(gocui.OutputTrue, OverlappingEdges, gocui.NORMAL, true)
+	if err != nil {
+		return err
+	}
+
+	gui.g = g
+	defer g.Close()
+
+	if err := gui.setColorScheme(); err != nil {
+		return err
+	}
+
+	result := make(chan error, 1)
+	gui.waitForIntro.Add(1)
+	go utils.Safe(func() {
+		// waiting until the views have been created and the repo loaded
+		gui.waitForIntro.Wait()
+		result <- script.Run(&scriptHandlers{gui: gui}, steps, out)
+		g.Update(func(*gocui.Gui) error { return gocui.ErrQuit })
+	})
+
+	g.SetManager(gocui.ManagerFunc(gui.layout), gocui.ManagerFunc(gui.getFocusLayout()))
+
+	// the main loop only lays out the views after handling an event
+	g.Update(func(*gocui.Gui) error { return nil })
+
+	if err := g.MainLoop(); err != gocui.ErrQuit {
+		return err
+	}
+
+	for _, manager := range gui.viewBufferManagerMap {
+		manager.Close()
+	}
+
+	return <-result
+}
+
+// failScriptStep is called instead of showing an error, and returns the error
+// so that handlers returning it stop there
+func (gui *Gui) failScriptStep(err error) error {
+	if gui.script.err == nil {
+		gui.script.err = err
+	}
+	return err
+}
+
+// answerPopupFromScript is called instead of showing a popup
+func (gui *Gui) answerPopupFromScript(opts createPopupPanelOpts) error {
+	if opts.hasLoader {
+		return nil
+	}
+
+	if opts.editable {
+		if len(gui.script.answers) == 0 || opts.handleConfirmPrompt == nil {
+			return gui.failScriptStep(gui.cantAnswerFromScriptError(opts.title))
+		}
+		answer := gui.script.answers[0]
+		gui.script.answers = gui.script.answers[1:]
+		return opts.handleConfirmPrompt(answer)
+	}
+
+	if gui.script.confirmations == 0 {
+		question := opts.prompt
+		if question == "" {
+			question = opts.title
+		}
+		return gui.failScriptStep(gui.cantAnswerFromScriptError(question))
+	}
+	gui.script.confirmations--
+	if opts.handleConfirm == nil {
+		return nil
+	}
+	return opts.handleConfirm()
+}
+
+func (gui *Gui) cantAnswerFromScriptError(question string) error {
+	return errors.New(utils.ResolvePlaceholderString(
+		gui.Tr.CantAnswerFromScript,
+		map[string]string{"question": strings.TrimSpace(utils.Decolorise(question))},
+	))
+}
+
+// promptForCredentialInTerminal is used instead of the credentials panel, which
+// isn't drawn when running a script
+func promptForCredentialInTerminal(prompt string) string {
+	fmt.Fprint(os.Stderr, strings.TrimSpace(prompt)+" ")
+
+	if isSecretCredentialPrompt(prompt) {
+		credential, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return ""
+		}
+		return string(credential)
+	}
+
+	credential, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return ""
+	}
+	return strings.TrimRight(credential, "\r\n")
+}
+
+// scriptHandlers lets a script carry out its actions through the gui
+type scriptHandlers struct {
+	gui *Gui
+}
+
+// run carries out a step on the main loop, just like a keybinding would.
+// confirmations is the number of confirmations the step says yes to, and
+// answers are what it types into the prompts it expects.
+func (h *scriptHandlers) run(confirmations int, answers []string, f func() error) error {
+	done := make(chan error)
+	h.gui.g.Update(func(*gocui.Gui) error {
+		h.gui.script = &scriptState{confirmations: confirmations, answers: answers}
+		err := f()
+		if h.gui.script.err != nil {
+			err = h.gui.script.err
+		}
+		done <- err
+		return nil
+	})
+	return <-done
+}
+
+func (h *scriptHandlers) Checkout(ref string) error {
+	return h.run(0, nil, func() error {
+		return h.gui.handleCheckoutRef(ref, handleCheckoutRefOptions{span: h.gui.Tr.Spans.CheckoutBranch})
+	})
+}
+
+func (h *scriptHandlers) CreateBranch(name string, base string) error {
+	return h.run(0, nil, func() error {
+		return h.gui.createNewBranch(name, base)
+	})
+}
+
+func (h *scriptHandlers) DeleteBranch(name string, force bool) error {
+	// without 'force', the question of whether to force delete an unmerged
+	// branch goes unanswered
+	return h.run(1, nil, func() error {
+		return h.gui.deleteNamedBranch(&models.Branch{Name: name}, force)
+	})
+}
+
+func (h *scriptHandlers) Merge(ref string) error {
+	return h.run(1, nil, func() error {
+		return h.gui.mergeBranchIntoCheckedOutBranch(ref)
+	})
+}
+
+func (h *scriptHandlers) Rebase(ref string) error {
+	return h.run(1, nil, func() error {
+		return h.gui.handleRebaseOntoBranch(ref)
+	})
+}
+
+func (h *scriptHandlers) ContinueRebase() error {
+	return h.run(0, nil, func() error {
+		return h.gui.genericMergeCommand("continue")
+	})
+}
+
+func (h *scriptHandlers) AbortRebase() error {
+	return h.run(0, nil, func() error {
+		return h.gui.genericMergeCommand("abort")
+	})
+}
+
+func (h *scriptHandlers) Fetch(remoteName string) error {
+	return h.run(0, nil, func() error {
+		if remoteName == "" {
+			return h.gui.handleGitFetch()
+		}
+		return h.gui.fetchRemote(remoteName)
+	})
+}
+
+func (h *scriptHandlers) Pull() error {
+	return h.run(0, nil, h.gui.handlePullFiles)
+}
+
+func (h *scriptHandlers) Push(force bool) error {
+	// with 'force', we say yes when asked whether to force push
+	confirmations := 0
+	if force {
+		confirmations = 1
+	}
+	return h.run(confirmations, nil, h.gui.pushFiles)
+}
+
+func (h *scriptHandlers) StageAll() error {
+	return h.run(0, nil, func() error {
+		// the keybinding unstages everything if it's all staged already
+		if h.gui.allFilesStaged() {
+			return nil
+		}
+		return h.gui.handleStageAll()
+	})
+}
+
+func (h *scriptHandlers) Commit(message string) error {
+	return h.run(0, nil, func() error {
+		return h.gui.commit(message)
+	})
+}
+
+func (h *scriptHandlers) Stash(message string) error {
+	return h.run(0, []string{message}, h.gui.handleStashChanges)
+}
+
+func (h *scriptHandlers) StashPop() error {
+	return h.run(1, nil, func() error {
+		h.gui.State.Panels.Stash.SelectedLineIdx = 0
+		return h.gui.handleStashPop()
+	})
+}
+
+func (h *scriptHandlers) Tag(name string, ref string) error {
+	return h.run(0, []string{name}, func() error {
+		return h.gui.handleCreateLightweightTag(ref)
+	})
+}
+
+func (h *scriptHandlers) Reset(ref string, strength string) error {
+	return h.run(0, nil, func() error {
+		return h.gui.resetToRef(ref, strength, "Reset", oscommands.RunCommandOptions{})
+	})
+}
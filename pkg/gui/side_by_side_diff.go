@@ -0,0 +1,36 @@
+package gui
+
+// diffTask returns the task for showing a diff in a main view. getCmdStr gives
+// the command for the diff, which needs to be plain (uncoloured) when we're the
+// ones rendering it side by side.
+func (gui *Gui) diffTask(getCmdStr func(plain bool) string) updateTask {
+	if gui.State.SideBySideDiff {
+		return NewRenderDiffTask(getCmdStr(true), true)
+	}
+
+	return NewRunPtyTask(gui.OSCommand.ExecutableFromString(getCmdStr(false)))
+}
+
+func (gui *Gui) handleToggleSideBySideDiff() error {
+	// the staging and merging panels have their own way of showing changes
+	if gui.State.MainContext != MAIN_NORMAL_CONTEXT_KEY && gui.State.MainContext != MAIN_PATCH_BUILDING_CONTEXT_KEY {
+		return nil
+	}
+
+	gui.State.SideBySideDiff = !gui.State.SideBySideDiff
+
+	toastMessage := gui.Tr.ShowingUnifiedDiff
+	if gui.State.SideBySideDiff {
+		toastMessage = gui.Tr.ShowingSideBySideDiff
+	}
+	gui.raiseToast(toastMessage)
+
+	if gui.State.MainContext == MAIN_PATCH_BUILDING_CONTEXT_KEY {
+		return gui.withLBLActiveCheck(func(state *LblPanelState) error {
+			return gui.refreshAndFocusLblPanel(state)
+		})
+	}
+
+	// re-rendering the side panel's selection re-renders the main view
+	return gui.currentSideContext().HandleFocus()
+}
@@ -0,0 +1,182 @@
+package gui
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// sparseCheckoutDir returns the directory the selected node in the files panel
+// refers to, which for a file is the directory containing it. Files at the
+// root of the repo are always checked out so in that case we return "".
+func (gui *Gui) sparseCheckoutDir() string {
+	node := gui.getSelectedFileNode()
+	if node == nil {
+		return ""
+	}
+
+	dir := node.GetPath()
+	if node.File != nil {
+		dir = filepath.Dir(dir)
+	}
+	if dir == "." {
+		return ""
+	}
+
+	return filepath.ToSlash(dir)
+}
+
+func (gui *Gui) handleCreateSparseCheckoutMenu() error {
+	if !gui.GitCommand.IsSparseCheckout() {
+		return gui.createMenu(gui.Tr.SparseCheckoutTitle, []*menuItem{
+			{
+				displayString: gui.Tr.LcEnableSparseCheckout,
+				onPress: func() error {
+					return gui.sparseCheckoutAction(gui.Tr.Spans.EnableSparseCheckout, (*commands.GitCommand).SparseCheckoutInit)
+				},
+			},
+		}, createMenuOptions{showCancel: true})
+	}
+
+	cone, err := gui.GitCommand.GetSparseCheckoutCone()
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+	if cone == nil {
+		// sparse checkout was disabled from outside lazygit in the meantime
+		return nil
+	}
+
+	menuItems := []*menuItem{}
+
+	if dir := gui.sparseCheckoutDir(); dir != "" {
+		if cone.Status(dir) == models.ConeStatusInside {
+			menuItems = append(menuItems, &menuItem{
+				displayStrings: []string{gui.Tr.LcRemoveFromSparseCheckout, dir},
+				onPress: func() error {
+					return gui.removeFromSparseCheckout(cone, dir)
+				},
+			})
+		} else {
+			menuItems = append(menuItems, &menuItem{
+				displayStrings: []string{gui.Tr.LcAddToSparseCheckout, dir},
+				onPress: func() error {
+					return gui.addToSparseCheckout(dir)
+				},
+			})
+		}
+	}
+
+	menuItems = append(menuItems, &menuItem{
+		displayStrings: []string{gui.Tr.LcAddDirToSparseCheckout, ""},
+		onPress: func() error {
+			return gui.promptForSparseCheckoutDir(cone, false, gui.addToSparseCheckout)
+		},
+	}, &menuItem{
+		displayStrings: []string{gui.Tr.LcRemoveDirFromSparseCheckout, ""},
+		onPress: func() error {
+			return gui.promptForSparseCheckoutDir(cone, true, func(dir string) error {
+				return gui.removeFromSparseCheckout(cone, dir)
+			})
+		},
+	}, &menuItem{
+		displayStrings: []string{gui.Tr.LcReapplySparseCheckout, ""},
+		onPress: func() error {
+			return gui.sparseCheckoutAction(gui.Tr.Spans.ReapplySparseCheckout, (*commands.GitCommand).SparseCheckoutReapply)
+		},
+	}, &menuItem{
+		displayStrings: []string{gui.Tr.LcDisableSparseCheckout, ""},
+		onPress: func() error {
+			return gui.sparseCheckoutAction(gui.Tr.Spans.DisableSparseCheckout, (*commands.GitCommand).SparseCheckoutDisable)
+		},
+	})
+
+	// the current patterns are listed for reference
+	for _, dir := range cone.Dirs {
+		menuItems = append(menuItems, &menuItem{
+			displayStrings: []string{gui.Tr.LcInSparseCheckout, dir},
+			onPress: func() error {
+				return nil
+			},
+		})
+	}
+
+	return gui.createMenu(gui.Tr.SparseCheckoutTitle, menuItems, createMenuOptions{showCancel: true})
+}
+
+// promptForSparseCheckoutDir lets the user pick any directory in the repo,
+// including the ones that aren't checked out, suggesting those in the cone when
+// we're removing and the rest when we're adding
+func (gui *Gui) promptForSparseCheckoutDir(cone *models.SparseCheckoutCone, inCone bool, handleConfirm func(string) error) error {
+	allDirs, err := gui.GitCommand.GetDirectories()
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+
+	candidates := []string{}
+	for _, dir := range allDirs {
+		if (cone.Status(dir) == models.ConeStatusInside) == inCone {
+			candidates = append(candidates, dir)
+		}
+	}
+
+	return gui.prompt(promptOpts{
+		title: gui.Tr.SparseCheckoutDirPromptTitle,
+		findSuggestionsFunc: func(input string) []*types.Suggestion {
+			matchingDirs := utils.FuzzySearch(input, candidates)
+			suggestions := make([]*types.Suggestion, len(matchingDirs))
+			for i, dir := range matchingDirs {
+				suggestions[i] = &types.Suggestion{Value: dir, Label: dir}
+			}
+			return suggestions
+		},
+		handleConfirm: func(dir string) error {
+			dir = strings.Trim(filepath.ToSlash(strings.TrimSpace(dir)), "/")
+			if dir == "" {
+				return nil
+			}
+			if !utils.IncludesString(allDirs, dir) {
+				return gui.createErrorPanel(utils.ResolvePlaceholderString(
+					gui.Tr.NotADirectoryInRepo,
+					map[string]string{"dir": dir},
+				))
+			}
+			return handleConfirm(dir)
+		},
+	})
+}
+
+func (gui *Gui) addToSparseCheckout(dir string) error {
+	return gui.sparseCheckoutAction(gui.Tr.Spans.AddToSparseCheckout, func(gitCommand *commands.GitCommand) error {
+		return gitCommand.SparseCheckoutAdd(dir)
+	})
+}
+
+// removeFromSparseCheckout takes the directory out of the cone whether it's a
+// directory in the cone itself or only inside one
+func (gui *Gui) removeFromSparseCheckout(cone *models.SparseCheckoutCone, dir string) error {
+	return gui.sparseCheckoutAction(gui.Tr.Spans.RemoveFromSparseCheckout, func(gitCommand *commands.GitCommand) error {
+		allDirs, err := gitCommand.GetDirectories()
+		if err != nil {
+			return err
+		}
+
+		return gitCommand.SparseCheckoutSet(cone.Without(dir, allDirs))
+	})
+}
+
+// sparseCheckoutAction runs a sparse checkout command and then refreshes,
+// given that files will have come and gone
+func (gui *Gui) sparseCheckoutAction(span string, f func(*commands.GitCommand) error) error {
+	return gui.WithWaitingStatus(gui.Tr.LcUpdatingSparseCheckout, func() error {
+		if err := f(gui.GitCommand.WithSpan(span)); err != nil {
+			return err
+		}
+
+		return gui.refreshSidePanels(refreshOptions{mode: ASYNC})
+	})
+}
@@ -0,0 +1,92 @@
+package gui
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// Splitting a commit means stopping at it in a rebase and undoing it, so that
+// the user can commit its changes bit by bit from the files panel. Each time
+// they commit we check whether anything is left over and when nothing is, we
+// continue the rebase.
+
+type splitCommitState struct {
+	// the message of the commit being split, which we offer as a starting point
+	// for the messages of the new commits
+	message string
+}
+
+func (gui *Gui) handleSplitCommit() error {
+	if ok, err := gui.validateNotInFilterMode(); err != nil || !ok {
+		return err
+	}
+
+	if gui.GitCommand.WorkingTreeState() != commands.REBASE_MODE_NORMAL {
+		return gui.createErrorPanel(gui.Tr.CantSplitCommitWhileRebasing)
+	}
+
+	commit := gui.getSelectedLocalCommit()
+	if commit == nil {
+		return nil
+	}
+
+	if commit.IsMerge() {
+		return gui.createErrorPanel(gui.Tr.CantSplitMergeCommit)
+	}
+
+	return gui.ask(askOpts{
+		title:  gui.Tr.SplitCommitTitle,
+		prompt: gui.Tr.SplitCommitPrompt,
+		handleConfirm: func() error {
+			message, err := gui.GitCommand.GetCommitMessage(commit.Sha)
+			if err != nil {
+				return gui.surfaceError(err)
+			}
+
+			return gui.WithWaitingStatus(gui.Tr.RebasingStatus, func() error {
+				gui.recordForcePushLease()
+				err := gui.GitCommand.WithSpan(gui.Tr.Spans.SplitCommit).SplitCommit(gui.State.Commits, gui.State.Panels.Commits.SelectedLineIdx)
+				if err != nil {
+					return gui.handleGenericMergeCommandResult(err)
+				}
+
+				gui.State.SplitCommit = &splitCommitState{message: message}
+
+				if err := gui.refreshSidePanels(refreshOptions{mode: BLOCK_UI}); err != nil {
+					return err
+				}
+
+				return gui.pushContext(gui.State.Contexts.Files)
+			})
+		},
+	})
+}
+
+// splittingCommit returns the state of the split we're in the middle of, if any
+func (gui *Gui) splittingCommit() *splitCommitState {
+	if gui.State.SplitCommit != nil && gui.GitCommand.WorkingTreeState() != commands.REBASE_MODE_REBASING {
+		// the user has aborted or continued the rebase themselves
+		gui.State.SplitCommit = nil
+	}
+
+	return gui.State.SplitCommit
+}
+
+// continueSplitCommitIfDone continues the rebase once all of the split commit's
+// changes have been committed
+func (gui *Gui) continueSplitCommitIfDone() error {
+	if gui.splittingCommit() == nil {
+		return nil
+	}
+
+	hasChanges, err := gui.GitCommand.HasTrackedChanges()
+	if err != nil {
+		return err
+	}
+	if hasChanges {
+		return nil
+	}
+
+	gui.State.SplitCommit = nil
+
+	return gui.genericMergeCommand("continue")
+}
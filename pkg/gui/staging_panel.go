@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/jesseduffield/lazygit/pkg/commands/patch"
+	"github.com/jesseduffield/lazygit/pkg/gui/lbl"
 )
 
 func (gui *Gui) refreshStagingPanel(forceSecondaryFocused bool, selectedLineIdx int) error {
@@ -81,6 +82,16 @@ func (gui *Gui) handleTogglePanel() error {
 	})
 }
 
+// handleStagingReturn leaves word diff mode if we're in it, otherwise it leaves
+// the staging panel
+func (gui *Gui) handleStagingReturn() error {
+	if state := gui.State.Panels.LineByLine; state != nil && state.WordDiff != nil {
+		return gui.handleToggleWordDiff()
+	}
+
+	return gui.handleStagingEscape()
+}
+
 func (gui *Gui) handleStagingEscape() error {
 	gui.escapeLineByLinePanel()
 
@@ -130,10 +141,15 @@ func (gui *Gui) applySelection(reverse bool, state *LblPanelState) error {
 		return nil
 	}
 
-	firstLineIdx, lastLineIdx := state.SelectedRange()
-	patch := patch.ModifiedPatchForRange(gui.Log, file.Name, state.GetDiff(), firstLineIdx, lastLineIdx, reverse, false)
+	var modifiedPatch string
+	if state.WordDiff != nil {
+		modifiedPatch = state.WordDiff.ModifiedPatch(file.Name, reverse)
+	} else {
+		firstLineIdx, lastLineIdx := state.SelectedRange()
+		modifiedPatch = patch.ModifiedPatchForRange(gui.Log, file.Name, state.GetDiff(), firstLineIdx, lastLineIdx, reverse, false)
+	}
 
-	if patch == "" {
+	if modifiedPatch == "" {
 		return nil
 	}
 
@@ -143,7 +159,7 @@ func (gui *Gui) applySelection(reverse bool, state *LblPanelState) error {
 	if !reverse || state.SecondaryFocused {
 		applyFlags = append(applyFlags, "cached")
 	}
-	err := gui.GitCommand.WithSpan(gui.Tr.Spans.ApplyPatch).ApplyPatch(patch, applyFlags...)
+	err := gui.GitCommand.WithSpan(gui.Tr.Spans.ApplyPatch).ApplyPatch(modifiedPatch, applyFlags...)
 	if err != nil {
 		return gui.surfaceError(err)
 	}
@@ -151,6 +167,9 @@ func (gui *Gui) applySelection(reverse bool, state *LblPanelState) error {
 	if state.SelectingRange() {
 		state.SetLineSelectMode()
 	}
+	if state.WordDiff != nil && state.WordDiff.SelectingRange() {
+		state.WordDiff.SetLineSelectMode()
+	}
 
 	if err := gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{FILES}}); err != nil {
 		return err
@@ -160,3 +179,20 @@ func (gui *Gui) applySelection(reverse bool, state *LblPanelState) error {
 	}
 	return nil
 }
+
+// handleToggleWordDiff switches between staging whole lines and staging the
+// individual words of the block of changed lines containing the selected line
+func (gui *Gui) handleToggleWordDiff() error {
+	return gui.withLBLActiveCheck(func(state *LblPanelState) error {
+		if state.WordDiff != nil {
+			state.WordDiff = nil
+		} else {
+			state.WordDiff = lbl.NewWordDiffState(state.GetDiff(), state.GetSelectedLineIdx())
+			if state.WordDiff == nil {
+				return gui.createErrorPanel(gui.Tr.WordDiffRequiresChangedLine)
+			}
+		}
+
+		return gui.refreshAndFocusLblPanel(state)
+	})
+}
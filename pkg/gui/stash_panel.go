@@ -22,10 +22,9 @@ func (gui *Gui) handleStashEntrySelect() error {
 	if stashEntry == nil {
 		task = NewRenderStringTask(gui.Tr.NoStashEntries)
 	} else {
-		cmd := gui.OSCommand.ExecutableFromString(
-			gui.GitCommand.ShowStashEntryCmdStr(stashEntry.Index),
-		)
-		task = NewRunPtyTask(cmd)
+		task = gui.diffTask(func(plain bool) string {
+			return gui.GitCommand.ShowStashEntryCmdStr(stashEntry.Index, plain)
+		})
 	}
 
 	return gui.refreshMainViews(refreshMainOpts{
@@ -37,11 +36,34 @@ func (gui *Gui) handleStashEntrySelect() error {
 }
 
 func (gui *Gui) refreshStashEntries() error {
+	prevStashEntries := gui.State.StashEntries
 	gui.State.StashEntries = gui.GitCommand.GetStashEntries(gui.State.Modes.Filtering.GetPath())
 
+	// a dropped stash entry is lost, and a restored one is no longer lost
+	if prevStashEntries != nil && !stashEntriesEqual(prevStashEntries, gui.State.StashEntries) {
+		gui.forgetLostCommits()
+	}
+
+	// the stash view is shared with the lost & found tab
+	if ContextKey(gui.Views.Stash.Context) != STASH_CONTEXT_KEY {
+		return nil
+	}
+
 	return gui.State.Contexts.Stash.HandleRender()
 }
 
+func stashEntriesEqual(a []*models.StashEntry, b []*models.StashEntry) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Name != b[i].Name {
+			return false
+		}
+	}
+	return true
+}
+
 // specific functions
 
 func (gui *Gui) handleStashApply() error {
@@ -117,13 +139,25 @@ func (gui *Gui) handleStashSave(stashFunc func(message string) error) error {
 		return gui.createErrorPanel(gui.Tr.NoTrackedStagedFilesStash)
 	}
 
+	return gui.promptForStashMessage(stashFunc, nil)
+}
+
+// promptForStashMessage asks for the stash entry's message before stashing.
+// 'then' is called once the stash and files panels have been refreshed.
+func (gui *Gui) promptForStashMessage(stashFunc func(message string) error, then func() error) error {
 	return gui.prompt(promptOpts{
 		title: gui.Tr.StashChanges,
 		handleConfirm: func(stashComment string) error {
 			if err := stashFunc(stashComment); err != nil {
 				return gui.surfaceError(err)
 			}
-			return gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{STASH, FILES}})
+			if err := gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{STASH, FILES}}); err != nil {
+				return err
+			}
+			if then != nil {
+				return then()
+			}
+			return nil
 		},
 	})
 }
@@ -26,11 +26,18 @@ func (gui *Gui) refreshStatus() {
 	if currentBranch.IsRealBranch() {
 		status += presentation.ColoredBranchStatus(currentBranch) + " "
 	}
+	if currentBranch.HasPushRemote() {
+		status += presentation.ColoredPushRemoteStatus(currentBranch) + " "
+	}
 
 	if gui.GitCommand.WorkingTreeState() != commands.REBASE_MODE_NORMAL {
 		status += style.FgYellow.Sprintf("(%s) ", gui.GitCommand.WorkingTreeState())
 	}
 
+	if gui.GitCommand.IsSparseCheckout() {
+		status += style.FgCyan.Sprintf("(%s) ", gui.Tr.LcSparse)
+	}
+
 	name := presentation.GetBranchTextStyle(currentBranch.Name).Sprint(currentBranch.Name)
 	repoName := utils.GetCurrentRepoName()
 	status += fmt.Sprintf("%s → %s ", repoName, name)
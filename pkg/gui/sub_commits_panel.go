@@ -18,16 +18,17 @@ func (gui *Gui) getSelectedSubCommit() *models.Commit {
 }
 
 func (gui *Gui) handleSubCommitSelect() error {
+	state := gui.State.Panels.SubCommits
+	gui.loadMoreIfNecessary(&state.pagination, state.SelectedLineIdx, len(gui.State.SubCommits), gui.loadMoreSubCommits)
+
 	commit := gui.getSelectedSubCommit()
 	var task updateTask
 	if commit == nil {
 		task = NewRenderStringTask("No commits")
 	} else {
-		cmd := gui.OSCommand.ExecutableFromString(
-			gui.GitCommand.ShowCmdStr(commit.Sha, gui.State.Modes.Filtering.GetPath()),
-		)
-
-		task = NewRunPtyTask(cmd)
+		task = gui.diffTask(func(plain bool) string {
+			return gui.GitCommand.ShowCmdStr(commit.Sha, gui.State.Modes.Filtering.GetPath(), plain)
+		})
 	}
 
 	return gui.refreshMainViews(refreshMainOpts{
@@ -79,9 +80,11 @@ func (gui *Gui) switchToSubCommitsContext(refName string) error {
 	// need to populate my sub commits
 	builder := commands.NewCommitListBuilder(gui.Log, gui.GitCommand, gui.OSCommand, gui.Tr)
 
+	pagination := &gui.State.Panels.SubCommits.pagination
+	pagination.reset()
 	commits, err := builder.GetCommits(
 		commands.GetCommitsOptions{
-			Limit:                gui.State.Panels.Commits.LimitCommits,
+			Limit:                pagination.limit,
 			FilterPath:           gui.State.Modes.Filtering.GetPath(),
 			IncludeRebaseCommits: false,
 			RefName:              refName,
@@ -92,6 +95,7 @@ func (gui *Gui) switchToSubCommitsContext(refName string) error {
 	}
 
 	gui.State.SubCommits = commits
+	pagination.afterRefresh(len(commits))
 	gui.State.Panels.SubCommits.refName = refName
 	gui.State.Panels.SubCommits.SelectedLineIdx = 0
 	gui.State.Contexts.SubCommits.SetParentContext(gui.currentSideListContext())
@@ -107,3 +111,34 @@ func (gui *Gui) handleSwitchToSubCommits() error {
 
 	return gui.switchToSubCommitsContext(currentContext.GetSelectedItemId())
 }
+
+// loadMoreSubCommits loads the next page of commits onto the end of the list
+func (gui *Gui) loadMoreSubCommits() error {
+	state := gui.State.Panels.SubCommits
+	refName := state.refName
+	loadedCommits := gui.State.SubCommits
+	builder := commands.NewCommitListBuilder(gui.Log, gui.GitCommand, gui.OSCommand, gui.Tr)
+
+	commits, err := builder.GetMoreCommits(
+		loadedCommits,
+		commands.GetCommitsOptions{
+			Limit:      commands.COMMITS_PAGE_SIZE,
+			FilterPath: gui.State.Modes.Filtering.GetPath(),
+			RefName:    refName,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// we may have moved on to another ref's commits in the meantime
+	if state.refName != refName {
+		state.pagination.loadingMore = false
+		return nil
+	}
+
+	gui.State.SubCommits = commits
+	state.pagination.afterLoadingMore(len(commits) - len(loadedCommits))
+
+	return gui.postRefreshUpdate(gui.State.Contexts.SubCommits)
+}
@@ -0,0 +1,111 @@
+package syntax
+
+import "strings"
+
+type language struct {
+	extensions       []string
+	keywords         map[string]bool
+	lineComments     []string
+	blockComment     [2]string
+	stringDelimiters string
+}
+
+func (l *language) startsLineComment(str string) bool {
+	for _, lineComment := range l.lineComments {
+		if strings.HasPrefix(str, lineComment) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func keywordSet(keywords string) map[string]bool {
+	result := map[string]bool{}
+	for _, keyword := range strings.Fields(keywords) {
+		result[keyword] = true
+	}
+
+	return result
+}
+
+var cBlockComment = [2]string{"/*", "*/"}
+
+var languages = []*language{
+	{
+		extensions: []string{"go"},
+		keywords: keywordSet(`break case chan const continue default defer else fallthrough for func go goto if
+			import interface map package range return select struct switch type var true false nil iota`),
+		lineComments:     []string{"//"},
+		blockComment:     cBlockComment,
+		stringDelimiters: "\"'`",
+	},
+	{
+		extensions: []string{"c", "h", "cc", "cpp", "cxx", "hpp", "hxx"},
+		keywords: keywordSet(`auto break case char class const constexpr continue default delete do double else
+			enum extern float for goto if inline int long namespace new nullptr private protected public register
+			return short signed sizeof static struct switch template this typedef typename union unsigned using
+			virtual void volatile while true false NULL`),
+		lineComments:     []string{"//"},
+		blockComment:     cBlockComment,
+		stringDelimiters: "\"'",
+	},
+	{
+		extensions: []string{"java", "kt", "kts"},
+		keywords: keywordSet(`abstract boolean break byte case catch char class const continue default do double
+			else enum extends final finally float for fun if implements import instanceof int interface long new
+			null object override package private protected public return short static super switch this throw
+			throws try val var void when while true false`),
+		lineComments:     []string{"//"},
+		blockComment:     cBlockComment,
+		stringDelimiters: "\"'",
+	},
+	{
+		extensions: []string{"js", "jsx", "mjs", "cjs", "ts", "tsx"},
+		keywords: keywordSet(`async await break case catch class const continue default delete do else enum
+			export extends finally for from function if implements import in instanceof interface let new null
+			of return static super switch this throw try type typeof undefined var void while yield true false`),
+		lineComments:     []string{"//"},
+		blockComment:     cBlockComment,
+		stringDelimiters: "\"'`",
+	},
+	{
+		extensions: []string{"py"},
+		keywords: keywordSet(`and as assert async await break class continue def del elif else except finally
+			for from global if import in is lambda nonlocal not or pass raise return try while with yield None
+			True False self`),
+		lineComments:     []string{"#"},
+		stringDelimiters: "\"'",
+	},
+	{
+		extensions: []string{"rb"},
+		keywords: keywordSet(`alias and begin break case class def defined do else elsif end ensure false for if
+			in module next nil not or redo rescue retry return self super then true undef unless until when while
+			yield require`),
+		lineComments:     []string{"#"},
+		stringDelimiters: "\"'",
+	},
+	{
+		extensions: []string{"rs"},
+		keywords: keywordSet(`as async await break const continue crate dyn else enum extern fn for if impl in let
+			loop match mod move mut pub ref return self Self static struct super trait type unsafe use where while
+			true false`),
+		lineComments: []string{"//"},
+		blockComment: cBlockComment,
+		// a single quote is more often a lifetime than a char
+		stringDelimiters: "\"",
+	},
+	{
+		extensions: []string{"sh", "bash", "zsh"},
+		keywords: keywordSet(`case do done elif else esac export fi for function if in local return select then
+			until while`),
+		lineComments:     []string{"#"},
+		stringDelimiters: "\"'",
+	},
+	{
+		extensions:       []string{"yml", "yaml"},
+		keywords:         keywordSet(`true false null yes no on off`),
+		lineComments:     []string{"#"},
+		stringDelimiters: "\"'",
+	},
+}
@@ -0,0 +1,196 @@
+package syntax
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+)
+
+// The job of this file is to do some lightweight syntax highlighting without
+// relying on an external pager. We don't parse anything: we just pick out
+// comments, strings, numbers and keywords line by line, remembering whether a
+// block comment is still open at the end of a line.
+
+type TokenKind int
+
+const (
+	PLAIN TokenKind = iota
+	KEYWORD
+	STRING
+	COMMENT
+	NUMBER
+)
+
+type Token struct {
+	Kind TokenKind
+	Text string
+}
+
+// Style returns the style to print the token with, where plainStyle is used
+// for anything that isn't highlighted
+func (t Token) Style(plainStyle style.TextStyle) style.TextStyle {
+	switch t.Kind {
+	case KEYWORD:
+		return theme.SyntaxKeywordColor
+	case STRING:
+		return theme.SyntaxStringColor
+	case COMMENT:
+		return theme.SyntaxCommentColor
+	case NUMBER:
+		return theme.SyntaxNumberColor
+	}
+
+	return plainStyle
+}
+
+// Highlighter tokenises the lines of a single file in order
+type Highlighter struct {
+	language       *language
+	inBlockComment bool
+}
+
+// NewHighlighter returns a highlighter for the file at the given path, or nil
+// if syntax highlighting is disabled or we don't know the file's language
+func NewHighlighter(path string) *Highlighter {
+	if !theme.SyntaxHighlighting {
+		return nil
+	}
+
+	language := languageForFile(path)
+	if language == nil {
+		return nil
+	}
+
+	return &Highlighter{language: language}
+}
+
+// Tokenize splits the line into tokens. Lines must be passed in the order they
+// appear in the file so that we know when we're inside a block comment.
+func (h *Highlighter) Tokenize(line string) []Token {
+	tokens := []Token{}
+	add := func(kind TokenKind, text string) {
+		if text == "" {
+			return
+		}
+		if len(tokens) > 0 && tokens[len(tokens)-1].Kind == kind {
+			tokens[len(tokens)-1].Text += text
+			return
+		}
+		tokens = append(tokens, Token{Kind: kind, Text: text})
+	}
+
+	blockStart, blockEnd := h.language.blockComment[0], h.language.blockComment[1]
+
+	for i := 0; i < len(line); {
+		rest := line[i:]
+
+		if h.inBlockComment {
+			end := strings.Index(rest, blockEnd)
+			if end == -1 {
+				add(COMMENT, rest)
+				break
+			}
+			add(COMMENT, rest[:end+len(blockEnd)])
+			h.inBlockComment = false
+			i += end + len(blockEnd)
+			continue
+		}
+
+		if blockStart != "" && strings.HasPrefix(rest, blockStart) {
+			add(COMMENT, blockStart)
+			h.inBlockComment = true
+			i += len(blockStart)
+			continue
+		}
+
+		if h.language.startsLineComment(rest) {
+			add(COMMENT, rest)
+			break
+		}
+
+		char := line[i]
+		switch {
+		case strings.IndexByte(h.language.stringDelimiters, char) != -1:
+			end := stringEnd(rest)
+			add(STRING, rest[:end])
+			i += end
+		case isDigit(char):
+			end := wordEnd(rest, true)
+			add(NUMBER, rest[:end])
+			i += end
+		case isWordChar(char):
+			end := wordEnd(rest, false)
+			word := rest[:end]
+			if h.language.keywords[word] {
+				add(KEYWORD, word)
+			} else {
+				add(PLAIN, word)
+			}
+			i += end
+		default:
+			add(PLAIN, rest[:1])
+			i++
+		}
+	}
+
+	return tokens
+}
+
+// stringEnd returns the index just past the closing delimiter of the string
+// starting at the beginning of str, or the length of str if it isn't closed on
+// this line
+func stringEnd(str string) int {
+	delimiter := str[0]
+	for i := 1; i < len(str); i++ {
+		if str[i] == '\\' && delimiter != '`' {
+			i++
+			continue
+		}
+		if str[i] == delimiter {
+			return i + 1
+		}
+	}
+
+	return len(str)
+}
+
+// wordEnd returns the length of the word at the start of str. Numbers may
+// contain dots e.g. '1.5' or letters e.g. '0x1F'.
+func wordEnd(str string, number bool) int {
+	for i := 1; i < len(str); i++ {
+		if !isWordChar(str[i]) && !(number && str[i] == '.') {
+			return i
+		}
+	}
+
+	return len(str)
+}
+
+func isDigit(char byte) bool {
+	return char >= '0' && char <= '9'
+}
+
+// non-ascii bytes count as part of a word so that we never split a multi-byte
+// character in two
+func isWordChar(char byte) bool {
+	return isDigit(char) || (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z') || char == '_' || char >= 0x80
+}
+
+func languageForFile(path string) *language {
+	extension := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+	if extension == "" {
+		return nil
+	}
+
+	for _, language := range languages {
+		for _, languageExtension := range language.extensions {
+			if extension == languageExtension {
+				return language
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewHighlighter is a function.
+func TestNewHighlighter(t *testing.T) {
+	theme.SyntaxHighlighting = true
+	defer func() { theme.SyntaxHighlighting = false }()
+
+	assert.NotNil(t, NewHighlighter("pkg/gui/gui.go"))
+	assert.NotNil(t, NewHighlighter("scripts/RUN.SH"))
+	assert.Nil(t, NewHighlighter("README.md"))
+	assert.Nil(t, NewHighlighter("Makefile"))
+
+	theme.SyntaxHighlighting = false
+	assert.Nil(t, NewHighlighter("pkg/gui/gui.go"))
+}
+
+// TestTokenize is a function.
+func TestTokenize(t *testing.T) {
+	theme.SyntaxHighlighting = true
+	defer func() { theme.SyntaxHighlighting = false }()
+
+	type scenario struct {
+		testName string
+		path     string
+		lines    []string
+		expected [][]Token
+	}
+
+	scenarios := []scenario{
+		{
+			"Keywords, strings and numbers",
+			"main.go",
+			[]string{`	return fmt.Sprintf("%d", 0x1F) // done`},
+			[][]Token{
+				{
+					{PLAIN, "\t"},
+					{KEYWORD, "return"},
+					{PLAIN, " fmt.Sprintf("},
+					{STRING, `"%d"`},
+					{PLAIN, ", "},
+					{NUMBER, "0x1F"},
+					{PLAIN, ") "},
+					{COMMENT, "// done"},
+				},
+			},
+		},
+		{
+			"Escaped and unterminated strings",
+			"main.py",
+			[]string{`x = 'it\'s' + "open`},
+			[][]Token{
+				{
+					{PLAIN, "x = "},
+					{STRING, `'it\'s'`},
+					{PLAIN, " + "},
+					{STRING, `"open`},
+				},
+			},
+		},
+		{
+			"Comment markers inside strings",
+			"index.js",
+			[]string{`const url = "https://example.com" # not a comment`},
+			[][]Token{
+				{
+					{KEYWORD, "const"},
+					{PLAIN, " url = "},
+					{STRING, `"https://example.com"`},
+					{PLAIN, " # not a comment"},
+				},
+			},
+		},
+		{
+			"Block comments spanning lines",
+			"main.c",
+			[]string{"int x; /* start", "still a comment", "end */ return x;"},
+			[][]Token{
+				{
+					{KEYWORD, "int"},
+					{PLAIN, " x; "},
+					{COMMENT, "/* start"},
+				},
+				{
+					{COMMENT, "still a comment"},
+				},
+				{
+					{COMMENT, "end */"},
+					{PLAIN, " "},
+					{KEYWORD, "return"},
+					{PLAIN, " x;"},
+				},
+			},
+		},
+		{
+			"Identifiers containing keywords and non-ascii characters",
+			"main.rb",
+			[]string{"définir = ends_with"},
+			[][]Token{
+				{
+					{PLAIN, "définir = ends_with"},
+				},
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			highlighter := NewHighlighter(s.path)
+			result := [][]Token{}
+			for _, line := range s.lines {
+				result = append(result, highlighter.Tokenize(line))
+			}
+			assert.EqualValues(t, s.expected, result)
+		})
+	}
+}
@@ -1,7 +1,11 @@
 package gui
 
 import (
+	"fmt"
+
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
@@ -40,6 +44,54 @@ func (gui *Gui) handleCreateTag() error {
 	})
 }
 
+func (gui *Gui) handleCreateAnnotatedTagMenu() error {
+	return gui.handleCreateTagMenu("")
+}
+
+// handleCreateTagMenu lets the user choose between a plain annotated tag and a
+// signed one
+func (gui *Gui) handleCreateTagMenu(commitSha string) error {
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.LcAnnotatedTag,
+			onPress: func() error {
+				return gui.handleCreateAnnotatedTag(commitSha, false)
+			},
+		},
+		{
+			displayString: gui.Tr.LcSignedTag,
+			onPress: func() error {
+				return gui.handleCreateAnnotatedTag(commitSha, true)
+			},
+		},
+	}
+
+	return gui.createMenu(gui.Tr.CreateAnnotatedTagMenuTitle, menuItems, createMenuOptions{showCancel: true})
+}
+
+// handleCreateAnnotatedTag asks for the tag's name and then its message, for
+// which we use the commit message panel given messages can span multiple lines
+func (gui *Gui) handleCreateAnnotatedTag(commitSha string, sign bool) error {
+	return gui.prompt(promptOpts{
+		title: gui.Tr.TagNameTitle,
+		handleConfirm: func(tagName string) error {
+			title := utils.ResolvePlaceholderString(
+				gui.Tr.TagMessageTitle,
+				map[string]string{
+					"tagName": tagName,
+				},
+			)
+
+			return gui.promptForMessage(title, gui.Tr.TagWithoutMessageErr, func(message string) error {
+				cmdStr := gui.GitCommand.CreateAnnotatedTagCmdStr(tagName, commitSha, message, sign)
+				gui.OnRunCommand(oscommands.NewCmdLogEntry(cmdStr, gui.Tr.Spans.CreateAnnotatedTag, true))
+
+				return gui.withGpgHandling(cmdStr, gui.GitCommand.UsingGpgForTag(sign), gui.Tr.CreatingTagStatus, nil)
+			})
+		},
+	})
+}
+
 // tag-specific handlers
 // view model would need to raise an event called 'tag selected', perhaps containing a tag. The listener would _be_ the main view, or the main context, and it would be able to render to itself.
 func (gui *Gui) handleTagSelect() error {
@@ -51,7 +103,7 @@ func (gui *Gui) handleTagSelect() error {
 		cmd := gui.OSCommand.ExecutableFromString(
 			gui.GitCommand.GetBranchGraphCmdStr(tag.Name),
 		)
-		task = NewRunCommandTask(cmd)
+		task = NewRunCommandTaskWithPrefix(cmd, gui.tagDetails(tag))
 	}
 
 	return gui.refreshMainViews(refreshMainOpts{
@@ -62,6 +114,32 @@ func (gui *Gui) handleTagSelect() error {
 	})
 }
 
+// tagDetails is shown above the tag's log in the main view
+func (gui *Gui) tagDetails(tag *models.Tag) string {
+	if !tag.Annotated {
+		return fmt.Sprintf(
+			"Tag:    %s %s\nCommit: %s\n\n",
+			style.FgCyan.Sprint(tag.Name),
+			gui.Tr.LcLightweightTagParens,
+			style.FgYellow.Sprint(tag.Sha),
+		)
+	}
+
+	name := tag.Name
+	if tag.Signed {
+		name += " " + style.FgGreen.Sprint(gui.Tr.LcSignedTagParens)
+	}
+
+	return fmt.Sprintf(
+		"Tag:    %s\nTagger: %s\nDate:   %s\nCommit: %s\n\n%s\n\n",
+		style.FgCyan.Sprint(name),
+		style.FgGreen.Sprint(tag.Tagger),
+		style.FgBlue.Sprint(utils.UnixToDate(tag.UnixTimestamp)),
+		style.FgYellow.Sprint(tag.Sha),
+		tag.Message,
+	)
+}
+
 // this is a controller: it can't access tags directly. Or can it? It should be able to get but not set. But that's exactly what I'm doing here, setting it. but through a mutator which encapsulates the event.
 func (gui *Gui) refreshTags() error {
 	tags, err := gui.GitCommand.GetTags()
@@ -1,11 +1,17 @@
 package gui
 
 import (
+	"bytes"
+	"errors"
 	"os/exec"
 	"strings"
 
 	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/commands/patch"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
 	"github.com/jesseduffield/lazygit/pkg/tasks"
+	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
 func (gui *Gui) newCmdTask(view *gocui.View, cmd *exec.Cmd, prefix string) error {
@@ -76,6 +82,111 @@ func (gui *Gui) newStringTaskWithoutScroll(view *gocui.View, str string) error {
 	return nil
 }
 
+// newRenderDiffTask runs the diff command, which should give a plain diff, and
+// renders its output ourselves, either side by side to fit the view or as a
+// regular diff. The diff of an LFS pointer file is summarised instead.
+func (gui *Gui) newRenderDiffTask(view *gocui.View, cmdStr string, sideBySide bool) error {
+	manager := gui.getManager(view)
+
+	f := func(stop chan struct{}) error {
+		diff, err, stopped := gui.runDiffCommand(cmdStr, stop)
+		if stopped {
+			return nil
+		}
+		if err != nil {
+			gui.setViewContent(view, style.FgRed.Sprint(err.Error()))
+			return nil
+		}
+
+		if summary, ok := gui.lfsDiffSummary(diff); ok {
+			gui.setViewContent(view, summary)
+			return nil
+		}
+
+		patchParser := patch.NewPatchParser(gui.Log, diff)
+		if !sideBySide {
+			gui.setViewContent(view, patchParser.Render(-1, -1, nil))
+			return nil
+		}
+
+		// the side by side layout depends on the view's width, so we render it
+		// again whenever the view is resized until the task is stopped
+		rows := patchParser.SideBySideRows()
+		for {
+			resized := gui.mainViewResizedChan()
+			width, _ := view.Size()
+			gui.setViewContent(view, patchParser.RenderSideBySide(rows, width, -1, -1, nil))
+
+			select {
+			case <-stop:
+				return nil
+			case <-resized:
+			}
+		}
+	}
+
+	if err := manager.NewTask(f); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// runDiffCommand runs the command, killing it if the task is stopped first.
+// Commands like `git diff --no-index` exit with an error when there is a
+// difference, so it's only a failure if there's no diff.
+func (gui *Gui) runDiffCommand(cmdStr string, stop chan struct{}) (diff string, err error, stopped bool) {
+	cmd := gui.OSCommand.ExecutableFromString(cmdStr)
+	gui.Log.WithField("command", cmdStr).Debug("RunCommand")
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Start(); err != nil {
+		return "", err, false
+	}
+
+	done := make(chan error, 1)
+	go utils.Safe(func() {
+		done <- cmd.Wait()
+	})
+
+	select {
+	case <-stop:
+		if err := oscommands.Kill(cmd); err != nil {
+			gui.Log.Error(err)
+		}
+		<-done
+		return "", nil, true
+	case err = <-done:
+	}
+
+	if err != nil && stdout.Len() == 0 {
+		if stderr.Len() > 0 {
+			err = errors.New(strings.TrimSpace(stderr.String()))
+		}
+		return "", err, false
+	}
+
+	return stdout.String(), nil, false
+}
+
+func (gui *Gui) mainViewResizedChan() chan struct{} {
+	gui.Mutexes.MainViewResizedMutex.Lock()
+	defer gui.Mutexes.MainViewResizedMutex.Unlock()
+
+	return gui.mainViewResized
+}
+
+func (gui *Gui) notifyMainViewResized() {
+	gui.Mutexes.MainViewResizedMutex.Lock()
+	defer gui.Mutexes.MainViewResizedMutex.Unlock()
+
+	close(gui.mainViewResized)
+	gui.mainViewResized = make(chan struct{})
+}
+
 func (gui *Gui) getManager(view *gocui.View) *tasks.ViewBufferManager {
 	manager, ok := gui.viewBufferManagerMap[view.Name()]
 	if !ok {
@@ -2,166 +2,157 @@ package gui
 
 import (
 	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
-	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
 // Quick summary of how this all works:
-// when you want to undo or redo, we start from the top of the reflog and work
-// down until we've reached the last user-initiated reflog entry that hasn't already been undone
-// we then do the reverse of what that reflog describes.
-// When we do this, we create a new reflog entry, and tag it as either an undo or redo
-// Then, next time we want to undo, we'll use those entries to know which user-initiated
-// actions we can skip. E.g. if I do do three things, A, B, and C, and hit undo twice,
-// the reflog will read UUCBA, and when I read the first two undos, I know to skip the following
-// two user actions, meaning we end up undoing reflog entry C. Redoing works in a similar way.
-
-type ReflogActionKind int
-
-const (
-	CHECKOUT ReflogActionKind = iota
-	COMMIT
-	REBASE
-	CURRENT_REBASE
-)
-
-type reflogAction struct {
-	kind ReflogActionKind
-	from string
-	to   string
-}
-
-// Here we're going through the reflog and maintaining a counter that represents how many
-// undos/redos/user actions we've seen. when we hit a user action we call the callback specifying
-// what the counter is up to and the nature of the action.
-// If we find ourselves mid-rebase, we just return because undo/redo mid rebase
-// requires knowledge of previous TODO file states, which you can't just get from the reflog.
-// Though we might support this later, hence the use of the CURRENT_REBASE action kind.
-func (gui *Gui) parseReflogForActions(onUserAction func(counter int, action reflogAction) (bool, error)) error {
-	counter := 0
-	reflogCommits := gui.State.FilteredReflogCommits
-	rebaseFinishCommitSha := ""
-	var action *reflogAction
-	for reflogCommitIdx, reflogCommit := range reflogCommits {
-		action = nil
-
-		prevCommitSha := ""
-		if len(reflogCommits)-1 >= reflogCommitIdx+1 {
-			prevCommitSha = reflogCommits[reflogCommitIdx+1].Sha
-		}
-
-		if rebaseFinishCommitSha == "" {
-			if ok, _ := utils.FindStringSubmatch(reflogCommit.Name, `^\[lazygit undo\]`); ok {
-				counter++
-			} else if ok, _ := utils.FindStringSubmatch(reflogCommit.Name, `^\[lazygit redo\]`); ok {
-				counter--
-			} else if ok, _ := utils.FindStringSubmatch(reflogCommit.Name, `^rebase -i \(abort\)|^rebase -i \(finish\)`); ok {
-				rebaseFinishCommitSha = reflogCommit.Sha
-			} else if ok, match := utils.FindStringSubmatch(reflogCommit.Name, `^checkout: moving from ([\S]+) to ([\S]+)`); ok {
-				action = &reflogAction{kind: CHECKOUT, from: match[1], to: match[2]}
-			} else if ok, _ := utils.FindStringSubmatch(reflogCommit.Name, `^commit|^reset: moving to|^pull`); ok {
-				action = &reflogAction{kind: COMMIT, from: prevCommitSha, to: reflogCommit.Sha}
-			} else if ok, _ := utils.FindStringSubmatch(reflogCommit.Name, `^rebase -i \(start\)`); ok {
-				// if we're here then we must be currently inside an interactive rebase
-				action = &reflogAction{kind: CURRENT_REBASE, from: prevCommitSha}
-			}
-		} else if ok, _ := utils.FindStringSubmatch(reflogCommit.Name, `^rebase -i \(start\)`); ok {
-			action = &reflogAction{kind: REBASE, from: prevCommitSha, to: rebaseFinishCommitSha}
-			rebaseFinishCommitSha = ""
-		}
+// the operation journal (see pkg/commands/operation_journal.go) keeps a stack of
+// operations which can be undone, and a stack of those which have been undone
+// and can be redone. Operations which move HEAD are picked up from the reflog,
+// and we reverse them by resetting or checking out, tagging the resulting
+// reflog entries as undos/redos so that they aren't journaled themselves.
+// Everything else, like deleting a branch, is reversed using the state that was
+// recorded in the journal when the operation was performed.
+// Mid-rebase we can't use the journal, so instead we undo/redo the edits we've made
+// to the rebase todo file, using snapshots we take before each edit.
+
+func (gui *Gui) handleUndo() error {
+	if gui.GitCommand.WorkingTreeState() == commands.REBASE_MODE_REBASING {
+		return gui.rebaseTodoUndo()
+	}
 
-		if action != nil {
-			if action.kind != CURRENT_REBASE && action.from == action.to {
-				// if we're going from one place to the same place we'll ignore the action.
-				continue
-			}
-			ok, err := onUserAction(counter, *action)
-			if ok {
-				return err
-			}
-			counter--
-		}
+	operation, err := gui.GitCommand.NextOperationToUndo()
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+	if operation == nil {
+		gui.raiseToast(gui.Tr.NothingToUndo)
+		return nil
 	}
-	return nil
-}
 
-func (gui *Gui) reflogUndo() error {
+	onSuccess := func() error {
+		return gui.GitCommand.MarkOperationUndone(operation)
+	}
 	undoEnvVars := []string{"GIT_REFLOG_ACTION=[lazygit undo]"}
 	undoingStatus := gui.Tr.UndoingStatus
+	span := gui.Tr.Spans.Undo
 
-	if gui.GitCommand.WorkingTreeState() == commands.REBASE_MODE_REBASING {
-		return gui.createErrorPanel(gui.Tr.LcCantUndoWhileRebasing)
+	switch operation.Kind {
+	case models.OPERATION_MOVE_HEAD:
+		return gui.handleHardResetWithAutoStash(operation.From, handleHardResetWithAutoStashOptions{
+			EnvVars:       undoEnvVars,
+			WaitingStatus: undoingStatus,
+			span:          span,
+			onSuccess:     onSuccess,
+		})
+	case models.OPERATION_CHECKOUT:
+		return gui.handleCheckoutRef(operation.From, handleCheckoutRefOptions{
+			EnvVars:       undoEnvVars,
+			WaitingStatus: undoingStatus,
+			span:          span,
+			onSuccess:     onSuccess,
+		})
 	}
 
-	span := gui.Tr.Spans.Undo
-
-	return gui.parseReflogForActions(func(counter int, action reflogAction) (bool, error) {
-		if counter != 0 {
-			return false, nil
+	return gui.WithWaitingStatus(undoingStatus, func() error {
+		if err := gui.GitCommand.WithSpan(span).UndoOperation(operation, gui.promptUserForCredential); err != nil {
+			return gui.surfaceError(err)
 		}
 
-		switch action.kind {
-		case COMMIT, REBASE:
-			return true, gui.handleHardResetWithAutoStash(action.from, handleHardResetWithAutoStashOptions{
-				EnvVars:       undoEnvVars,
-				WaitingStatus: undoingStatus,
-				span:          span,
-			})
-		case CHECKOUT:
-			return true, gui.handleCheckoutRef(action.from, handleCheckoutRefOptions{
-				EnvVars:       undoEnvVars,
-				WaitingStatus: undoingStatus,
-				span:          span,
-			})
+		if err := onSuccess(); err != nil {
+			return err
 		}
 
-		gui.Log.Error("didn't match on the user action when trying to undo")
-		return true, nil
+		return gui.refreshSidePanels(refreshOptions{mode: ASYNC})
 	})
 }
 
-func (gui *Gui) reflogRedo() error {
-	redoEnvVars := []string{"GIT_REFLOG_ACTION=[lazygit redo]"}
-	redoingStatus := gui.Tr.RedoingStatus
-
+func (gui *Gui) handleRedo() error {
 	if gui.GitCommand.WorkingTreeState() == commands.REBASE_MODE_REBASING {
-		return gui.createErrorPanel(gui.Tr.LcCantRedoWhileRebasing)
+		return gui.rebaseTodoRedo()
 	}
 
+	operation, err := gui.GitCommand.NextOperationToRedo()
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+	if operation == nil {
+		gui.raiseToast(gui.Tr.NothingToRedo)
+		return nil
+	}
+
+	onSuccess := func() error {
+		return gui.GitCommand.MarkOperationRedone(operation)
+	}
+	redoEnvVars := []string{"GIT_REFLOG_ACTION=[lazygit redo]"}
+	redoingStatus := gui.Tr.RedoingStatus
 	span := gui.Tr.Spans.Redo
 
-	return gui.parseReflogForActions(func(counter int, action reflogAction) (bool, error) {
-		// if we're redoing and the counter is zero, we just return
-		if counter == 0 {
-			return true, nil
-		} else if counter > 1 {
-			return false, nil
+	switch operation.Kind {
+	case models.OPERATION_MOVE_HEAD:
+		return gui.handleHardResetWithAutoStash(operation.To, handleHardResetWithAutoStashOptions{
+			EnvVars:       redoEnvVars,
+			WaitingStatus: redoingStatus,
+			span:          span,
+			onSuccess:     onSuccess,
+		})
+	case models.OPERATION_CHECKOUT:
+		return gui.handleCheckoutRef(operation.To, handleCheckoutRefOptions{
+			EnvVars:       redoEnvVars,
+			WaitingStatus: redoingStatus,
+			span:          span,
+			onSuccess:     onSuccess,
+		})
+	}
+
+	return gui.WithWaitingStatus(redoingStatus, func() error {
+		if err := gui.GitCommand.WithSpan(span).RedoOperation(operation, gui.promptUserForCredential); err != nil {
+			return gui.surfaceError(err)
 		}
 
-		switch action.kind {
-		case COMMIT, REBASE:
-			return true, gui.handleHardResetWithAutoStash(action.to, handleHardResetWithAutoStashOptions{
-				EnvVars:       redoEnvVars,
-				WaitingStatus: redoingStatus,
-				span:          span,
-			})
-		case CHECKOUT:
-			return true, gui.handleCheckoutRef(action.to, handleCheckoutRefOptions{
-				EnvVars:       redoEnvVars,
-				WaitingStatus: redoingStatus,
-				span:          span,
-			})
+		if err := onSuccess(); err != nil {
+			return err
 		}
 
-		gui.Log.Error("didn't match on the user action when trying to redo")
-		return true, nil
+		return gui.refreshSidePanels(refreshOptions{mode: ASYNC})
 	})
 }
 
+func (gui *Gui) rebaseTodoUndo() error {
+	ok, err := gui.GitCommand.UndoRebaseTodoChange()
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+	if !ok {
+		return gui.createErrorPanel(gui.Tr.LcCantUndoWhileRebasing)
+	}
+
+	gui.OnRunCommand(oscommands.NewCmdLogEntry("Restoring previous rebase TODO", gui.Tr.Spans.Undo, false))
+
+	return gui.refreshRebaseCommits()
+}
+
+func (gui *Gui) rebaseTodoRedo() error {
+	ok, err := gui.GitCommand.RedoRebaseTodoChange()
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+	if !ok {
+		return gui.createErrorPanel(gui.Tr.LcCantRedoWhileRebasing)
+	}
+
+	gui.OnRunCommand(oscommands.NewCmdLogEntry("Reapplying undone rebase TODO change", gui.Tr.Spans.Redo, false))
+
+	return gui.refreshRebaseCommits()
+}
+
 type handleHardResetWithAutoStashOptions struct {
 	WaitingStatus string
 	EnvVars       []string
 	span          string
+	// called once the reset has succeeded
+	onSuccess func() error
 }
 
 // only to be used in the undo flow for now
@@ -169,10 +160,15 @@ func (gui *Gui) handleHardResetWithAutoStash(commitSha string, options handleHar
 	gitCommand := gui.GitCommand.WithSpan(options.span)
 
 	reset := func() error {
-		if err := gui.resetToRef(commitSha, "hard", options.span, oscommands.RunCommandOptions{EnvVars: options.EnvVars}); err != nil {
+		if err := gitCommand.ResetToCommit(commitSha, "hard", oscommands.RunCommandOptions{EnvVars: options.EnvVars}); err != nil {
 			return gui.surfaceError(err)
 		}
-		return nil
+		if options.onSuccess != nil {
+			if err := options.onSuccess(); err != nil {
+				return err
+			}
+		}
+		return gui.afterResetToRef()
 	}
 
 	// if we have any modified tracked files we need to ask the user if they want us to stash for them
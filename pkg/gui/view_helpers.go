@@ -28,6 +28,7 @@ const (
 	REMOTES
 	STATUS
 	SUBMODULES
+	WORKTREES
 )
 
 func getScopeNames(scopes []RefreshableView) []string {
@@ -41,6 +42,7 @@ func getScopeNames(scopes []RefreshableView) []string {
 		TAGS:       "tags",
 		REMOTES:    "remotes",
 		STATUS:     "status",
+		WORKTREES:  "worktrees",
 	}
 
 	scopeNames := make([]string, len(scopes))
@@ -87,6 +89,11 @@ func arrToMap(arr []RefreshableView) map[RefreshableView]bool {
 }
 
 func (gui *Gui) refreshSidePanels(options refreshOptions) error {
+	if gui.runningScript() {
+		// the next step needs to see the result of this one
+		options.mode = SYNC
+	}
+
 	if options.scope == nil {
 		gui.Log.Infof(
 			"refreshing all scopes in %s mode",
@@ -105,7 +112,7 @@ func (gui *Gui) refreshSidePanels(options refreshOptions) error {
 	f := func() {
 		var scopeMap map[RefreshableView]bool
 		if len(options.scope) == 0 {
-			scopeMap = arrToMap([]RefreshableView{COMMITS, BRANCHES, FILES, STASH, REFLOG, TAGS, REMOTES, STATUS})
+			scopeMap = arrToMap([]RefreshableView{COMMITS, BRANCHES, FILES, STASH, REFLOG, TAGS, REMOTES, WORKTREES, STATUS})
 		} else {
 			scopeMap = arrToMap(options.scope)
 		}
@@ -170,6 +177,18 @@ func (gui *Gui) refreshSidePanels(options refreshOptions) error {
 			}()
 		}
 
+		if scopeMap[WORKTREES] {
+			wg.Add(1)
+			func() {
+				if options.mode == ASYNC {
+					go utils.Safe(func() { _ = gui.refreshWorktrees() })
+				} else {
+					_ = gui.refreshWorktrees()
+				}
+				wg.Done()
+			}()
+		}
+
 		wg.Wait()
 
 		gui.refreshStatus()
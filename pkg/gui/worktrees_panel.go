@@ -0,0 +1,245 @@
+package gui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// list panel functions
+
+func (gui *Gui) getSelectedWorktree() *models.Worktree {
+	selectedLine := gui.State.Panels.Worktrees.SelectedLineIdx
+	if selectedLine == -1 || len(gui.State.Worktrees) == 0 {
+		return nil
+	}
+
+	return gui.State.Worktrees[selectedLine]
+}
+
+func (gui *Gui) handleWorktreeSelect() error {
+	var task updateTask
+	worktree := gui.getSelectedWorktree()
+	if worktree == nil {
+		task = NewRenderStringTask(gui.Tr.NoWorktrees)
+	} else {
+		branch := worktree.Branch
+		if worktree.IsDetached() {
+			branch = gui.Tr.DetachedHead
+		}
+
+		prefix := fmt.Sprintf(
+			"Path:   %s\nBranch: %s\nHEAD:   %s\n\n",
+			style.FgCyan.Sprint(worktree.Path),
+			style.FgGreen.Sprint(branch),
+			style.FgYellow.Sprint(worktree.Head),
+		)
+
+		if worktree.Bare || worktree.Head == "" {
+			task = NewRenderStringTask(prefix)
+		} else {
+			cmd := gui.OSCommand.ExecutableFromString(
+				gui.GitCommand.GetBranchGraphCmdStr(worktree.Head),
+			)
+			task = NewRunCommandTaskWithPrefix(cmd, prefix)
+		}
+	}
+
+	return gui.refreshMainViews(refreshMainOpts{
+		main: &viewUpdateOpts{
+			title: "Worktree",
+			task:  task,
+		},
+	})
+}
+
+func (gui *Gui) refreshWorktrees() error {
+	worktrees, err := gui.GitCommand.GetWorktrees()
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+
+	gui.State.Worktrees = worktrees
+
+	// the branches panel marks branches checked out in other worktrees so it needs re-rendering too
+	if err := gui.postRefreshUpdate(gui.State.Contexts.Branches); err != nil {
+		return err
+	}
+
+	return gui.postRefreshUpdate(gui.State.Contexts.Worktrees)
+}
+
+func (gui *Gui) withSelectedWorktree(f func(worktree *models.Worktree) error) func() error {
+	return func() error {
+		worktree := gui.getSelectedWorktree()
+		if worktree == nil {
+			return nil
+		}
+
+		return f(worktree)
+	}
+}
+
+func (gui *Gui) handleSwitchToWorktree(worktree *models.Worktree) error {
+	if worktree.Current {
+		return gui.createErrorPanel(gui.Tr.AlreadyInWorktree)
+	}
+
+	if worktree.Bare {
+		return gui.createErrorPanel(gui.Tr.CantSwitchToBareWorktree)
+	}
+
+	// if we were in a submodule, we want to forget about that stack of repos
+	// so that hitting escape in the new worktree does nothing
+	gui.RepoPathStack = []string{}
+
+	return gui.dispatchSwitchToRepo(worktree.Path, false)
+}
+
+func (gui *Gui) handleCreateWorktree() error {
+	return gui.prompt(promptOpts{
+		title:               gui.Tr.NewWorktreeBranch,
+		findSuggestionsFunc: gui.findBranchNameSuggestions,
+		handleConfirm: func(branchName string) error {
+			return gui.promptForWorktreePath(branchName)
+		},
+	})
+}
+
+func (gui *Gui) handleCreateWorktreeFromBranch() error {
+	branch := gui.getSelectedBranch()
+	if branch == nil {
+		return nil
+	}
+
+	if branch.Head {
+		return gui.createErrorPanel(gui.Tr.WorktreeBranchCheckedOut)
+	}
+
+	return gui.promptForWorktreePath(branch.Name)
+}
+
+func (gui *Gui) promptForWorktreePath(branchName string) error {
+	title := utils.ResolvePlaceholderString(
+		gui.Tr.NewWorktreePath,
+		map[string]string{
+			"branchName": branchName,
+		},
+	)
+
+	return gui.prompt(promptOpts{
+		title:          title,
+		initialContent: gui.defaultWorktreePath(branchName),
+		handleConfirm: func(path string) error {
+			return gui.createWorktree(path, branchName)
+		},
+	})
+}
+
+// defaultWorktreePath suggests a sibling directory of the main worktree, so that
+// a branch 'feature/foo' in repo 'lazygit' gets '../lazygit-feature-foo'
+func (gui *Gui) defaultWorktreePath(branchName string) string {
+	mainPath := ""
+	for _, worktree := range gui.State.Worktrees {
+		if worktree.Main {
+			mainPath = worktree.Path
+			break
+		}
+	}
+	if mainPath == "" {
+		return ""
+	}
+
+	dirName := filepath.Base(mainPath) + "-" + strings.Replace(branchName, "/", "-", -1)
+	return filepath.Join(filepath.Dir(mainPath), dirName)
+}
+
+func (gui *Gui) createWorktree(path string, branchName string) error {
+	return gui.WithWaitingStatus(gui.Tr.CreatingWorktreeStatus, func() error {
+		if err := gui.GitCommand.WithSpan(gui.Tr.Spans.AddWorktree).NewWorktree(path, branchName); err != nil {
+			return gui.surfaceError(err)
+		}
+
+		if err := gui.pushContext(gui.State.Contexts.Worktrees); err != nil {
+			return err
+		}
+
+		return gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{WORKTREES, BRANCHES}, then: func() {
+			// select the worktree we've just created
+			for i, worktree := range gui.State.Worktrees {
+				if worktree.Branch == branchName {
+					gui.State.Panels.Worktrees.SelectedLineIdx = i
+					if err := gui.State.Contexts.Worktrees.HandleRender(); err != nil {
+						gui.Log.Error(err)
+					}
+
+					return
+				}
+			}
+		}})
+	})
+}
+
+func (gui *Gui) handleRemoveWorktree(worktree *models.Worktree) error {
+	if worktree.Main {
+		return gui.createErrorPanel(gui.Tr.CantRemoveMainWorktree)
+	}
+
+	if worktree.Current {
+		return gui.createErrorPanel(gui.Tr.CantRemoveCurrentWorktree)
+	}
+
+	prompt := utils.ResolvePlaceholderString(
+		gui.Tr.RemoveWorktreePrompt,
+		map[string]string{
+			"worktreePath": worktree.Path,
+		},
+	)
+
+	return gui.ask(askOpts{
+		title:  gui.Tr.RemoveWorktreeTitle,
+		prompt: prompt,
+		handleConfirm: func() error {
+			return gui.removeWorktree(worktree, false)
+		},
+	})
+}
+
+func (gui *Gui) removeWorktree(worktree *models.Worktree, force bool) error {
+	if err := gui.GitCommand.WithSpan(gui.Tr.Spans.RemoveWorktree).RemoveWorktree(worktree.Path, force); err != nil {
+		if !force && strings.Contains(err.Error(), "contains modified or untracked files") {
+			prompt := utils.ResolvePlaceholderString(
+				gui.Tr.ForceRemoveWorktreePrompt,
+				map[string]string{
+					"worktreePath": worktree.Path,
+				},
+			)
+
+			return gui.ask(askOpts{
+				title:  gui.Tr.RemoveWorktreeTitle,
+				prompt: prompt,
+				handleConfirm: func() error {
+					return gui.removeWorktree(worktree, true)
+				},
+			})
+		}
+
+		return gui.surfaceError(err)
+	}
+
+	return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{WORKTREES, BRANCHES}})
+}
+
+func (gui *Gui) handlePruneWorktrees() error {
+	return gui.WithWaitingStatus(gui.Tr.PruningWorktreesStatus, func() error {
+		if err := gui.GitCommand.WithSpan(gui.Tr.Spans.PruneWorktrees).PruneWorktrees(); err != nil {
+			return gui.surfaceError(err)
+		}
+
+		return gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{WORKTREES, BRANCHES}})
+	})
+}
@@ -37,7 +37,6 @@ func chineseTranslationSet() TranslationSet {
 		CommitMessage:                       "提交信息",
 		CredentialsUsername:                 "用户名",
 		CredentialsPassword:                 "密码",
-		CredentialsPassphrase:               "输入SSH密钥的密码",
 		PassUnameWrong:                      "密码, 密码 和/或 用户名错误",
 		CommitChanges:                       "提交更改",
 		AmendLastCommit:                     "修改最后一次提交",
@@ -19,7 +19,6 @@ func dutchTranslationSet() TranslationSet {
 		CommitMessage:                       "Commitbericht",
 		CredentialsUsername:                 "Gebruikersnaam",
 		CredentialsPassword:                 "Wachtwoord",
-		CredentialsPassphrase:               "Voer een wachtwoordzin in voor de SSH-sleutel",
 		PassUnameWrong:                      "Wachtwoord en/of gebruikersnaam verkeerd",
 		CommitChanges:                       "Commit veranderingen",
 		AmendLastCommit:                     "wijzig laatste commit",
@@ -29,7 +29,6 @@ type TranslationSet struct {
 	CommitMessage                       string
 	CredentialsUsername                 string
 	CredentialsPassword                 string
-	CredentialsPassphrase               string
 	PassUnameWrong                      string
 	CommitChanges                       string
 	AmendLastCommit                     string
@@ -460,6 +459,151 @@ type TranslationSet struct {
 	LcSelectBranch                      string
 	CreatePullRequest                   string
 	CreatingPullRequestAtUrl            string
+	WorktreesTitle                      string
+	NoWorktrees                         string
+	DetachedHead                        string
+	LcCreateWorktree                    string
+	LcCreateWorktreeFromBranch          string
+	LcSwitchToWorktree                  string
+	LcRemoveWorktree                    string
+	LcPruneWorktrees                    string
+	NewWorktreeBranch                   string
+	NewWorktreePath                     string
+	CreatingWorktreeStatus              string
+	PruningWorktreesStatus              string
+	RemoveWorktreeTitle                 string
+	RemoveWorktreePrompt                string
+	ForceRemoveWorktreePrompt           string
+	CantRemoveMainWorktree              string
+	CantRemoveCurrentWorktree           string
+	AlreadyInWorktree                   string
+	CantSwitchToBareWorktree            string
+	WorktreeBranchCheckedOut            string
+	LcViewBisectOptions                 string
+	BisectMenuTitle                     string
+	LcBisectMark                        string
+	LcBisectSkip                        string
+	LcResetBisect                       string
+	LcBisecting                         string
+	LcBisectCandidatesLeft              string
+	LcBisectWaitingForCommits           string
+	LcBisectFoundFirstCommit            string
+	BisectCompleteTitle                 string
+	BisectCompletePrompt                string
+	LcCreateAnnotatedTag                string
+	LcAnnotatedTagCommit                string
+	LcAnnotatedTag                      string
+	LcSignedTag                         string
+	LcLightweightTagParens              string
+	LcSignedTagParens                   string
+	TagMessageTitle                     string
+	CreatingTagStatus                   string
+	CreateAnnotatedTagMenuTitle         string
+	LcStashSelectedPath                 string
+	LcStashSelectedLines                string
+	StashLinesOnlyUnstaged              string
+	StashLinesRequiresNoStagedChanges   string
+	LcPickBaseHunk                      string
+	LcPickAllHunks                      string
+	PickBaseHunk                        string
+	PickAllHunks                        string
+	NoBaseSectionInConflict             string
+	ServiceURLNotConfigured             string
+	LcOpenInBrowser                     string
+	CommitSubjectTooLong                string
+	CommitNotConventional               string
+	CommitTypeNotAllowed                string
+	CommitScopeRequired                 string
+	CommitScopeNotAllowed               string
+	CommitMessageInvalid                string
+	CommitMessageValid                  string
+	AddTrailer                          string
+	LcAddTrailer                        string
+	SignedOffByTrailer                  string
+	CoAuthoredByTrailer                 string
+	CoAuthorPromptTitle                 string
+	LcToggleWordDiff                    string
+	WordDiffRequiresChangedLine         string
+	LcSplitCommit                       string
+	SplitCommitTitle                    string
+	SplitCommitPrompt                   string
+	CantSplitMergeCommit                string
+	CantSplitCommitWhileRebasing        string
+	LcToggleSideBySideDiff              string
+	ShowingSideBySideDiff               string
+	ShowingUnifiedDiff                  string
+	LfsObjectChanged                    string
+	LfsObjectAdded                      string
+	LfsObjectDeleted                    string
+	LcViewLfsOptions                    string
+	LfsOptionsTitle                     string
+	LcLoadingLfsLocks                   string
+	LcLfsLockFile                       string
+	LcLfsUnlockFile                     string
+	LcLfsForceUnlockFile                string
+	LcLockingLfsFile                    string
+	LcUnlockingLfsFile                  string
+	LfsForceUnlockTitle                 string
+	LfsForceUnlockPrompt                string
+	LfsLockedBy                         string
+	NoLfsLocks                          string
+	LcViewSparseCheckoutOptions         string
+	SparseCheckoutTitle                 string
+	LcEnableSparseCheckout              string
+	LcAddToSparseCheckout               string
+	LcRemoveFromSparseCheckout          string
+	LcReapplySparseCheckout             string
+	LcDisableSparseCheckout             string
+	LcInSparseCheckout                  string
+	LcUpdatingSparseCheckout            string
+	LcSparse                            string
+	LcBlameFile                         string
+	BlameFileTitle                      string
+	BlameFileAtRefTitle                 string
+	LcBlameParent                       string
+	LcSelectLine                        string
+	LcViewCommitOfLine                  string
+	LcReturn                            string
+	NoPreviousCommitToBlame             string
+	CantViewUncommittedLine             string
+	CantBlameUntrackedFile              string
+	BlameTitle                          string
+	NothingToUndo                       string
+	NothingToRedo                       string
+	OperationLogTitle                   string
+	LcViewOperationLog                  string
+	LcUndone                            string
+	CreateBranchOperation               string
+	DeleteBranchOperation               string
+	RenameBranchOperation               string
+	DeleteTagOperation                  string
+	DropStashOperation                  string
+	DeleteRemoteBranchOperation         string
+	NoOperations                        string
+	LostAndFoundTitle                   string
+	LcFindingLostCommits                string
+	NoLostCommits                       string
+	NotAStashCommit                     string
+	LcRestoreAsStashEntry               string
+	LoadingMoreCommits                  string
+	LcCancelRemoteOperation             string
+	RemoteOperationCancelled            string
+	LcSetPushRemote                     string
+	SetPushRemoteTitle                  string
+	LcDefaultPushRemote                 string
+	LcFetchAllRemotes                   string
+	FetchingAllRemotesStatus            string
+	RemoteBranchChanged                 string
+	RemoteBranchChangedPrompt           string
+	LcAddDirToSparseCheckout            string
+	LcRemoveDirFromSparseCheckout       string
+	SparseCheckoutDirPromptTitle        string
+	NotADirectoryInRepo                 string
+	CantAnswerFromScript                string
+	TagWithoutMessageErr                string
+	RestoreUnstashedLinesErr            string
+	CannotBrowseLocalOnlyCommit         string
+	CannotBrowseRef                     string
 	Spans                               Spans
 }
 
@@ -542,6 +686,27 @@ type Spans struct {
 	HardReset                         string
 	Undo                              string
 	Redo                              string
+	AddWorktree                       string
+	RemoveWorktree                    string
+	PruneWorktrees                    string
+	StartBisect                       string
+	MarkBisectCommit                  string
+	ResetBisect                       string
+	CreateAnnotatedTag                string
+	StashSelectedPath                 string
+	StashSelectedLines                string
+	OpenInBrowser                     string
+	SplitCommit                       string
+	LfsLock                           string
+	LfsUnlock                         string
+	EnableSparseCheckout              string
+	AddToSparseCheckout               string
+	RemoveFromSparseCheckout          string
+	ReapplySparseCheckout             string
+	DisableSparseCheckout             string
+	RestoreStash                      string
+	SetBranchPushRemote               string
+	FetchAllRemotes                   string
 }
 
 const englishIntroPopupMessage = `
@@ -581,7 +746,6 @@ func englishTranslationSet() TranslationSet {
 		CommitMessage:                       "Commit message",
 		CredentialsUsername:                 "Username",
 		CredentialsPassword:                 "Password",
-		CredentialsPassphrase:               "Enter passphrase for SSH key",
 		PassUnameWrong:                      "Password, passphrase and/or username wrong",
 		CommitChanges:                       "commit changes",
 		AmendLastCommit:                     "amend last commit",
@@ -676,8 +840,8 @@ func englishTranslationSet() TranslationSet {
 		LcPickHunk:                          "pick hunk",
 		LcPickBothHunks:                     "pick both hunks",
 		LcUndo:                              "undo",
-		LcUndoReflog:                        "undo (via reflog) (experimental)",
-		LcRedoReflog:                        "redo (via reflog) (experimental)",
+		LcUndoReflog:                        "undo",
+		LcRedoReflog:                        "redo",
 		LcPop:                               "pop",
 		LcDrop:                              "drop",
 		LcApply:                             "apply",
@@ -912,8 +1076,8 @@ func englishTranslationSet() TranslationSet {
 		LcResetCherryPick:                   "reset cherry-picked (copied) commits selection",
 		LcNextTab:                           "next tab",
 		LcPrevTab:                           "previous tab",
-		LcCantUndoWhileRebasing:             "Can't undo while rebasing",
-		LcCantRedoWhileRebasing:             "Can't redo while rebasing",
+		LcCantUndoWhileRebasing:             "Nothing to undo. While rebasing, only changes made to the rebase TODO can be undone",
+		LcCantRedoWhileRebasing:             "Nothing to redo. While rebasing, only changes made to the rebase TODO can be redone",
 		MustStashWarning:                    "Pulling a patch out into the index requires stashing and unstashing your changes. If something goes wrong, you'll be able to access your files from the stash. Continue?",
 		MustStashTitle:                      "Must stash",
 		ConfirmationTitle:                   "Confirmation Panel",
@@ -1013,6 +1177,151 @@ func englishTranslationSet() TranslationSet {
 		LcDefaultBranch:                     "default branch",
 		LcSelectBranch:                      "select branch",
 		CreatingPullRequestAtUrl:            "Creating pull request at URL: %s",
+		WorktreesTitle:                      "Worktrees Tab",
+		NoWorktrees:                         "No worktrees",
+		DetachedHead:                        "(detached head)",
+		LcCreateWorktree:                    "create worktree",
+		LcCreateWorktreeFromBranch:          "create worktree from branch",
+		LcSwitchToWorktree:                  "switch to worktree",
+		LcRemoveWorktree:                    "remove worktree",
+		LcPruneWorktrees:                    "prune stale worktrees",
+		NewWorktreeBranch:                   "Branch to check out in new worktree:",
+		NewWorktreePath:                     "Path for new worktree of '{{.branchName}}':",
+		CreatingWorktreeStatus:              "creating worktree",
+		PruningWorktreesStatus:              "pruning worktrees",
+		RemoveWorktreeTitle:                 "Remove worktree",
+		RemoveWorktreePrompt:                "Are you sure you want to remove worktree '{{.worktreePath}}'?",
+		ForceRemoveWorktreePrompt:           "'{{.worktreePath}}' contains modified or untracked files. Remove it anyway? Those changes will be lost.",
+		CantRemoveMainWorktree:              "You cannot remove the main worktree",
+		CantRemoveCurrentWorktree:           "You cannot remove the worktree you are currently in. Switch to another worktree first",
+		AlreadyInWorktree:                   "You are already in this worktree",
+		CantSwitchToBareWorktree:            "Cannot switch to a bare repository: it has no working tree",
+		WorktreeBranchCheckedOut:            "This branch is already checked out here. Pick another branch to create a worktree for",
+		LcViewBisectOptions:                 "view bisect options",
+		BisectMenuTitle:                     "Bisect",
+		LcBisectMark:                        "mark {{.commit}} as {{.term}}",
+		LcBisectSkip:                        "skip {{.commit}}",
+		LcResetBisect:                       "reset bisect",
+		LcBisecting:                         "bisecting",
+		LcBisectCandidatesLeft:              "{{.count}} candidate commit(s) left",
+		LcBisectWaitingForCommits:           "waiting for a '{{.newTerm}}' and a '{{.oldTerm}}' commit",
+		LcBisectFoundFirstCommit:            "first '{{.newTerm}}' commit is {{.commit}}",
+		BisectCompleteTitle:                 "Bisect complete",
+		BisectCompletePrompt:                "The first '{{.newTerm}}' commit is:\n\n{{.commit}}\n\nDo you want to reset 'git bisect' now?",
+		LcCreateAnnotatedTag:                "create annotated tag",
+		LcAnnotatedTagCommit:                "create annotated tag on commit",
+		LcAnnotatedTag:                      "annotated tag",
+		LcSignedTag:                         "signed annotated tag",
+		LcLightweightTagParens:              "(lightweight)",
+		LcSignedTagParens:                   "(signed)",
+		TagMessageTitle:                     "Message for tag '{{.tagName}}'",
+		CreatingTagStatus:                   "creating tag",
+		CreateAnnotatedTagMenuTitle:         "Create annotated tag",
+		LcStashSelectedPath:                 "stash changes in '{{.path}}'",
+		LcStashSelectedLines:                "stash selected lines",
+		StashLinesOnlyUnstaged:              "Only unstaged lines can be stashed. Switch to the unstaged changes first",
+		StashLinesRequiresNoStagedChanges:   "Lines can only be stashed from a tracked file with no staged changes",
+		LcPickBaseHunk:                      "pick base hunk",
+		LcPickAllHunks:                      "pick all hunks",
+		PickBaseHunk:                        "pick base hunk (requires merge.conflictStyle=diff3)",
+		PickAllHunks:                        "pick all hunks, including the base hunk",
+		NoBaseSectionInConflict:             "This conflict has no base section. Set merge.conflictStyle to diff3 to have git include the common ancestor's version in conflicts",
+		ServiceURLNotConfigured:             "This git service has no URL configured for that",
+		LcOpenInBrowser:                     "open in browser",
+		CommitSubjectTooLong:                "subject is {{.length}} characters long, the limit is {{.maxLength}}",
+		CommitNotConventional:               "subject should look like 'type(scope): description'",
+		CommitTypeNotAllowed:                "type '{{.type}}' should be one of: {{.types}}",
+		CommitScopeRequired:                 "a scope is required e.g. 'type(scope): description'",
+		CommitScopeNotAllowed:               "scope '{{.scope}}' should be one of: {{.scopes}}",
+		CommitMessageInvalid:                "Commit message doesn't follow the configured rules:",
+		CommitMessageValid:                  "valid",
+		AddTrailer:                          "Add trailer",
+		LcAddTrailer:                        "add trailer",
+		SignedOffByTrailer:                  "Signed-off-by: {{.identity}}",
+		CoAuthoredByTrailer:                 "Co-authored-by...",
+		CoAuthorPromptTitle:                 "Co-author (Name <email>):",
+		LcToggleWordDiff:                    "toggle staging individual words",
+		WordDiffRequiresChangedLine:         "Select an added or removed line to stage its words individually",
+		LcSplitCommit:                       "split commit into several commits",
+		SplitCommitTitle:                    "Split commit",
+		SplitCommitPrompt:                   "This will start a rebase which stops at this commit and undoes it, leaving its changes in the working tree. Stage and commit them bit by bit, each with its own message, and once everything is committed the rebase will continue. Are you sure?",
+		CantSplitMergeCommit:                "Merge commits can't be split",
+		CantSplitCommitWhileRebasing:        "You can't split a commit while merging or rebasing",
+		LcToggleSideBySideDiff:              "toggle side-by-side diff",
+		ShowingSideBySideDiff:               "Showing diffs side by side",
+		ShowingUnifiedDiff:                  "Showing unified diffs",
+		LfsObjectChanged:                    "LFS object changed: {{.old}} → {{.new}}",
+		LfsObjectAdded:                      "LFS object added: {{.new}}",
+		LfsObjectDeleted:                    "LFS object removed: {{.old}}",
+		LcViewLfsOptions:                    "view Git LFS lock options",
+		LfsOptionsTitle:                     "Git LFS locks",
+		LcLoadingLfsLocks:                   "loading LFS locks",
+		LcLfsLockFile:                       "lock",
+		LcLfsUnlockFile:                     "unlock",
+		LcLfsForceUnlockFile:                "force unlock",
+		LcLockingLfsFile:                    "locking file",
+		LcUnlockingLfsFile:                  "unlocking file",
+		LfsForceUnlockTitle:                 "Force unlock",
+		LfsForceUnlockPrompt:                "This file is locked by {{.owner}}. Are you sure you want to release their lock?",
+		LfsLockedBy:                         "locked by {{.owner}}",
+		NoLfsLocks:                          "No LFS locks",
+		LcViewSparseCheckoutOptions:         "view sparse checkout options",
+		SparseCheckoutTitle:                 "Sparse checkout",
+		LcEnableSparseCheckout:              "enable sparse checkout (cone mode)",
+		LcAddToSparseCheckout:               "add to sparse checkout",
+		LcRemoveFromSparseCheckout:          "remove from sparse checkout",
+		LcReapplySparseCheckout:             "reapply sparse checkout",
+		LcDisableSparseCheckout:             "disable sparse checkout",
+		LcInSparseCheckout:                  "in cone",
+		LcUpdatingSparseCheckout:            "updating sparse checkout",
+		LcSparse:                            "sparse",
+		LcBlameFile:                         "blame file",
+		BlameFileTitle:                      "Blame: {{.path}}",
+		BlameFileAtRefTitle:                 "Blame: {{.path}} @ {{.ref}}",
+		LcBlameParent:                       "blame the file before the commit that changed this line",
+		LcSelectLine:                        "select line",
+		LcViewCommitOfLine:                  "view the commit that changed this line",
+		LcReturn:                            "return",
+		NoPreviousCommitToBlame:             "The commit that changed this line added the file, so there is nothing before it to blame",
+		CantViewUncommittedLine:             "This line hasn't been committed yet",
+		CantBlameUntrackedFile:              "Cannot blame a file which isn't tracked yet",
+		BlameTitle:                          "Blame",
+		NothingToUndo:                       "Nothing to undo",
+		NothingToRedo:                       "Nothing to redo",
+		OperationLogTitle:                   "Operation log",
+		LcViewOperationLog:                  "view the log of operations which can be undone",
+		LcUndone:                            "undone",
+		CreateBranchOperation:               "create branch {{.name}}",
+		DeleteBranchOperation:               "delete branch {{.name}}",
+		RenameBranchOperation:               "rename branch {{.from}} to {{.to}}",
+		DeleteTagOperation:                  "delete tag {{.name}}",
+		DropStashOperation:                  "drop stash entry '{{.name}}'",
+		DeleteRemoteBranchOperation:         "delete remote branch {{.remote}}/{{.name}}",
+		NoOperations:                        "No operations have been recorded yet",
+		LostAndFoundTitle:                   "Lost & found",
+		LcFindingLostCommits:                "finding lost commits",
+		NoLostCommits:                       "No lost commits or stash entries",
+		NotAStashCommit:                     "Only commits made by 'git stash' can be restored as stash entries",
+		LcRestoreAsStashEntry:               "restore as stash entry",
+		LoadingMoreCommits:                  "Loading more commits...",
+		LcCancelRemoteOperation:             "cancel push, pull or fetch",
+		RemoteOperationCancelled:            "Cancelled",
+		LcSetPushRemote:                     "set push remote",
+		SetPushRemoteTitle:                  "Push {{.branchName}} to",
+		LcDefaultPushRemote:                 "default (remote.pushDefault or the upstream's remote)",
+		LcFetchAllRemotes:                   "fetch all remotes",
+		FetchingAllRemotesStatus:            "fetching all remotes",
+		RemoteBranchChanged:                 "Remote branch has changed",
+		RemoteBranchChangedPrompt:           "Someone has pushed to {{.branchName}} since you started rewriting it (it was at {{.expectedSha}}), so we didn't overwrite their commits. Fetch and view the incoming commits? You can force push again once you've seen them.",
+		LcAddDirToSparseCheckout:            "add a directory to sparse checkout",
+		LcRemoveDirFromSparseCheckout:       "remove a directory from sparse checkout",
+		SparseCheckoutDirPromptTitle:        "Directory:",
+		NotADirectoryInRepo:                 "'{{.dir}}' is not a directory in HEAD",
+		CantAnswerFromScript:                "a script can't answer this: {{question}}",
+		TagWithoutMessageErr:                "You cannot create an annotated tag without a message",
+		RestoreUnstashedLinesErr:            "Failed to put back the lines that weren't stashed: {{.error}}\n\nThey're saved in {{.path}}, which you can apply with `git apply`",
+		CannotBrowseLocalOnlyCommit:         "Stash entries and lost commits only exist in your local repo, so they can't be opened on your git service",
+		CannotBrowseRef:                     "'{{.ref}}' isn't a commit or branch that can be opened on your git service",
 		Spans: Spans{
 			// TODO: combine this with the original keybinding descriptions (those are all in lowercase atm)
 			CheckoutCommit:                    "Checkout commit",
@@ -1093,6 +1402,27 @@ func englishTranslationSet() TranslationSet {
 			FastForwardBranch:                 "Fast forward branch",
 			Undo:                              "Undo",
 			Redo:                              "Redo",
+			AddWorktree:                       "Add worktree",
+			RemoveWorktree:                    "Remove worktree",
+			PruneWorktrees:                    "Prune worktrees",
+			StartBisect:                       "Start bisect",
+			MarkBisectCommit:                  "Mark bisect commit",
+			ResetBisect:                       "Reset bisect",
+			CreateAnnotatedTag:                "Create annotated tag",
+			StashSelectedPath:                 "Stash selected file",
+			StashSelectedLines:                "Stash selected lines",
+			OpenInBrowser:                     "Open in browser",
+			SplitCommit:                       "Split commit",
+			LfsLock:                           "Lock LFS file",
+			LfsUnlock:                         "Unlock LFS file",
+			EnableSparseCheckout:              "Enable sparse checkout",
+			AddToSparseCheckout:               "Add to sparse checkout",
+			RemoveFromSparseCheckout:          "Remove from sparse checkout",
+			ReapplySparseCheckout:             "Reapply sparse checkout",
+			DisableSparseCheckout:             "Disable sparse checkout",
+			RestoreStash:                      "Restore stash entry",
+			SetBranchPushRemote:               "Set branch push remote",
+			FetchAllRemotes:                   "Fetch all remotes",
 		},
 	}
 }
@@ -15,7 +15,6 @@ func polishTranslationSet() TranslationSet {
 		CommitMessage:                       "Wiadomość commita",
 		CredentialsUsername:                 "Username",
 		CredentialsPassword:                 "Password",
-		CredentialsPassphrase:               "Passphrase",
 		PassUnameWrong:                      "Password, passphrase and/or username wrong",
 		CommitChanges:                       "commituj zmiany",
 		AmendLastCommit:                     "zmień ostatnie zatwierdzenie",
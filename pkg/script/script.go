@@ -0,0 +1,252 @@
+package script
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	yaml "github.com/jesseduffield/yaml"
+)
+
+// A script is a list of steps, each naming an action and giving its arguments.
+// Scripts are written in YAML (which means JSON works too) e.g.
+//
+//   - action: checkout
+//     args: [feature]
+//   - action: rebase
+//     args: [main]
+//   - action: push
+//     args: [force]
+//
+// Unlike replaying recorded key events, scripts don't depend on keybindings
+// or on the layout of the gui.
+
+type Step struct {
+	Action string   `yaml:"action"`
+	Args   []string `yaml:"args"`
+}
+
+func (s Step) String() string {
+	return strings.TrimSpace(s.Action + " " + strings.Join(s.Args, " "))
+}
+
+// Handlers carries out each action. The gui implements this so that a script's
+// steps go through the same code as the corresponding keybindings, with the
+// same confirmations, refreshes and credential prompts.
+type Handlers interface {
+	Checkout(ref string) error
+	CreateBranch(name string, base string) error
+	DeleteBranch(name string, force bool) error
+	Merge(ref string) error
+	Rebase(ref string) error
+	ContinueRebase() error
+	AbortRebase() error
+	Fetch(remoteName string) error
+	Pull() error
+	Push(force bool) error
+	StageAll() error
+	Commit(message string) error
+	Stash(message string) error
+	StashPop() error
+	Tag(name string, ref string) error
+	Reset(ref string, strength string) error
+}
+
+type action struct {
+	// usage describes the action's arguments, with optional ones in brackets
+	usage   string
+	minArgs int
+	maxArgs int
+	run     func(handlers Handlers, args []string) error
+}
+
+func optionalArg(args []string, index int, defaultValue string) string {
+	if len(args) > index {
+		return args[index]
+	}
+	return defaultValue
+}
+
+var actions = map[string]action{
+	"checkout": {
+		usage:   "<ref>",
+		minArgs: 1,
+		maxArgs: 1,
+		run: func(handlers Handlers, args []string) error {
+			return handlers.Checkout(args[0])
+		},
+	},
+	"createBranch": {
+		usage:   "<name> [base]",
+		minArgs: 1,
+		maxArgs: 2,
+		run: func(handlers Handlers, args []string) error {
+			return handlers.CreateBranch(args[0], optionalArg(args, 1, ""))
+		},
+	},
+	"deleteBranch": {
+		usage:   "<name> [force]",
+		minArgs: 1,
+		maxArgs: 2,
+		run: func(handlers Handlers, args []string) error {
+			return handlers.DeleteBranch(args[0], optionalArg(args, 1, "") == "force")
+		},
+	},
+	"merge": {
+		usage:   "<ref>",
+		minArgs: 1,
+		maxArgs: 1,
+		run: func(handlers Handlers, args []string) error {
+			return handlers.Merge(args[0])
+		},
+	},
+	"rebase": {
+		usage:   "<ref>",
+		minArgs: 1,
+		maxArgs: 1,
+		run: func(handlers Handlers, args []string) error {
+			return handlers.Rebase(args[0])
+		},
+	},
+	"continueRebase": {
+		run: func(handlers Handlers, args []string) error {
+			return handlers.ContinueRebase()
+		},
+	},
+	"abortRebase": {
+		run: func(handlers Handlers, args []string) error {
+			return handlers.AbortRebase()
+		},
+	},
+	"fetch": {
+		usage:   "[remote]",
+		maxArgs: 1,
+		run: func(handlers Handlers, args []string) error {
+			return handlers.Fetch(optionalArg(args, 0, ""))
+		},
+	},
+	"pull": {
+		run: func(handlers Handlers, args []string) error {
+			return handlers.Pull()
+		},
+	},
+	"push": {
+		usage:   "[force]",
+		maxArgs: 1,
+		run: func(handlers Handlers, args []string) error {
+			return handlers.Push(optionalArg(args, 0, "") == "force")
+		},
+	},
+	"stageAll": {
+		run: func(handlers Handlers, args []string) error {
+			return handlers.StageAll()
+		},
+	},
+	"commit": {
+		usage:   "<message>",
+		minArgs: 1,
+		maxArgs: 1,
+		run: func(handlers Handlers, args []string) error {
+			return handlers.Commit(args[0])
+		},
+	},
+	"stash": {
+		usage:   "[message]",
+		maxArgs: 1,
+		run: func(handlers Handlers, args []string) error {
+			return handlers.Stash(optionalArg(args, 0, ""))
+		},
+	},
+	"stashPop": {
+		run: func(handlers Handlers, args []string) error {
+			return handlers.StashPop()
+		},
+	},
+	"tag": {
+		usage:   "<name> [ref]",
+		minArgs: 1,
+		maxArgs: 2,
+		run: func(handlers Handlers, args []string) error {
+			return handlers.Tag(args[0], optionalArg(args, 1, ""))
+		},
+	},
+	"reset": {
+		usage:   "<ref> [soft|mixed|hard]",
+		minArgs: 1,
+		maxArgs: 2,
+		run: func(handlers Handlers, args []string) error {
+			strength := optionalArg(args, 1, "mixed")
+			if strength != "soft" && strength != "mixed" && strength != "hard" {
+				return fmt.Errorf("unknown reset strength '%s'", strength)
+			}
+			return handlers.Reset(args[0], strength)
+		},
+	},
+}
+
+// StepError tells us which step of a script went wrong
+type StepError struct {
+	Index int
+	Step  Step
+	Err   error
+}
+
+func (e *StepError) Error() string {
+	return fmt.Sprintf("step %d (%s) failed: %s", e.Index+1, e.Step, strings.TrimSpace(e.Err.Error()))
+}
+
+// ParseScript reads a script's steps from its YAML/JSON content
+func ParseScript(content []byte) ([]Step, error) {
+	steps := []Step{}
+	if err := yaml.Unmarshal(content, &steps); err != nil {
+		return nil, fmt.Errorf("could not parse script: %s", err.Error())
+	}
+
+	return steps, nil
+}
+
+// LoadScript reads a script's steps from the given file
+func LoadScript(path string) ([]Step, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseScript(content)
+}
+
+// Validate checks every step up front, so that we don't get halfway through a
+// script before finding a typo in a later step
+func Validate(steps []Step) error {
+	for i, step := range steps {
+		stepAction, ok := actions[step.Action]
+		if !ok {
+			return &StepError{Index: i, Step: step, Err: fmt.Errorf("unknown action '%s'", step.Action)}
+		}
+
+		if len(step.Args) < stepAction.minArgs || len(step.Args) > stepAction.maxArgs {
+			return &StepError{Index: i, Step: step, Err: fmt.Errorf("expected arguments: %s %s", step.Action, stepAction.usage)}
+		}
+	}
+
+	return nil
+}
+
+// Run validates the steps and then runs them in order, stopping at the first
+// one that fails
+func Run(handlers Handlers, steps []Step, out io.Writer) error {
+	if err := Validate(steps); err != nil {
+		return err
+	}
+
+	for i, step := range steps {
+		fmt.Fprintf(out, "[%d/%d] %s\n", i+1, len(steps), step)
+
+		if err := actions[step.Action].run(handlers, step.Args); err != nil {
+			return &StepError{Index: i, Step: step, Err: err}
+		}
+	}
+
+	return nil
+}
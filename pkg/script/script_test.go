@@ -0,0 +1,246 @@
+package script
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseScript is a function.
+func TestParseScript(t *testing.T) {
+	type scenario struct {
+		testName string
+		content  string
+		test     func([]Step, error)
+	}
+
+	scenarios := []scenario{
+		{
+			"YAML",
+			`
+- action: checkout
+  args: [feature]
+- action: push
+`,
+			func(steps []Step, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, []Step{
+					{Action: "checkout", Args: []string{"feature"}},
+					{Action: "push"},
+				}, steps)
+			},
+		},
+		{
+			"JSON",
+			`[{"action": "rebase", "args": ["main"]}]`,
+			func(steps []Step, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, []Step{{Action: "rebase", Args: []string{"main"}}}, steps)
+			},
+		},
+		{
+			"Not a list",
+			`action: checkout`,
+			func(steps []Step, err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			s.test(ParseScript([]byte(s.content)))
+		})
+	}
+}
+
+// TestValidate is a function.
+func TestValidate(t *testing.T) {
+	type scenario struct {
+		testName      string
+		steps         []Step
+		expectedError string
+	}
+
+	scenarios := []scenario{
+		{
+			"Valid steps",
+			[]Step{
+				{Action: "checkout", Args: []string{"feature"}},
+				{Action: "createBranch", Args: []string{"new", "main"}},
+				{Action: "push"},
+			},
+			"",
+		},
+		{
+			"Unknown action",
+			[]Step{
+				{Action: "checkout", Args: []string{"feature"}},
+				{Action: "teleport"},
+			},
+			"step 2 (teleport) failed: unknown action 'teleport'",
+		},
+		{
+			"Missing argument",
+			[]Step{{Action: "checkout"}},
+			"step 1 (checkout) failed: expected arguments: checkout <ref>",
+		},
+		{
+			"Too many arguments",
+			[]Step{{Action: "reset", Args: []string{"HEAD~1", "hard", "now"}}},
+			"step 1 (reset HEAD~1 hard now) failed: expected arguments: reset <ref> [soft|mixed|hard]",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			err := Validate(s.steps)
+			if s.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, s.expectedError)
+			}
+		})
+	}
+}
+
+// recordingHandlers records the actions it's asked to carry out, failing the
+// one given in failOn
+type recordingHandlers struct {
+	calls  []string
+	failOn string
+}
+
+func (h *recordingHandlers) record(format string, args ...interface{}) error {
+	call := fmt.Sprintf(format, args...)
+	h.calls = append(h.calls, call)
+	if call == h.failOn {
+		return errors.New("conflict")
+	}
+	return nil
+}
+
+func (h *recordingHandlers) Checkout(ref string) error { return h.record("Checkout %s", ref) }
+func (h *recordingHandlers) CreateBranch(name string, base string) error {
+	return h.record("CreateBranch %s %q", name, base)
+}
+func (h *recordingHandlers) DeleteBranch(name string, force bool) error {
+	return h.record("DeleteBranch %s %t", name, force)
+}
+func (h *recordingHandlers) Merge(ref string) error        { return h.record("Merge %s", ref) }
+func (h *recordingHandlers) Rebase(ref string) error       { return h.record("Rebase %s", ref) }
+func (h *recordingHandlers) ContinueRebase() error         { return h.record("ContinueRebase") }
+func (h *recordingHandlers) AbortRebase() error            { return h.record("AbortRebase") }
+func (h *recordingHandlers) Fetch(remoteName string) error { return h.record("Fetch %q", remoteName) }
+func (h *recordingHandlers) Pull() error                   { return h.record("Pull") }
+func (h *recordingHandlers) Push(force bool) error         { return h.record("Push %t", force) }
+func (h *recordingHandlers) StageAll() error               { return h.record("StageAll") }
+func (h *recordingHandlers) Commit(message string) error   { return h.record("Commit %s", message) }
+func (h *recordingHandlers) Stash(message string) error    { return h.record("Stash %q", message) }
+func (h *recordingHandlers) StashPop() error               { return h.record("StashPop") }
+func (h *recordingHandlers) Tag(name string, ref string) error {
+	return h.record("Tag %s %q", name, ref)
+}
+func (h *recordingHandlers) Reset(ref string, strength string) error {
+	return h.record("Reset %s %s", ref, strength)
+}
+
+// TestRun is a function.
+func TestRun(t *testing.T) {
+	type scenario struct {
+		testName      string
+		steps         []Step
+		failOn        string
+		expectedCalls []string
+		expectedError string
+	}
+
+	scenarios := []scenario{
+		{
+			"All steps succeed",
+			[]Step{
+				{Action: "stageAll"},
+				{Action: "commit", Args: []string{"my message"}},
+				{Action: "tag", Args: []string{"v1"}},
+				{Action: "push", Args: []string{"force"}},
+			},
+			"",
+			[]string{
+				"StageAll",
+				"Commit my message",
+				`Tag v1 ""`,
+				"Push true",
+			},
+			"",
+		},
+		{
+			"Optional arguments",
+			[]Step{
+				{Action: "createBranch", Args: []string{"new", "main"}},
+				{Action: "deleteBranch", Args: []string{"old"}},
+				{Action: "fetch", Args: []string{"upstream"}},
+				{Action: "reset", Args: []string{"HEAD~1"}},
+			},
+			"",
+			[]string{
+				`CreateBranch new "main"`,
+				"DeleteBranch old false",
+				`Fetch "upstream"`,
+				"Reset HEAD~1 mixed",
+			},
+			"",
+		},
+		{
+			"Stops at the first failing step",
+			[]Step{
+				{Action: "checkout", Args: []string{"feature"}},
+				{Action: "merge", Args: []string{"main"}},
+				{Action: "tag", Args: []string{"v1"}},
+			},
+			"Merge main",
+			[]string{
+				"Checkout feature",
+				"Merge main",
+			},
+			"step 2 (merge main) failed: conflict",
+		},
+		{
+			"Unknown reset strength",
+			[]Step{{Action: "reset", Args: []string{"HEAD~1", "gentle"}}},
+			"",
+			nil,
+			"step 1 (reset HEAD~1 gentle) failed: unknown reset strength 'gentle'",
+		},
+		{
+			"Runs nothing if a step is invalid",
+			[]Step{
+				{Action: "checkout", Args: []string{"feature"}},
+				{Action: "checkout"},
+			},
+			"",
+			nil,
+			"step 2 (checkout) failed: expected arguments: checkout <ref>",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			handlers := &recordingHandlers{failOn: s.failOn}
+
+			err := Run(handlers, s.steps, &bytes.Buffer{})
+			if s.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, s.expectedError)
+
+				var stepError *StepError
+				assert.True(t, errors.As(err, &stepError))
+			}
+
+			assert.EqualValues(t, s.expectedCalls, handlers.calls)
+		})
+	}
+}
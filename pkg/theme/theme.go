@@ -36,6 +36,14 @@ var (
 	OptionsFgColor = style.New()
 
 	DiffTerminalColor = style.FgMagenta
+
+	// SyntaxHighlighting is whether code in diffs and files is syntax highlighted
+	SyntaxHighlighting = false
+
+	SyntaxKeywordColor = style.New()
+	SyntaxStringColor  = style.New()
+	SyntaxCommentColor = style.New()
+	SyntaxNumberColor  = style.New()
 )
 
 // UpdateTheme updates all theme variables
@@ -48,6 +56,12 @@ func UpdateTheme(themeConfig config.ThemeConfig) {
 	OptionsColor = GetGocuiStyle(themeConfig.OptionsTextColor)
 	OptionsFgColor = GetTextStyle(themeConfig.OptionsTextColor, false)
 
+	SyntaxHighlighting = themeConfig.SyntaxHighlighting.Enabled
+	SyntaxKeywordColor = GetTextStyle(themeConfig.SyntaxHighlighting.KeywordColor, false)
+	SyntaxStringColor = GetTextStyle(themeConfig.SyntaxHighlighting.StringColor, false)
+	SyntaxCommentColor = GetTextStyle(themeConfig.SyntaxHighlighting.CommentColor, false)
+	SyntaxNumberColor = GetTextStyle(themeConfig.SyntaxHighlighting.NumberColor, false)
+
 	isLightTheme := themeConfig.LightTheme
 	if isLightTheme {
 		DefaultTextColor = style.FgBlack
@@ -66,6 +66,7 @@ func localisedTitle(mApp *app.App, str string) string {
 		"remotes":        tr.RemotesTitle,
 		"reflogCommits":  tr.ReflogCommitsTitle,
 		"tags":           tr.TagsTitle,
+		"worktrees":      tr.WorktreesTitle,
 		"commitFiles":    tr.CommitFilesTitle,
 		"commitMessage":  tr.CommitMessageTitle,
 		"commits":        tr.CommitsTitle,
@@ -75,12 +76,14 @@ func localisedTitle(mApp *app.App, str string) string {
 		"main":           tr.MainTitle,
 		"patchBuilding":  tr.PatchBuildingTitle,
 		"merging":        tr.MergingTitle,
+		"blame":          tr.BlameTitle,
 		"normal":         tr.NormalTitle,
 		"staging":        tr.StagingTitle,
 		"menu":           tr.MenuTitle,
 		"search":         tr.SearchTitle,
 		"secondary":      tr.SecondaryTitle,
 		"stash":          tr.StashTitle,
+		"lostAndFound":   tr.LostAndFoundTitle,
 		"suggestions":    tr.SuggestionsTitle,
 		"extras":         tr.ExtrasTitle,
 	}